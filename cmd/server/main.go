@@ -14,5 +14,7 @@ func main() {
 	if err != nil {
 		log.Fatalln("Error loading .env the server hasn't been started")
 	}
-	server.CreateAndStartServer()
+	if err := server.CreateAndStartServer(); err != nil {
+		log.Fatalf("failed to start the server: %v", err)
+	}
 }
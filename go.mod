@@ -3,6 +3,7 @@ module dovakin0007.com/notes-grpc
 go 1.24.5
 
 require (
+	github.com/golang-jwt/jwt/v5 v5.3.1
 	google.golang.org/grpc v1.75.0
 	google.golang.org/protobuf v1.36.7
 )
@@ -0,0 +1,122 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type ctxKey struct{}
+
+// Identity is an authenticated caller: the actor it acts as and the groups
+// its token lists, which access grants can name.
+type Identity struct {
+	Actor  models.Actor
+	Groups []string
+}
+
+// NewContext returns a copy of ctx carrying the authenticated caller.
+func NewContext(ctx context.Context, caller Identity) context.Context {
+	return context.WithValue(ctx, ctxKey{}, caller)
+}
+
+// FromContext returns the caller stored by the interceptors, if any.
+func FromContext(ctx context.Context) (Identity, bool) {
+	caller, ok := ctx.Value(ctxKey{}).(Identity)
+	return caller, ok
+}
+
+// Claims are the token claims we read. The subject is the actor id; name and
+// picture fill in the actor's display name and avatar when present.
+type Claims struct {
+	Name    string   `json:"name,omitempty"`
+	Picture string   `json:"picture,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Verifier validates bearer tokens against a fixed set of keys.
+type Verifier struct {
+	keyfunc jwt.Keyfunc
+	parser  *jwt.Parser
+}
+
+// Option adds a claim check on top of signature and expiry validation.
+type Option = jwt.ParserOption
+
+func WithIssuer(iss string) Option   { return jwt.WithIssuer(iss) }
+func WithAudience(aud string) Option { return jwt.WithAudience(aud) }
+
+// NewHMACVerifier accepts HS256/384/512 tokens signed with secret.
+func NewHMACVerifier(secret []byte, opts ...Option) *Verifier {
+	return newVerifier(func(*jwt.Token) (any, error) { return secret, nil },
+		[]string{"HS256", "HS384", "HS512"}, opts)
+}
+
+// NewJWKSVerifier accepts RSA and ECDSA signed tokens whose kid is in the JWKS
+// file at path. The file is read once.
+func NewJWKSVerifier(path string, opts ...Option) (*Verifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading jwks: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return nil, err
+	}
+	return newVerifier(keys.keyfunc,
+		[]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}, opts), nil
+}
+
+func newVerifier(keyfunc jwt.Keyfunc, methods []string, opts []Option) *Verifier {
+	opts = append([]Option{jwt.WithValidMethods(methods), jwt.WithExpirationRequired()}, opts...)
+	return &Verifier{keyfunc: keyfunc, parser: jwt.NewParser(opts...)}
+}
+
+// Verify checks token and returns the caller it identifies.
+func (v *Verifier) Verify(token string) (Identity, error) {
+	var claims Claims
+	if _, err := v.parser.ParseWithClaims(token, &claims, v.keyfunc); err != nil {
+		return Identity{}, err
+	}
+	if claims.Subject == "" {
+		return Identity{}, errors.New("token has no subject")
+	}
+	caller := Identity{Actor: models.Actor{ID: claims.Subject}, Groups: claims.Groups}
+	if claims.Name != "" {
+		caller.Actor.DisplayName = &claims.Name
+	}
+	if claims.Picture != "" {
+		caller.Actor.AvatarURL = &claims.Picture
+	}
+	return caller, nil
+}
+
+// VerifierFromEnv builds a verifier from NOTES_JWT_JWKS_FILE or
+// NOTES_JWT_HMAC_SECRET, with optional NOTES_JWT_ISSUER and
+// NOTES_JWT_AUDIENCE checks. It returns nil when neither key is configured.
+func VerifierFromEnv() (*Verifier, error) {
+	var opts []Option
+	if iss := os.Getenv("NOTES_JWT_ISSUER"); iss != "" {
+		opts = append(opts, WithIssuer(iss))
+	}
+	if aud := os.Getenv("NOTES_JWT_AUDIENCE"); aud != "" {
+		opts = append(opts, WithAudience(aud))
+	}
+
+	jwksFile := os.Getenv("NOTES_JWT_JWKS_FILE")
+	secret := os.Getenv("NOTES_JWT_HMAC_SECRET")
+	switch {
+	case jwksFile != "" && secret != "":
+		return nil, errors.New("set only one of NOTES_JWT_JWKS_FILE and NOTES_JWT_HMAC_SECRET")
+	case jwksFile != "":
+		return NewJWKSVerifier(jwksFile, opts...)
+	case secret != "":
+		return NewHMACVerifier([]byte(secret), opts...), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,139 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	s, err := tok.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{"sub": "actor-1", "name": "Alice", "exp": time.Now().Add(time.Hour).Unix()}
+}
+
+func TestHMACVerifier(t *testing.T) {
+	v := auth.NewHMACVerifier([]byte("s3cret"), auth.WithIssuer("notes"))
+
+	claims := validClaims()
+	claims["iss"] = "notes"
+	caller, err := v.Verify(sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", claims))
+	require.NoError(t, err)
+	require.Equal(t, "actor-1", caller.Actor.ID)
+	require.Equal(t, "Alice", *caller.Actor.DisplayName)
+	require.Nil(t, caller.Actor.AvatarURL)
+
+	for name, tc := range map[string]struct {
+		key    []byte
+		mutate func(jwt.MapClaims)
+	}{
+		"wrong secret":  {[]byte("other"), func(jwt.MapClaims) {}},
+		"expired":       {[]byte("s3cret"), func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		"no expiry":     {[]byte("s3cret"), func(c jwt.MapClaims) { delete(c, "exp") }},
+		"wrong issuer":  {[]byte("s3cret"), func(c jwt.MapClaims) { c["iss"] = "someone-else" }},
+		"empty subject": {[]byte("s3cret"), func(c jwt.MapClaims) { c["sub"] = "" }},
+	} {
+		c := validClaims()
+		c["iss"] = "notes"
+		tc.mutate(c)
+		_, err := v.Verify(sign(t, jwt.SigningMethodHS256, tc.key, "", c))
+		require.Error(t, err, name)
+	}
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+func TestJWKSVerifier(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	set := map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.Bytes()), "y": b64(ecKey.Y.Bytes())},
+		{"kty": "oct", "kid": "ignored", "k": b64([]byte("x"))},
+	}}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	v, err := auth.NewJWKSVerifier(path)
+	require.NoError(t, err)
+
+	claims := validClaims()
+	claims["groups"] = []string{"hr", "security"}
+	caller, err := v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "rsa-1", claims))
+	require.NoError(t, err)
+	require.Equal(t, "actor-1", caller.Actor.ID)
+	require.Equal(t, []string{"hr", "security"}, caller.Groups)
+
+	_, err = v.Verify(sign(t, jwt.SigningMethodES256, ecKey, "ec-1", validClaims()))
+	require.NoError(t, err)
+
+	// Right key, wrong kid.
+	_, err = v.Verify(sign(t, jwt.SigningMethodRS256, rsaKey, "ec-1", validClaims()))
+	require.Error(t, err)
+	// HMAC tokens are not accepted by a JWKS verifier.
+	_, err = v.Verify(sign(t, jwt.SigningMethodHS256, []byte("x"), "ignored", validClaims()))
+	require.Error(t, err)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	v := auth.NewHMACVerifier([]byte("s3cret"))
+	intercept := auth.UnaryServerInterceptor(v)
+	info := &grpc.UnaryServerInfo{FullMethod: "/notes.v1.NoteService/CreateNote"}
+
+	var seen string
+	handler := func(ctx context.Context, req any) (any, error) {
+		caller, ok := auth.FromContext(ctx)
+		require.True(t, ok)
+		seen = caller.Actor.ID
+		return "ok", nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer "+sign(t, jwt.SigningMethodHS256, []byte("s3cret"), "", validClaims())))
+	_, err := intercept(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, "actor-1", seen)
+
+	_, err = intercept(context.Background(), nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic abc"))
+	_, err = intercept(ctx, nil, info, handler)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Health checks pass without credentials.
+	_, err = intercept(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"},
+		func(ctx context.Context, req any) (any, error) { return nil, nil })
+	require.NoError(t, err)
+}
@@ -0,0 +1,22 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store keeps attachment bytes. Keys are chosen by the caller and must be
+// safe to use as file names.
+type Store interface {
+	// Put writes everything from r under key and returns the number of bytes
+	// stored. A failed Put leaves nothing behind.
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+	// Get opens the blob for reading along with its size.
+	Get(ctx context.Context, key string) (io.ReadCloser, int64, error)
+	// Move renames a blob, replacing whatever is stored under to.
+	Move(ctx context.Context, from, to string) error
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,84 @@
+package blobstore_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/blobstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystem_PutGetDelete(t *testing.T) {
+	ctx := context.Background()
+	fs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	n, err := fs.Put(ctx, "abc123", strings.NewReader("hello blob"))
+	require.NoError(t, err)
+	require.Equal(t, int64(10), n)
+
+	rc, size, err := fs.Get(ctx, "abc123")
+	require.NoError(t, err)
+	body, err := io.ReadAll(rc)
+	rc.Close()
+	require.NoError(t, err)
+	require.Equal(t, int64(10), size)
+	require.Equal(t, "hello blob", string(body))
+
+	require.NoError(t, fs.Delete(ctx, "abc123"))
+	_, _, err = fs.Get(ctx, "abc123")
+	require.True(t, errors.Is(err, blobstore.ErrNotFound))
+
+	// Deleting a missing blob is not an error.
+	require.NoError(t, fs.Delete(ctx, "abc123"))
+}
+
+func TestFileSystem_Move(t *testing.T) {
+	ctx := context.Background()
+	fs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = fs.Put(ctx, "upload-1", strings.NewReader("new"))
+	require.NoError(t, err)
+	_, err = fs.Put(ctx, "f00d", strings.NewReader("old"))
+	require.NoError(t, err)
+
+	require.NoError(t, fs.Move(ctx, "upload-1", "f00d"))
+	rc, _, err := fs.Get(ctx, "f00d")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	require.Equal(t, "new", string(body))
+
+	_, _, err = fs.Get(ctx, "upload-1")
+	require.True(t, errors.Is(err, blobstore.ErrNotFound))
+	require.True(t, errors.Is(fs.Move(ctx, "upload-1", "f00d"), blobstore.ErrNotFound))
+}
+
+func TestFileSystem_RejectsUnsafeKeys(t *testing.T) {
+	fs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "ab", "../etc", "a/b/c", "x.y.z"} {
+		_, err := fs.Put(context.Background(), key, strings.NewReader("x"))
+		require.Error(t, err, key)
+	}
+}
+
+func TestFileSystem_FailedPutLeavesNothing(t *testing.T) {
+	ctx := context.Background()
+	fs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = fs.Put(ctx, "broken", io.MultiReader(strings.NewReader("partial"), errReader{}))
+	require.Error(t, err)
+	_, _, err = fs.Get(ctx, "broken")
+	require.True(t, errors.Is(err, blobstore.ErrNotFound))
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("stream broke") }
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Access checks read the caller that the auth interceptors put into ctx.
+// Without one (authentication disabled, background jobs) nothing is checked.
+//
+// A caller's role on a note is the highest of: owner if they wrote it, their
+// note grants, and their grants on the note's project. Grants apply to the
+// caller's actor id or to any group in their token.
+
+var roleRanks = map[models.Role]int{
+	models.RoleViewer: 1,
+	models.RoleEditor: 2,
+	models.RoleOwner:  3,
+}
+
+// principalMatch matches the grant rows aliased alias that apply to c.
+func principalMatch(alias string, c auth.Identity) sq.Sqlizer {
+	return sq.Expr(fmt.Sprintf(
+		"((%[1]s.principal_type = 'user' AND %[1]s.principal_id = ?) OR (%[1]s.principal_type = 'group' AND %[1]s.principal_id = ANY(?)))",
+		alias), c.Actor.ID, pq.Array(c.Groups))
+}
+
+// noteRankExpr is c's role rank on the note aliased n, 0 for no access.
+func noteRankExpr(c auth.Identity) sq.Sqlizer {
+	return sq.Expr(`GREATEST(
+		CASE WHEN n.author_id = ? THEN 3 ELSE 0 END,
+		COALESCE((SELECT MAX(role_rank(g.role)) FROM note_grants g WHERE g.note_id = n.id AND ?), 0),
+		COALESCE((SELECT MAX(role_rank(pg.role)) FROM project_grants pg WHERE pg.project_id = n.project_id AND ?), 0)
+	)`, c.Actor.ID, principalMatch("g", c), principalMatch("pg", c))
+}
+
+// visibleNotes limits a query over notes aliased n to the ones the caller in
+// ctx can read. It returns nil when there is no caller.
+func visibleNotes(ctx context.Context) sq.Sqlizer {
+	c, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return sq.Expr(`(n.author_id = ?
+		OR EXISTS (SELECT 1 FROM note_grants g WHERE g.note_id = n.id AND ?)
+		OR EXISTS (SELECT 1 FROM project_grants pg WHERE pg.project_id = n.project_id AND ?))`,
+		c.Actor.ID, principalMatch("g", c), principalMatch("pg", c))
+}
+
+// requireNoteRole checks that the caller has at least min on the note, in the
+// trash or not. Callers that cannot read the note get NotFound so that note
+// ids do not leak.
+func requireNoteRole(ctx context.Context, q sqlx.QueryerContext, noteID string, min models.Role) error {
+	c, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	query, args, err := psql.Select().Column(noteRankExpr(c)).
+		From("notes n").
+		Where(sq.Eq{"n.id": noteID}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	var rank int
+	if err := sqlx.GetContext(ctx, q, &rank, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.NotFound, "note not found")
+		}
+		return err
+	}
+	return checkRank(rank, min, "note")
+}
+
+// projectAccess returns c's role rank on a project. The project's owner
+// always ranks as owner.
+func projectAccess(ctx context.Context, q sqlx.QueryerContext, projectID string, c auth.Identity) (int, error) {
+	query, args, err := psql.Select().
+		Column(sq.Expr("GREATEST(CASE WHEN p.owner_id = ? THEN 3 ELSE 0 END, COALESCE(MAX(role_rank(pg.role)) FILTER (WHERE ?), 0))",
+			c.Actor.ID, principalMatch("pg", c))).
+		From("projects p").
+		LeftJoin("project_grants pg ON pg.project_id = p.id").
+		Where(sq.Eq{"p.id": projectID}).
+		GroupBy("p.id").
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	var rank int
+	if err := q.QueryRowxContext(ctx, query, args...).Scan(&rank); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, status.Error(codes.NotFound, "project not found")
+		}
+		return 0, err
+	}
+	return rank, nil
+}
+
+// requireProjectRole checks that the caller has at least min on the project.
+func requireProjectRole(ctx context.Context, q sqlx.QueryerContext, projectID string, min models.Role) error {
+	c, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	rank, err := projectAccess(ctx, q, projectID, c)
+	if err != nil {
+		return err
+	}
+	return checkRank(rank, min, "project")
+}
+
+// visibleProjects limits a query over projects aliased p to the ones the
+// caller in ctx owns, has a grant on, or can read a note in. It returns nil
+// when there is no caller.
+func visibleProjects(ctx context.Context) sq.Sqlizer {
+	c, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return sq.Expr(`(p.owner_id = ?
+		OR EXISTS (SELECT 1 FROM project_grants pg WHERE pg.project_id = p.id AND ?)
+		OR EXISTS (SELECT 1 FROM notes n WHERE n.project_id = p.id AND ?))`,
+		c.Actor.ID, principalMatch("pg", c), visibleNotes(ctx))
+}
+
+// requireSavedSearchRole checks that the caller has at least min on a saved
+// search: owner when they created it, else their best grant on it.
+func requireSavedSearchRole(ctx context.Context, q sqlx.QueryerContext, id string, min models.Role) error {
+	c, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	query, args, err := psql.Select().Column(savedSearchRankExpr(c)).
+		From("saved_searches s").
+		Where(sq.Eq{"s.id": id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	var rank int
+	if err := sqlx.GetContext(ctx, q, &rank, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.NotFound, "saved search not found")
+		}
+		return err
+	}
+	return checkRank(rank, min, "saved search")
+}
+
+// savedSearchRankExpr is c's role rank on the saved search aliased s, 0 for
+// no access.
+func savedSearchRankExpr(c auth.Identity) sq.Sqlizer {
+	return sq.Expr(`GREATEST(
+		CASE WHEN s.owner_id = ? THEN 3 ELSE 0 END,
+		COALESCE((SELECT MAX(role_rank(sg.role)) FROM saved_search_grants sg WHERE sg.saved_search_id = s.id AND ?), 0)
+	)`, c.Actor.ID, principalMatch("sg", c))
+}
+
+func checkRank(rank int, min models.Role, resource string) error {
+	if rank == 0 {
+		return status.Errorf(codes.NotFound, "%s not found", resource)
+	}
+	if rank < roleRanks[min] {
+		return status.Errorf(codes.PermissionDenied, "%s access to the %s is required", min, resource)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func callerCtx(id string, groups ...string) context.Context {
+	return auth.NewContext(context.Background(), auth.Identity{Actor: models.Actor{ID: id}, Groups: groups})
+}
+
+func expectNoteRank(mock sqlmock.Sqlmock, noteID string, rank int) {
+	mock.ExpectQuery(`(?s)SELECT GREATEST\(.*FROM notes n WHERE n\.id = \$\d+`).
+		WillReturnRows(sqlmock.NewRows([]string{"greatest"}).AddRow(rank))
+}
+
+func TestUpdateNote_ViewerIsDenied(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNoteRank(mock, "note-1", 1)
+	mock.ExpectRollback()
+
+	_, err := d.UpdateNote(callerCtx("bob", "hr"), models.UpdateNoteInput{NoteID: "note-1", Title: ptrString("x")})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteNote_HiddenNoteIsNotFound(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNoteRank(mock, "note-1", 0)
+	mock.ExpectRollback()
+
+	_, err := d.DeleteNote(callerCtx("bob"), models.DeleteNoteInput{NoteID: "note-1"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotes_OnlyVisibleNotes(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)WHERE n\.deleted_at IS NULL AND \(n\.author_id = \$1\s+OR EXISTS \(SELECT 1 FROM note_grants g .*principal_id = ANY\(\$3\).*OR EXISTS \(SELECT 1 FROM project_grants pg`).
+		WithArgs("bob", "bob", sqlmock.AnyArg(), "bob", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "author_id"}))
+
+	notes, _, err := d.ListNotes(callerCtx("bob", "hr"), models.ListNotesFilter{})
+	require.NoError(t, err)
+	require.Empty(t, notes)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CheckNoteRole fails with the error the note methods would give unless the
+// caller in ctx has at least min on noteID and the note is not in the trash.
+// It lets callers refuse work, such as receiving an upload, up front.
+func (d *Database) CheckNoteRole(ctx context.Context, noteID string, min models.Role) error {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	if err := requireNoteRole(ctx, d.Db, noteID, min); err != nil {
+		return err
+	}
+	var live bool
+	if err := d.Db.GetContext(ctx, &live, `SELECT EXISTS(SELECT 1 FROM notes WHERE id=$1 AND deleted_at IS NULL)`, noteID); err != nil {
+		return err
+	}
+	if !live {
+		return status.Error(codes.NotFound, "note not found")
+	}
+	return nil
+}
+
+// CreateAttachment links an uploaded blob to a note that is not in the trash.
+func (d *Database) CreateAttachment(ctx context.Context, a models.Attachment) (*models.Attachment, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+
+	if err := requireNoteRole(ctx, d.Db, a.NoteID, models.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// The inner select keeps "?" placeholders, psql renumbers them for the whole statement.
+	row := sq.Select().
+		Column("?", a.ID).
+		Column("n.id").
+		Column("?", a.URL).
+		Column("?", a.FileName).
+		Column("?", a.FileType).
+		Column("?", a.SHA256).
+		Column("?", a.SizeBytes).
+		Column("?", a.BlobKey).
+		From("notes n").
+		Where(sq.Eq{"n.id": a.NoteID}).
+		Where("n.deleted_at IS NULL")
+	query, args, err := psql.Insert("attachments").
+		Columns("id", "note_id", "url", "file_name", "file_type", "sha256", "size_bytes", "blob_key").
+		Select(row).
+		Suffix("RETURNING uploaded_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	out := a
+	if err := d.Db.GetContext(ctx, &out.UploadedAt, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "note not found")
+		}
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAttachment returns an attachment unless its note is in the trash or
+// hidden from the caller.
+func (d *Database) GetAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	q := psql.Select("x.id", "x.note_id", "x.url", "x.file_name", "x.file_type", "x.uploaded_at", "x.sha256", "x.size_bytes", "x.blob_key").
+		From("attachments x").
+		Join("notes n ON n.id = x.note_id").
+		Where(sq.Eq{"x.id": id}).
+		Where("n.deleted_at IS NULL")
+	if visible := visibleNotes(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var a models.Attachment
+	if err := d.Db.GetContext(ctx, &a, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "attachment not found")
+		}
+		return nil, err
+	}
+	return &a, nil
+}
@@ -0,0 +1,94 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateAttachment(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	a := models.Attachment{
+		ID:        "att-1",
+		NoteID:    "note-1",
+		URL:       "attachment://att-1",
+		FileName:  "a.txt",
+		FileType:  "text/plain",
+		SHA256:    ptrString("abc"),
+		SizeBytes: ptrInt64(5),
+		BlobKey:   ptrString("att-1"),
+	}
+	uploaded := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO attachments (id,note_id,url,file_name,file_type,sha256,size_bytes,blob_key) SELECT $1, n.id, $2, $3, $4, $5, $6, $7 FROM notes n WHERE n.id = $8 AND n.deleted_at IS NULL RETURNING uploaded_at")).
+		WithArgs(a.ID, a.URL, a.FileName, a.FileType, a.SHA256, a.SizeBytes, a.BlobKey, a.NoteID).
+		WillReturnRows(sqlmock.NewRows([]string{"uploaded_at"}).AddRow(uploaded))
+
+	out, err := d.CreateAttachment(context.Background(), a)
+	require.NoError(t, err)
+	require.Equal(t, uploaded, out.UploadedAt)
+	require.Equal(t, "att-1", out.ID)
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO attachments")).
+		WillReturnRows(sqlmock.NewRows([]string{"uploaded_at"}))
+	_, err = d.CreateAttachment(context.Background(), a)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAttachment_NotFound(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)^SELECT .* FROM attachments x JOIN notes n ON n\.id = x\.note_id WHERE x\.id = \$1 AND n\.deleted_at IS NULL`).
+		WithArgs("att-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := d.GetAttachment(context.Background(), "att-1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNote_AttachmentIDOfAnotherNote(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("WHERE attachments.note_id = EXCLUDED.note_id AND attachments.blob_key IS NULL")).
+		WithArgs("att-of-note-2", "note-1", "https://evil", "x", "text/plain", sqlmock.AnyArg(), nil, nil).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := d.UpdateNote(context.Background(), models.UpdateNoteInput{
+		NoteID:      "note-1",
+		Attachments: []models.Attachment{{ID: "att-of-note-2", NoteID: "note-1", URL: "https://evil", FileName: "x", FileType: "text/plain"}},
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheckNoteRole(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	expectNoteRank(mock, "note-1", 1)
+	err := d.CheckNoteRole(callerCtx("bob"), "note-1", models.RoleEditor)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM notes WHERE id=$1 AND deleted_at IS NULL)")).
+		WithArgs("note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	err = d.CheckNoteRole(context.Background(), "note-1", models.RoleEditor)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
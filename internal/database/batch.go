@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Batch calls run every item in one transaction under one acquisition of Mu.
+// Without partial the first failing item aborts the whole batch and its error
+// is returned. With partial each item runs under a savepoint: a failing item
+// is rolled back on its own and its error lands in its result slot.
+
+// BatchGetNotes loads notes from one snapshot.
+func (d *Database) BatchGetNotes(ctx context.Context, ids []string, opts models.GetNoteOptions, partial bool) ([]models.BatchResult, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+	txOpts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+	return d.runBatch(ctx, txOpts, len(ids), partial, func(tx *sqlx.Tx, i int) (*models.Note, error) {
+		return viewNote(ctx, tx, ids[i], opts)
+	})
+}
+
+func (d *Database) BatchCreateNotes(ctx context.Context, ins []models.CreateNoteInput, partial bool) ([]models.BatchResult, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	return d.runBatch(ctx, &sql.TxOptions{}, len(ins), partial, func(tx *sqlx.Tx, i int) (*models.Note, error) {
+		return d.createNote(ctx, tx, ins[i])
+	})
+}
+
+// BatchUpdateNotes returns each note as it is after its update.
+func (d *Database) BatchUpdateNotes(ctx context.Context, ins []models.UpdateNoteInput, partial bool) ([]models.BatchResult, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	return d.runBatch(ctx, &sql.TxOptions{}, len(ins), partial, func(tx *sqlx.Tx, i int) (*models.Note, error) {
+		if err := applyNoteUpdate(ctx, tx, ins[i]); err != nil {
+			return nil, err
+		}
+		return viewNote(ctx, tx, ins[i].NoteID, models.GetNoteOptions{IncludeAttachments: true})
+	})
+}
+
+// BatchDeleteNotes reports notes that did not exist as NotFound items.
+func (d *Database) BatchDeleteNotes(ctx context.Context, ins []models.DeleteNoteInput, partial bool) ([]models.BatchResult, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	return d.runBatch(ctx, &sql.TxOptions{}, len(ins), partial, func(tx *sqlx.Tx, i int) (*models.Note, error) {
+		deleted, err := deleteNote(ctx, tx, ins[i])
+		if err != nil {
+			return nil, err
+		}
+		if !deleted {
+			return nil, status.Error(codes.NotFound, "note not found")
+		}
+		return nil, nil
+	})
+}
+
+func (d *Database) runBatch(ctx context.Context, opts *sql.TxOptions, n int, partial bool, item func(tx *sqlx.Tx, i int) (*models.Note, error)) ([]models.BatchResult, error) {
+	tx, err := d.Db.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	results := make([]models.BatchResult, n)
+	for i := range results {
+		if !partial {
+			note, err := item(tx, i)
+			if err != nil {
+				return nil, batchItemError(i, err)
+			}
+			results[i].Note = note
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT batch_item"); err != nil {
+			return nil, err
+		}
+		note, err := item(tx, i)
+		if err != nil {
+			results[i].Err = err
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT batch_item"); err != nil {
+				return nil, err
+			}
+		} else {
+			results[i].Note = note
+		}
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT batch_item"); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// batchItemError says which item aborted a batch, keeping its status code.
+func batchItemError(i int, err error) error {
+	if st, ok := status.FromError(err); ok {
+		return status.Errorf(st.Code(), "item %d: %s", i, st.Message())
+	}
+	return fmt.Errorf("item %d: %w", i, err)
+}
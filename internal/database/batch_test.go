@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"regexp"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchDeleteNotes_PartialFailure(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT batch_item")).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectNoteRank(mock, "note-1", 2)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at = NOW()")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectNoteEvent(mock, models.NoteEventDeleted, "note-1")
+	mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT batch_item")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("SAVEPOINT batch_item")).WillReturnResult(sqlmock.NewResult(0, 0))
+	expectNoteRank(mock, "note-2", 0)
+	mock.ExpectExec(regexp.QuoteMeta("ROLLBACK TO SAVEPOINT batch_item")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("RELEASE SAVEPOINT batch_item")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	results, err := d.BatchDeleteNotes(callerCtx("bob"), []models.DeleteNoteInput{
+		{NoteID: "note-1"},
+		{NoteID: "note-2"},
+	}, true)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NoError(t, results[0].Err)
+	require.Equal(t, codes.NotFound, status.Code(results[1].Err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBatchDeleteNotes_AllOrNothing(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNoteRank(mock, "note-1", 2)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at = NOW()")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectNoteEvent(mock, models.NoteEventDeleted, "note-1")
+	expectNoteRank(mock, "note-2", 1)
+	mock.ExpectRollback()
+
+	_, err := d.BatchDeleteNotes(callerCtx("bob"), []models.DeleteNoteInput{
+		{NoteID: "note-1"},
+		{NoteID: "note-2"},
+	}, false)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "item 1")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
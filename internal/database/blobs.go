@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blobCollectBatch caps how many orphaned blobs one CollectBlobs call removes.
+const blobCollectBatch = 100
+
+// ClaimBlob records a blob before its bytes are moved into place. A blob that
+// has no references yet gets a fresh orphaned_at, so the collector leaves it
+// alone for a full grace period while the attachment row is written.
+func (d *Database) ClaimBlob(ctx context.Context, key string, size int64) error {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+
+	query, args, err := psql.Insert("blobs").
+		Columns("key", "size_bytes", "orphaned_at").
+		Values(key, size, sq.Expr("NOW()")).
+		Suffix("ON CONFLICT (key) DO UPDATE SET orphaned_at = CASE WHEN blobs.ref_count = 0 THEN NOW() ELSE NULL END").
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if _, err := d.Db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("claiming blob: %w", err)
+	}
+	return nil
+}
+
+// CollectBlobs removes blobs that have had no references since before cutoff.
+// remove deletes the bytes; when it fails the row is kept and retried on the
+// next run. The lock is held throughout so a concurrent ClaimBlob either
+// rescues the blob or runs after its bytes are gone.
+func (d *Database) CollectBlobs(ctx context.Context, cutoff time.Time, remove func(ctx context.Context, key string) error) (int, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+
+	query, args, err := psql.Select("key").
+		From("blobs").
+		Where("ref_count = 0").
+		Where(sq.Lt{"orphaned_at": cutoff}).
+		OrderBy("orphaned_at").
+		Limit(blobCollectBatch).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	var keys []string
+	if err := d.Db.SelectContext(ctx, &keys, query, args...); err != nil {
+		return 0, fmt.Errorf("listing orphaned blobs: %w", err)
+	}
+
+	collected := 0
+	for _, key := range keys {
+		ok, err := d.collectBlob(ctx, key, cutoff, remove)
+		if err != nil {
+			return collected, err
+		}
+		if ok {
+			collected++
+		}
+	}
+	return collected, nil
+}
+
+func (d *Database) collectBlob(ctx context.Context, key string, cutoff time.Time, remove func(ctx context.Context, key string) error) (bool, error) {
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	query, args, err := psql.Delete("blobs").
+		Where(sq.Eq{"key": key}).
+		Where("ref_count = 0").
+		Where(sq.Lt{"orphaned_at": cutoff}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("deleting blob row: %w", err)
+	}
+	if ra, err := res.RowsAffected(); err != nil {
+		return false, err
+	} else if ra == 0 {
+		return false, nil
+	}
+
+	if err := remove(ctx, key); err != nil {
+		return false, fmt.Errorf("removing blob %s: %w", key, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit failed after blob collect: %w", err)
+	}
+	return true, nil
+}
+
+// RunBlobCollector calls CollectBlobs every interval for blobs orphaned longer
+// than grace until ctx is done.
+func (d *Database) RunBlobCollector(ctx context.Context, grace, interval time.Duration, remove func(ctx context.Context, key string) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := d.CollectBlobs(ctx, time.Now().Add(-grace), remove)
+			if err != nil {
+				log.Printf("blob collection failed: %v", err)
+			}
+			if n > 0 {
+				log.Printf("collected %d orphaned blobs", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimBlob(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO blobs (key,size_bytes,orphaned_at) VALUES ($1,$2,NOW()) ON CONFLICT (key) DO UPDATE SET orphaned_at = CASE WHEN blobs.ref_count = 0 THEN NOW() ELSE NULL END")).
+		WithArgs("abc", int64(12)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, d.ClaimBlob(context.Background(), "abc", 12))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCollectBlobs(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	cutoff := time.Now().Add(-time.Hour)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT key FROM blobs WHERE ref_count = 0 AND orphaned_at < $1 ORDER BY orphaned_at LIMIT 100")).
+		WithArgs(cutoff).
+		WillReturnRows(sqlmock.NewRows([]string{"key"}).AddRow("aaa").AddRow("bbb").AddRow("ccc"))
+
+	deleteRow := regexp.QuoteMeta("DELETE FROM blobs WHERE key = $1 AND ref_count = 0 AND orphaned_at < $2")
+	// aaa is removed.
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteRow).WithArgs("aaa", cutoff).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	// bbb was claimed again since it was listed.
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteRow).WithArgs("bbb", cutoff).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	// ccc cannot be removed from the blob store, so its row stays.
+	mock.ExpectBegin()
+	mock.ExpectExec(deleteRow).WithArgs("ccc", cutoff).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+
+	var removed []string
+	n, err := d.CollectBlobs(context.Background(), cutoff, func(ctx context.Context, key string) error {
+		if key == "ccc" {
+			return errors.New("disk on fire")
+		}
+		removed = append(removed, key)
+		return nil
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{"aaa"}, removed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -32,12 +33,57 @@ var (
 	port       = os.Getenv("POSTGRES_PORT")
 )
 
+// upsertActorSuffix refreshes an actor's profile on every write. Actors coming
+// from tokens without name or picture claims leave the stored values alone.
+const upsertActorSuffix = "ON CONFLICT (id) DO UPDATE SET display_name=COALESCE(EXCLUDED.display_name, actors.display_name), avatar_url=COALESCE(EXCLUDED.avatar_url, actors.avatar_url)"
+
 type Store interface {
 	CreateNote(ctx context.Context, in models.CreateNoteInput) (*models.Note, error)
 	UpdateNote(ctx context.Context, in models.UpdateNoteInput) (*models.Note, error)
 	ListNotes(ctx context.Context, in models.ListNotesFilter) ([]models.Note, string, error)
+	StreamNotes(ctx context.Context, filter models.ListNotesFilter, fn func(models.Note) error) error
 	ViewNote(ctx context.Context, id string, opts models.GetNoteOptions) (*models.Note, error)
-	DeleteNote(ctx context.Context, id string, hard bool) (bool, error)
+	DeleteNote(ctx context.Context, in models.DeleteNoteInput) (bool, error)
+	MoveNotes(ctx context.Context, in models.MoveNotesInput) ([]models.Note, error)
+	BatchGetNotes(ctx context.Context, ids []string, opts models.GetNoteOptions, partial bool) ([]models.BatchResult, error)
+	BatchCreateNotes(ctx context.Context, ins []models.CreateNoteInput, partial bool) ([]models.BatchResult, error)
+	BatchUpdateNotes(ctx context.Context, ins []models.UpdateNoteInput, partial bool) ([]models.BatchResult, error)
+	BatchDeleteNotes(ctx context.Context, ins []models.DeleteNoteInput, partial bool) ([]models.BatchResult, error)
+	RestoreNote(ctx context.Context, id string) (*models.Note, error)
+	PurgeNote(ctx context.Context, id string) (bool, error)
+	ListNoteRevisions(ctx context.Context, in models.ListNoteRevisionsFilter) ([]models.NoteRevision, string, error)
+	RestoreNoteRevision(ctx context.Context, in models.RestoreNoteRevisionInput) (*models.Note, error)
+	GetNoteRevision(ctx context.Context, noteID, revisionID string) (*models.NoteRevision, error)
+	CheckNoteRole(ctx context.Context, noteID string, min models.Role) error
+	CreateAttachment(ctx context.Context, a models.Attachment) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, id string) (*models.Attachment, error)
+	ClaimBlob(ctx context.Context, key string, size int64) error
+	ListNoteEvents(ctx context.Context, filter models.NoteEventFilter) ([]models.NoteEvent, error)
+	LatestNoteEventID(ctx context.Context) (int64, error)
+	SubscribeNoteEvents() (<-chan struct{}, func())
+	CreateProject(ctx context.Context, in models.CreateProjectInput) (*models.Project, error)
+	GetProject(ctx context.Context, id string) (*models.Project, error)
+	ListProjects(ctx context.Context, filter models.ListProjectsFilter) ([]models.Project, string, error)
+	UpdateProject(ctx context.Context, in models.UpdateProjectInput) (*models.Project, error)
+	ArchiveProject(ctx context.Context, id string) (*models.Project, error)
+	ShareNote(ctx context.Context, noteID string, g models.Grant) (*models.Grant, error)
+	UnshareNote(ctx context.Context, noteID string, p models.Principal) (bool, error)
+	ListNoteGrants(ctx context.Context, noteID string) ([]models.Grant, error)
+	ShareProject(ctx context.Context, projectID string, g models.Grant) (*models.Grant, error)
+	UnshareProject(ctx context.Context, projectID string, p models.Principal) (bool, error)
+	ListProjectGrants(ctx context.Context, projectID string) ([]models.Grant, error)
+	ListTags(ctx context.Context, filter models.ListTagsFilter) ([]models.TagCount, error)
+	MergeTags(ctx context.Context, in models.MergeTagsInput) (int, error)
+	DeleteTag(ctx context.Context, projectID *string, tag string) (int, error)
+	SearchTitles(ctx context.Context, filter models.SearchTitlesFilter) ([]models.TitleMatch, []models.Suggestion, error)
+	CountNotes(ctx context.Context, filter models.ListNotesFilter) (int64, error)
+	CreateSavedSearch(ctx context.Context, in models.CreateSavedSearchInput) (*models.SavedSearch, error)
+	GetSavedSearch(ctx context.Context, id string) (*models.SavedSearch, error)
+	ListSavedSearches(ctx context.Context, filter models.ListSavedSearchesFilter) ([]models.SavedSearch, string, error)
+	DeleteSavedSearch(ctx context.Context, id string) (bool, error)
+	ShareSavedSearch(ctx context.Context, id string, g models.Grant) (*models.Grant, error)
+	UnshareSavedSearch(ctx context.Context, id string, p models.Principal) (bool, error)
+	GetRelatedNotes(ctx context.Context, noteID string, limit int) ([]models.RelatedNote, error)
 }
 
 const ddl = `
@@ -59,6 +105,12 @@ CREATE TABLE IF NOT EXISTS notes (
     updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS deleted_by TEXT REFERENCES actors(id) ON DELETE SET NULL;
+-- last_editor_id is who wrote the current title and content, NULL for the
+-- author.
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS last_editor_id TEXT REFERENCES actors(id) ON DELETE SET NULL;
+
 CREATE OR REPLACE FUNCTION set_updated_at() RETURNS trigger AS $$
 BEGIN
     NEW.updated_at = NOW();
@@ -97,21 +149,329 @@ CREATE TABLE IF NOT EXISTS attachments (
     size_bytes  BIGINT
 );
 
+ALTER TABLE attachments ADD COLUMN IF NOT EXISTS blob_key TEXT;
+
+-- Uploaded bytes are keyed by their SHA-256 and shared between attachments.
+-- ref_count is kept in sync by trg_attachments_blob_refs; blobs that drop to
+-- zero references get an orphaned_at and are collected after a grace period.
+CREATE TABLE IF NOT EXISTS blobs (
+    key          TEXT PRIMARY KEY,
+    size_bytes   BIGINT NOT NULL DEFAULT 0,
+    ref_count    BIGINT NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    orphaned_at  TIMESTAMPTZ
+);
+
+INSERT INTO blobs (key, size_bytes, ref_count)
+SELECT blob_key, COALESCE(MAX(size_bytes), 0), COUNT(*)
+FROM attachments
+WHERE blob_key IS NOT NULL
+GROUP BY blob_key
+ON CONFLICT (key) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION count_blob_refs() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' AND NEW.blob_key IS NOT NULL THEN
+        UPDATE blobs SET ref_count = ref_count + 1, orphaned_at = NULL
+        WHERE key = NEW.blob_key;
+    ELSIF TG_OP = 'DELETE' AND OLD.blob_key IS NOT NULL THEN
+        UPDATE blobs SET ref_count = ref_count - 1,
+            orphaned_at = CASE WHEN ref_count = 1 THEN NOW() ELSE orphaned_at END
+        WHERE key = OLD.blob_key;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_attachments_blob_refs ON attachments;
+CREATE TRIGGER trg_attachments_blob_refs
+AFTER INSERT OR DELETE ON attachments
+FOR EACH ROW EXECUTE FUNCTION count_blob_refs();
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    author_id    TEXT NOT NULL REFERENCES actors(id) ON DELETE CASCADE,
+    key          TEXT NOT NULL,
+    fingerprint  TEXT NOT NULL,
+    note_id      TEXT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (author_id, key)
+);
+
+-- Sharing. Authors always own their notes; everyone else needs a grant on the
+-- note or on its project, either directly or through one of their groups.
+CREATE OR REPLACE FUNCTION role_rank(role TEXT) RETURNS INT AS $$
+    SELECT CASE role WHEN 'owner' THEN 3 WHEN 'editor' THEN 2 WHEN 'viewer' THEN 1 ELSE 0 END
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE TABLE IF NOT EXISTS note_grants (
+    note_id         TEXT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    principal_type  TEXT NOT NULL CHECK (principal_type IN ('user', 'group')),
+    principal_id    TEXT NOT NULL,
+    role            TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    granted_by      TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (note_id, principal_type, principal_id)
+);
+
+-- Projects used to be free-form strings on notes. Every id already in use
+-- becomes a project named after itself, owned by the author of its oldest
+-- note, before notes.project_id starts referencing the table.
+CREATE TABLE IF NOT EXISTS projects (
+    id           TEXT PRIMARY KEY,
+    name         TEXT NOT NULL,
+    description  TEXT,
+    owner_id     TEXT NOT NULL REFERENCES actors(id),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    archived_at  TIMESTAMPTZ
+);
+
+INSERT INTO projects (id, name, owner_id, created_at)
+SELECT DISTINCT ON (project_id) project_id, project_id, author_id, created_at
+FROM notes
+WHERE project_id IS NOT NULL
+ORDER BY project_id, created_at, id
+ON CONFLICT (id) DO NOTHING;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'notes_project_id_fkey') THEN
+        ALTER TABLE notes ADD CONSTRAINT notes_project_id_fkey
+            FOREIGN KEY (project_id) REFERENCES projects(id);
+    END IF;
+END
+$$;
+
+CREATE TABLE IF NOT EXISTS project_grants (
+    project_id      TEXT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    principal_type  TEXT NOT NULL CHECK (principal_type IN ('user', 'group')),
+    principal_id    TEXT NOT NULL,
+    role            TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    granted_by      TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, principal_type, principal_id)
+);
+
+-- Grants made before projects were a table may point at ids that never
+-- became one; they are dropped before the key is added.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'project_grants_project_id_fkey') THEN
+        DELETE FROM project_grants pg
+        WHERE NOT EXISTS (SELECT 1 FROM projects p WHERE p.id = pg.project_id);
+        ALTER TABLE project_grants ADD CONSTRAINT project_grants_project_id_fkey
+            FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE;
+    END IF;
+END
+$$;
+
+DROP TRIGGER IF EXISTS trg_projects_set_updated_at ON projects;
+CREATE TRIGGER trg_projects_set_updated_at
+BEFORE UPDATE ON projects
+FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Audit trail of notes moving between projects. NULL project ids stand for
+-- "no project"; moved_by is kept as text so the trail survives its actor.
+CREATE TABLE IF NOT EXISTS note_moves (
+    id               BIGSERIAL PRIMARY KEY,
+    note_id          TEXT NOT NULL REFERENCES notes(id) ON DELETE CASCADE,
+    from_project_id  TEXT,
+    to_project_id    TEXT,
+    moved_by         TEXT,
+    moved_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Outbox of note changes feeding WatchNotes. Rows outlive their notes, so
+-- there are no foreign keys. Each insert is announced on the note_events
+-- channel so watchers wake up without waiting for their next poll.
+CREATE TABLE IF NOT EXISTS note_events (
+    id           BIGSERIAL PRIMARY KEY,
+    type         TEXT NOT NULL,
+    note_id      TEXT NOT NULL,
+    project_id   TEXT,
+    author_id    TEXT NOT NULL,
+    tags         TEXT[] NOT NULL DEFAULT '{}',
+    occurred_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION notify_note_event() RETURNS trigger AS $$
+BEGIN
+    PERFORM pg_notify('note_events', NEW.id::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_note_events_notify ON note_events;
+CREATE TRIGGER trg_note_events_notify
+AFTER INSERT ON note_events
+FOR EACH ROW EXECUTE FUNCTION notify_note_event();
+
 CREATE INDEX IF NOT EXISTS idx_notes_project_id    ON notes(project_id);
 CREATE INDEX IF NOT EXISTS idx_notes_author_id     ON notes(author_id);
 CREATE INDEX IF NOT EXISTS idx_notes_is_pinned     ON notes(is_pinned);
-
-CREATE INDEX IF NOT EXISTS idx_notes_fts
-ON notes
-USING GIN (to_tsvector('english', coalesce(title,'') || ' ' || coalesce(content,'')));
+CREATE INDEX IF NOT EXISTS idx_notes_deleted_at    ON notes(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_blobs_orphaned_at    ON blobs(orphaned_at) WHERE ref_count = 0;
+CREATE INDEX IF NOT EXISTS idx_note_events_occurred_at ON note_events(occurred_at);
+CREATE INDEX IF NOT EXISTS idx_note_grants_principal    ON note_grants(principal_type, principal_id);
+CREATE INDEX IF NOT EXISTS idx_project_grants_principal ON project_grants(principal_type, principal_id);
+CREATE INDEX IF NOT EXISTS idx_projects_owner_id        ON projects(owner_id);
+CREATE INDEX IF NOT EXISTS idx_note_moves_note_id       ON note_moves(note_id);
+
+-- language is the text search configuration a note is stemmed with. Notes
+-- created in a project with a language take it, the rest use english.
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS language REGCONFIG;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS language REGCONFIG NOT NULL DEFAULT 'english';
+
+-- search_vector is what ListNotes searches, titles weighted above content.
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS search_vector TSVECTOR
+GENERATED ALWAYS AS (
+    setweight(to_tsvector(language, coalesce(title,'')), 'A') ||
+    setweight(to_tsvector(language, coalesce(content,'')), 'B')
+) STORED;
+
+DROP INDEX IF EXISTS idx_notes_fts;
+CREATE INDEX IF NOT EXISTS idx_notes_search_vector ON notes USING GIN (search_vector);
+
+-- Trigram indexes back the typo tolerant SearchTitles.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_notes_title_trgm ON notes USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_note_tags_tag_trgm ON note_tags USING GIN (tag gin_trgm_ops);
 
 
 CREATE INDEX IF NOT EXISTS idx_note_tags_tag ON note_tags(tag);
+
+-- normalize_tag mirrors utils.NormalizeTag: every slash-separated segment is
+-- lowercased, trimmed of all whitespace, not just spaces, and has inner runs
+-- collapsed to one space; empty segments are dropped.
+CREATE OR REPLACE FUNCTION normalize_tag(t TEXT) RETURNS TEXT AS $$
+    SELECT COALESCE(string_agg(s, '/' ORDER BY i), '')
+    FROM (
+        SELECT lower(regexp_replace(regexp_replace(seg, '^\s+|\s+$', '', 'g'), '\s+', ' ', 'g')) AS s, i
+        FROM unnest(string_to_array(t, '/')) WITH ORDINALITY AS u(seg, i)
+    ) segs
+    WHERE s <> ''
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Tags are written normalized; fold in rows from before that.
+INSERT INTO note_tags (note_id, tag)
+SELECT note_id, normalize_tag(tag)
+FROM note_tags
+WHERE tag <> normalize_tag(tag) AND normalize_tag(tag) <> ''
+ON CONFLICT DO NOTHING;
+DELETE FROM note_tags WHERE tag <> normalize_tag(tag);
+
+-- A saved search keeps a ListNotes request as protobuf JSON. Grants on it
+-- only let people run it; notes are still filtered by the runner's access.
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    owner_id    TEXT NOT NULL REFERENCES actors(id) ON DELETE CASCADE,
+    request     JSONB NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS saved_search_grants (
+    saved_search_id TEXT NOT NULL REFERENCES saved_searches(id) ON DELETE CASCADE,
+    principal_type  TEXT NOT NULL CHECK (principal_type IN ('user', 'group')),
+    principal_id    TEXT NOT NULL,
+    role            TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    granted_by      TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (saved_search_id, principal_type, principal_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_owner_id ON saved_searches(owner_id);
+CREATE INDEX IF NOT EXISTS idx_saved_search_grants_principal ON saved_search_grants(principal_type, principal_id);
+
+-- note_terms, term_stats and note_lengths index search_vector for the BM25
+-- scoring of GetRelatedNotes: term frequencies per note, how many notes have
+-- each term, and note lengths. trg_notes_index_terms keeps them current.
+-- Title occurrences count twice.
+CREATE TABLE IF NOT EXISTS note_terms (
+    note_id  TEXT NOT NULL,
+    term     TEXT NOT NULL,
+    tf       INT NOT NULL,
+    PRIMARY KEY (note_id, term)
+);
+CREATE INDEX IF NOT EXISTS idx_note_terms_term ON note_terms(term);
+
+CREATE TABLE IF NOT EXISTS term_stats (
+    term       TEXT PRIMARY KEY,
+    doc_count  INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS note_lengths (
+    note_id  TEXT PRIMARY KEY,
+    length   INT NOT NULL
+);
+
+CREATE OR REPLACE FUNCTION note_term_counts(v TSVECTOR) RETURNS TABLE (term TEXT, tf INT) AS $$
+    SELECT u.lexeme, COALESCE((SELECT SUM(CASE w WHEN 'A' THEN 2 ELSE 1 END) FROM unnest(u.weights) w), 1)::INT
+    FROM unnest(v) u
+$$ LANGUAGE sql IMMUTABLE;
+
+-- No foreign keys on the index tables: cascades would run before this
+-- trigger and leave term_stats counting deleted notes.
+CREATE OR REPLACE FUNCTION index_note_terms() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.search_vector IS NOT DISTINCT FROM NEW.search_vector THEN
+        RETURN NULL;
+    END IF;
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE term_stats s SET doc_count = s.doc_count - 1
+        FROM note_terms t
+        WHERE t.note_id = OLD.id AND s.term = t.term;
+        DELETE FROM note_terms WHERE note_id = OLD.id;
+        DELETE FROM note_lengths WHERE note_id = OLD.id;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        INSERT INTO note_terms (note_id, term, tf)
+        SELECT NEW.id, c.term, c.tf FROM note_term_counts(NEW.search_vector) c;
+        INSERT INTO term_stats (term, doc_count)
+        SELECT c.term, 1 FROM note_term_counts(NEW.search_vector) c ORDER BY c.term
+        ON CONFLICT (term) DO UPDATE SET doc_count = term_stats.doc_count + 1;
+        INSERT INTO note_lengths (note_id, length)
+        SELECT NEW.id, COALESCE(SUM(c.tf), 0) FROM note_term_counts(NEW.search_vector) c;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_notes_index_terms ON notes;
+CREATE TRIGGER trg_notes_index_terms
+AFTER INSERT OR UPDATE OR DELETE ON notes
+FOR EACH ROW EXECUTE FUNCTION index_note_terms();
+
+-- Index the notes written before the trigger existed.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM notes n WHERE NOT EXISTS (SELECT 1 FROM note_lengths l WHERE l.note_id = n.id)) THEN
+        INSERT INTO note_terms (note_id, term, tf)
+        SELECT n.id, c.term, c.tf
+        FROM notes n CROSS JOIN LATERAL note_term_counts(n.search_vector) c
+        WHERE NOT EXISTS (SELECT 1 FROM note_lengths l WHERE l.note_id = n.id);
+        INSERT INTO note_lengths (note_id, length)
+        SELECT n.id, COALESCE((SELECT SUM(c.tf) FROM note_term_counts(n.search_vector) c), 0)
+        FROM notes n
+        WHERE NOT EXISTS (SELECT 1 FROM note_lengths l WHERE l.note_id = n.id);
+        INSERT INTO term_stats (term, doc_count)
+        SELECT term, COUNT(*) FROM note_terms GROUP BY term
+        ON CONFLICT (term) DO UPDATE SET doc_count = EXCLUDED.doc_count;
+    END IF;
+END $$;
 `
 
 type Database struct {
 	Mu *sync.RWMutex
 	Db *sqlx.DB
+
+	// How long CreateNote idempotency keys are honored, 24h when unset.
+	IdempotencyTTL time.Duration
+
+	listenDSN string
+	events    eventHub
 }
 
 func GetDb() *Database {
@@ -125,8 +485,9 @@ func initDb() *Database {
 	connection_str := fmt.Sprintf("user=%s password=%s dbname=%s port=%s sslmode=disable", user, password, dbName, port)
 
 	Db := &Database{
-		Mu: &sync.RWMutex{},
-		Db: sqlx.MustConnect(driverName, connection_str),
+		Mu:        &sync.RWMutex{},
+		Db:        sqlx.MustConnect(driverName, connection_str),
+		listenDSN: connection_str,
 	}
 
 	Db.migrate(context.Background())
@@ -145,6 +506,7 @@ func (d *Database) migrate(ctx context.Context) {
 }
 
 func (d *Database) Close() error {
+	d.events.close()
 	if d.Db != nil {
 		return d.Db.Close()
 	}
@@ -166,7 +528,7 @@ func (d *Database) UpsertActor(ctx context.Context, actor models.Actor) error {
 	q := psql.Insert("actors").
 		Columns("id", "display_name", "avatar_url").
 		Values(actor.ID, actor.DisplayName, actor.AvatarURL).
-		Suffix("ON CONFLICT (id) DO UPDATE SET display_name=EXCLUDED.display_name, avatar_url=EXCLUDED.avatar_url")
+		Suffix(upsertActorSuffix)
 
 	sqlStr, args, err := q.ToSql()
 	if err != nil {
@@ -186,29 +548,66 @@ func (d *Database) CreateNote(ctx context.Context, in models.CreateNoteInput) (*
 	defer func() {
 		tx.Rollback()
 	}()
+	n, err := d.createNote(ctx, tx, in)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// createNote inserts a note and everything hanging off it inside tx. A
+// replayed idempotency key returns the note created the first time. The new
+// note lists the notes it nearly duplicates, see findDuplicates.
+func (d *Database) createNote(ctx context.Context, tx *sqlx.Tx, in models.CreateNoteInput) (*models.Note, error) {
+	in.Tags = utils.NormalizeTags(in.Tags)
 	aq := psql.Insert("actors").
 		Columns("id", "display_name", "avatar_url").
 		Values(in.Author.ID, in.Author.DisplayName, in.Author.AvatarURL).
-		Suffix("ON CONFLICT (id) DO UPDATE SET display_name=EXCLUDED.display_name, avatar_url=EXCLUDED.avatar_url")
+		Suffix(upsertActorSuffix)
 	var query, args, sql_err = aq.ToSql()
 	if sql_err != nil {
-		return nil, err
+		return nil, sql_err
 	}
 	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
 		return nil, err
 	}
 
+	var fingerprint string
+	if in.IdempotencyKey != nil {
+		fingerprint = createNoteFingerprint(in)
+		existing, err := findIdempotentNote(ctx, tx, in.Author.ID, *in.IdempotencyKey, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	if in.ProjectID != nil {
+		if err := checkNoteProject(ctx, tx, *in.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	duplicates, err := findDuplicates(ctx, tx, in)
+	if err != nil {
+		return nil, err
+	}
+
 	nq := psql.Insert("notes").
-		Columns("id", "project_id", "author_id", "title", "content", "is_pinned").
-		Values(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false).
-		Suffix("RETURNING id, project_id, author_id, title, content, is_pinned, created_at, updated_at")
+		Columns("id", "project_id", "author_id", "title", "content", "is_pinned", "language").
+		Values(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false, noteLanguage(in.Language, sq.Expr("?", in.ProjectID))).
+		Suffix("RETURNING id, project_id, author_id, title, content, is_pinned, language, created_at, updated_at")
 	query, args, sql_err = nq.ToSql()
 	if sql_err != nil {
-		return nil, err
+		return nil, sql_err
 	}
 	var n models.Note
 	if err := tx.GetContext(ctx, &n, query, args...); err != nil {
-		return nil, err
+		return nil, languageError(err)
 	}
 
 	if len(in.Tags) > 0 {
@@ -219,30 +618,42 @@ func (d *Database) CreateNote(ctx context.Context, in models.CreateNoteInput) (*
 		tq = tq.Suffix("ON CONFLICT DO NOTHING")
 		query, args, sql_err = tq.ToSql()
 		if sql_err != nil {
-			return nil, err
+			return nil, sql_err
 		}
 		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
 			return nil, err
 		}
 	}
-	err = InsertAttachment(ctx, tx, in.Attachment)
-
-	if err != nil {
+	if err := InsertAttachment(ctx, tx, in.Attachment); err != nil {
 		return nil, err
 	}
-	if err := tx.Commit(); err != nil {
+	if err := recordNoteEvent(ctx, tx, models.NoteEventCreated, n.ID); err != nil {
 		return nil, err
 	}
+	if in.IdempotencyKey != nil {
+		if err := saveIdempotencyKey(ctx, tx, in.Author.ID, *in.IdempotencyKey, fingerprint, n.ID, d.idempotencyTTL()); err != nil {
+			return nil, err
+		}
+	}
 	n.Author = &in.Author
 	n.Tags = append([]string(nil), in.Tags...)
+	n.Duplicates = duplicates
 	return &n, nil
 }
 
 func (d *Database) ViewNote(ctx context.Context, noteID string, opts models.GetNoteOptions) (*models.Note, error) {
 	d.Mu.RLock()
 	defer d.Mu.RUnlock()
+	return viewNote(ctx, d.Db, noteID, opts)
+}
+
+// viewNote loads a note through db, which can be the pool or an open
+// transaction. Revisions and attachments are aggregated into the same query
+// so GetNote stays a single round trip. Callers are responsible for locking.
+func viewNote(ctx context.Context, db sqlx.QueryerContext, noteID string, opts models.GetNoteOptions) (*models.Note, error) {
 	q := psql.Select(
-		"n.id", "n.project_id", "n.author_id", "n.title", "n.content", "n.is_pinned", "n.created_at", "n.updated_at",
+		"n.id", "n.project_id", "n.author_id", "n.title", "n.content", "n.is_pinned", "n.language", "n.created_at", "n.updated_at",
+		"n.deleted_at", "n.deleted_by",
 		"a.id AS author_id", "a.display_name AS author_display_name", "a.avatar_url AS author_avatar_url",
 		"COALESCE(t.tags, '{}') AS tags",
 	).
@@ -254,25 +665,40 @@ func (d *Database) ViewNote(ctx context.Context, noteID string, opts models.GetN
 			GROUP BY note_id
 		) t ON t.note_id = n.id`).
 		Where(sq.Eq{"n.id": noteID})
-	// if opts.IncludeRevisions {
-	// 	q = q.LeftJoin(`
-	//         SELECT r.id, r.note_id, r.title, r.content, r.editor_id, r.edited_at,
-	//                e.id AS "editor.id", e.display_name AS "editor.display_name", e.avatar_url AS "editor.avatar_url"
-	//         FROM note_revisions r
-	//         JOIN actors e ON e.id = r.editor_id
-	//         WHERE r.note_id = $1
-	//         ORDER BY r.edited_at DESC, r.id DESC
-	//     `)
-	// }
-
-	// if opts.IncludeAttachments {
-	// 	q = q.LeftJoin(`
-	//         SELECT a.id, a.note_id, a.url, a.file_name, a.file_type, a.uploaded_at, a.sha256, a.size_bytes
-	//         FROM attachments a
-	//         WHERE a.note_id = $1
-	//         ORDER BY a.uploaded_at DESC, a.id DESC
-	//     `)
-	// }
+	if !opts.IncludeDeleted {
+		q = q.Where("n.deleted_at IS NULL")
+	}
+	if visible := visibleNotes(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	if opts.IncludeRevisions {
+		// One extra row tells us whether the note has more revisions than the cap.
+		q = q.Column("rv.revisions").LeftJoin(fmt.Sprintf(`LATERAL (
+			SELECT json_agg(json_build_object(
+				'id', r.id, 'note_id', r.note_id, 'title', r.title, 'content', r.content,
+				'editor_id', r.editor_id, 'edited_at', r.edited_at,
+				'editor', json_build_object('id', e.id, 'display_name', e.display_name, 'avatar_url', e.avatar_url)
+			) ORDER BY r.edited_at DESC, r.id DESC) AS revisions
+			FROM (
+				SELECT * FROM note_revisions
+				WHERE note_id = n.id
+				ORDER BY edited_at DESC, id DESC
+				LIMIT %d
+			) r
+			LEFT JOIN actors e ON e.id = r.editor_id
+		) rv ON TRUE`, revisionsLimit(opts)+1))
+	}
+
+	if opts.IncludeAttachments {
+		q = q.Column("att.attachments").LeftJoin(`LATERAL (
+			SELECT json_agg(json_build_object(
+				'id', x.id, 'note_id', x.note_id, 'url', x.url, 'file_name', x.file_name, 'file_type', x.file_type,
+				'uploaded_at', x.uploaded_at, 'sha256', x.sha256, 'size_bytes', x.size_bytes, 'blob_key', x.blob_key
+			) ORDER BY x.uploaded_at DESC, x.id DESC) AS attachments
+			FROM attachments x
+			WHERE x.note_id = n.id
+		) att ON TRUE`)
+	}
 
 	query, args, sql_err := q.ToSql()
 	if sql_err != nil {
@@ -286,15 +712,20 @@ func (d *Database) ViewNote(ctx context.Context, noteID string, opts models.GetN
 		TitlePtr        *string        `db:"title"`   // scan into pointer then assign to non-pointer Title
 		ContentPtr      *string        `db:"content"` // models.Note.Content is *string, so keep pointer
 		IsPinned        bool           `db:"is_pinned"`
+		Language        string         `db:"language"`
 		CreatedAt       sql.NullTime   `db:"created_at"`
 		UpdatedAt       sql.NullTime   `db:"updated_at"`
+		DeletedAt       *time.Time     `db:"deleted_at"`
+		DeletedBy       *string        `db:"deleted_by"`
 		AuthorName      *string        `db:"author_display_name"`
 		AuthorAvatarURL *string        `db:"author_avatar_url"`
 		Tags            pq.StringArray `db:"tags"`
+		Revisions       []byte         `db:"revisions"`
+		Attachments     []byte         `db:"attachments"`
 	}
 
 	var rw row
-	if err := d.Db.GetContext(ctx, &rw, query, args...); err != nil {
+	if err := sqlx.GetContext(ctx, db, &rw, query, args...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "note not found")
 		}
@@ -315,12 +746,15 @@ func (d *Database) ViewNote(ctx context.Context, noteID string, opts models.GetN
 
 	n.AuthorID = rw.AuthorID_
 	n.IsPinned = rw.IsPinned
+	n.Language = rw.Language
 	if rw.CreatedAt.Valid {
 		n.CreatedAt = rw.CreatedAt.Time
 	}
 	if rw.UpdatedAt.Valid {
 		n.UpdatedAt = rw.UpdatedAt.Time
 	}
+	n.DeletedAt = rw.DeletedAt
+	n.DeletedByID = rw.DeletedBy
 
 	n.Author = &models.Actor{
 		ID:          rw.AuthorID_,
@@ -334,74 +768,48 @@ func (d *Database) ViewNote(ctx context.Context, noteID string, opts models.GetN
 		n.Tags = nil
 	}
 
+	if len(rw.Revisions) > 0 {
+		if err := json.Unmarshal(rw.Revisions, &n.Revisions); err != nil {
+			return nil, fmt.Errorf("decoding revisions: %w", err)
+		}
+		if limit := revisionsLimit(opts); len(n.Revisions) > limit {
+			n.Revisions = n.Revisions[:limit]
+			n.HasMoreRevisions = true
+		}
+	}
+	if len(rw.Attachments) > 0 {
+		if err := json.Unmarshal(rw.Attachments, &n.Attachments); err != nil {
+			return nil, fmt.Errorf("decoding attachments: %w", err)
+		}
+	}
+
 	return &n, nil
 
 }
 
-func (d *Database) ListNotes(ctx context.Context, filter models.ListNotesFilter) ([]models.Note, string, error) {
-
-	if filter.PageSize < 10 {
-		filter.PageSize = 10
-	} else if filter.PageSize > 100 {
-		filter.PageSize = 100
-	}
-
-	var sortBy string
-
-	switch strings.ToLower(filter.SortBy) {
-	case "updated_at", "created_at", "title", "is_pinned":
-		sortBy = filter.SortBy
+const (
+	defaultRevisionsLimit = 50
+	maxRevisionsLimit     = 500
+)
 
-	default:
-		sortBy = "updated_at"
+func revisionsLimit(opts models.GetNoteOptions) int {
+	if opts.RevisionsLimit <= 0 {
+		return defaultRevisionsLimit
 	}
+	return min(opts.RevisionsLimit, maxRevisionsLimit)
+}
 
-	dir := "DESC"
-	if !filter.SortDesc {
-		dir = "ASC"
-	}
+func (d *Database) ListNotes(ctx context.Context, filter models.ListNotesFilter) ([]models.Note, string, error) {
 
-	q := psql.Select(
-		"n.id", "n.project_id", "n.author_id", "n.title", "n.content", "n.is_pinned", "n.created_at", "n.updated_at",
-		"a.id AS author_id", "a.display_name AS author_display_name", "a.avatar_url AS author_avatar_url",
-	).
-		From("notes n").
-		LeftJoin("actors a ON a.id = n.author_id"). // change to your author table name
-		OrderBy(fmt.Sprintf("n.%s %s, n.id %s", sortBy, dir, dir)).
-		Limit(uint64(filter.PageSize))
+	filter.PageSize = clampPageSize(filter.PageSize)
 
-	if filter.ProjectID != nil {
-		q = q.Where(sq.Eq{"n.project_id": *filter.ProjectID})
-	}
-	if filter.UserID != nil {
-		q = q.Where(sq.Eq{"n.author_id": filter.UserID})
-	}
-	if filter.Query != nil && *filter.Query != "" {
-		q = q.Where("to_tsvector('english', coalesce(n.title,'') || ' ' || coalesce(n.content,'')) @@ plainto_tsquery('english', ?)", filter.Query)
+	q, sortBy, dir, err := listNotesQuery(ctx, filter, true)
+	if err != nil {
+		return nil, "", err
 	}
+	q = q.Limit(uint64(filter.PageSize))
 
-	if filter.PageToken != "" {
-		if c, err := utils.DecodePaginationToken(filter.PageToken); err == nil {
-			op := "<"
-			if dir == "ASC" {
-				op = ">"
-			}
-
-			// Keyset pagination filter:
-			//   (1) Include rows where n.<sortBy> is after the cursor value (c.Key),
-			//   OR
-			//   (2) If n.<sortBy> equals c.Key, use n.id as a tiebreaker to ensure stable ordering.
-			// This avoids duplicates and gaps compared to OFFSET/LIMIT pagination.
-			q = q.Where(fmt.Sprintf("(n.%s %s ? OR (n.%s = ? AND n.id %s ?))", sortBy, op, sortBy, op), c.Key, c.Key, c.ID)
-		}
-	}
-	type row struct {
-		models.Note
-		AuthorID        string  `db:"author_id"` // if you already have author_id in models.Note, drop this
-		AuthorName      *string `db:"author_display_name"`
-		AuthorAvatarURL *string `db:"author_avatar_url"`
-	}
-	var rows []row
+	var rows []listNotesRow
 	sqlStr, args, err := q.ToSql()
 
 	if err != nil {
@@ -418,6 +826,9 @@ func (d *Database) ListNotes(ctx context.Context, filter models.ListNotesFilter)
 	}
 
 	if err := d.Db.SelectContext(ctx, &rows, sqlStr, args...); err != nil {
+		if err := languageError(err); status.Code(err) == codes.InvalidArgument {
+			return nil, "", err
+		}
 		return nil, "", status.Errorf(codes.Internal, "query failed: %v", err)
 	}
 	notes := make([]models.Note, 0, len(rows))
@@ -434,13 +845,17 @@ func (d *Database) ListNotes(ctx context.Context, filter models.ListNotesFilter)
 
 	}
 	for _, row := range rows {
-		n := row.Note
-		n.Author = &models.Actor{ID: row.AuthorID, DisplayName: row.AuthorName, AvatarURL: row.AuthorAvatarURL}
-		notes = append(notes, n)
+		notes = append(notes, row.toNote())
 	}
 
 	var next string
-	if len(notes) == filter.PageSize {
+	if len(notes) == filter.PageSize && sortBy == "relevance" {
+		last := notes[len(notes)-1]
+		cur := utils.RelevanceCursor{Rank: last.Match.Rank, ID: last.ID, Search: utils.RelevanceSearchKey(*filter.Query, filter.Language)}
+		if s, err := utils.EncodeRelevanceCursor(cur); err == nil {
+			next = s
+		}
+	} else if len(notes) == filter.PageSize {
 		last := notes[len(notes)-1]
 		var key string
 		var keyType string
@@ -458,6 +873,9 @@ func (d *Database) ListNotes(ctx context.Context, filter models.ListNotesFilter)
 		case "is_pinned":
 			key = strconv.FormatBool(last.IsPinned)
 			keyType = "bool"
+		case "deleted_at":
+			key = last.DeletedAt.UTC().Format(time.RFC3339Nano)
+			keyType = "time"
 		default:
 			key = last.UpdatedAt.UTC().Format(time.RFC3339Nano)
 			keyType = "time"
@@ -478,15 +896,232 @@ func (d *Database) ListNotes(ctx context.Context, filter models.ListNotesFilter)
 	return notes, next, nil
 }
 
+// CountNotes counts the notes ListNotes returns for filter across all pages.
+func (d *Database) CountNotes(ctx context.Context, filter models.ListNotesFilter) (int64, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	// Order and position do not change the count.
+	filter.SortBy, filter.PageToken = "", ""
+	q, _, _, err := listNotesQuery(ctx, filter, false)
+	if err != nil {
+		return 0, err
+	}
+	sqlStr, args, err := psql.Select("COUNT(*)").FromSelect(q, "matches").ToSql()
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to build query: %v", err)
+	}
+	var n int64
+	if err := d.Db.GetContext(ctx, &n, sqlStr, args...); err != nil {
+		if err := languageError(err); status.Code(err) == codes.InvalidArgument {
+			return 0, err
+		}
+		return 0, status.Errorf(codes.Internal, "query failed: %v", err)
+	}
+	return n, nil
+}
+
+type listNotesRow struct {
+	models.Note
+	AuthorID        string         `db:"author_id"` // if you already have author_id in models.Note, drop this
+	AuthorName      *string        `db:"author_display_name"`
+	AuthorAvatarURL *string        `db:"author_avatar_url"`
+	Tags            pq.StringArray `db:"tags"`
+	SearchRank      *float32       `db:"search_rank"`
+	SearchSnippet   *string        `db:"search_snippet"`
+}
+
+func (r listNotesRow) toNote() models.Note {
+	n := r.Note
+	n.Author = &models.Actor{ID: r.AuthorID, DisplayName: r.AuthorName, AvatarURL: r.AuthorAvatarURL}
+	if len(r.Tags) > 0 {
+		n.Tags = []string(r.Tags)
+	}
+	if r.SearchRank != nil {
+		n.Match = &models.NoteMatch{Rank: *r.SearchRank}
+		if r.SearchSnippet != nil {
+			n.Match.Snippet, n.Match.Highlights = splitHeadline(*r.SearchSnippet)
+		}
+	}
+	return n
+}
+
+// notesSelect selects the listNotesRow columns of notes aliased n joined with
+// their author aliased a.
+func notesSelect() sq.SelectBuilder {
+	return psql.Select(
+		"n.id", "n.project_id", "n.author_id", "n.title", "n.content", "n.is_pinned", "n.language", "n.created_at", "n.updated_at",
+		"n.deleted_at", "n.deleted_by",
+		"a.id AS author_id", "a.display_name AS author_display_name", "a.avatar_url AS author_avatar_url",
+		"COALESCE((SELECT ARRAY_AGG(nt.tag ORDER BY nt.tag) FROM note_tags nt WHERE nt.note_id = n.id), '{}') AS tags",
+	).
+		From("notes n").
+		LeftJoin("actors a ON a.id = n.author_id") // change to your author table name
+}
+
+// listNotesQuery builds the unpaged ListNotes query: filters, sort order and
+// the keyset condition from filter.PageToken, limited to the notes the caller
+// in ctx can read. It also returns the validated sort column and direction.
+// A malformed filter.Query fails with InvalidArgument. When the query has
+// words to search for every row gets its search_rank, and with snippets its
+// search_snippet.
+func listNotesQuery(ctx context.Context, filter models.ListNotesFilter, snippets bool) (sq.SelectBuilder, string, string, error) {
+	var search noteSearch
+	if filter.Query != nil {
+		var err error
+		if search, err = parseNoteSearch(*filter.Query, filter.Language); err != nil {
+			return sq.SelectBuilder{}, "", "", err
+		}
+	}
+
+	var sortBy string
+
+	switch strings.ToLower(filter.SortBy) {
+	case "updated_at", "created_at", "title", "is_pinned":
+		sortBy = filter.SortBy
+	case "relevance":
+		if search.tsquery == nil {
+			return sq.SelectBuilder{}, "", "", status.Error(codes.InvalidArgument, "sort_by relevance needs a query with words or phrases to search for")
+		}
+		sortBy = "relevance"
+	case "deleted_at":
+		// deleted_at is only non-null for every row when listing the trash
+		if filter.OnlyDeleted {
+			sortBy = "deleted_at"
+		} else {
+			sortBy = "updated_at"
+		}
+
+	default:
+		sortBy = "updated_at"
+	}
+
+	dir := "DESC"
+	if !filter.SortDesc && sortBy != "relevance" {
+		dir = "ASC"
+	}
+
+	q := notesSelect()
+	if search.tsquery != nil {
+		q = q.Column(sq.Alias(search.rank(), "search_rank"))
+		if snippets {
+			q = q.Column(sq.Alias(search.headline(), "search_snippet"))
+		}
+	}
+	if sortBy == "relevance" {
+		q = q.OrderBy("search_rank DESC", "n.id DESC")
+	} else {
+		q = q.OrderBy(fmt.Sprintf("n.%s %s, n.id %s", sortBy, dir, dir))
+	}
+
+	if filter.OnlyDeleted {
+		q = q.Where("n.deleted_at IS NOT NULL")
+	} else if !filter.IncludeDeleted {
+		q = q.Where("n.deleted_at IS NULL")
+	}
+	if visible := visibleNotes(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	if filter.ProjectID != nil {
+		q = q.Where(sq.Eq{"n.project_id": *filter.ProjectID})
+	}
+	if filter.UserID != nil {
+		q = q.Where(sq.Eq{"n.author_id": filter.UserID})
+	}
+	if search.cond != nil {
+		q = q.Where(search.cond)
+	}
+	// Tag filters match nested tags too: "eng" matches "eng/backend".
+	if tags := utils.NormalizeTags(filter.TagsAny); len(tags) > 0 {
+		q = q.Where("EXISTS (SELECT 1 FROM note_tags nt, UNNEST(?::text[]) f(tag) WHERE nt.note_id = n.id AND "+tagUnder("nt.tag", "f.tag")+")", pq.Array(tags))
+	}
+	if tags := utils.NormalizeTags(filter.TagsAll); len(tags) > 0 {
+		q = q.Where("NOT EXISTS (SELECT 1 FROM UNNEST(?::text[]) f(tag) WHERE NOT EXISTS (SELECT 1 FROM note_tags nt WHERE nt.note_id = n.id AND "+tagUnder("nt.tag", "f.tag")+"))", pq.Array(tags))
+	}
+	if tags := utils.NormalizeTags(filter.TagsNone); len(tags) > 0 {
+		q = q.Where("NOT EXISTS (SELECT 1 FROM note_tags nt, UNNEST(?::text[]) f(tag) WHERE nt.note_id = n.id AND "+tagUnder("nt.tag", "f.tag")+")", pq.Array(tags))
+	}
+
+	if filter.PageToken != "" && sortBy == "relevance" {
+		c, err := utils.DecodeRelevanceCursor(filter.PageToken)
+		if err != nil || c.Search != utils.RelevanceSearchKey(*filter.Query, filter.Language) {
+			return sq.SelectBuilder{}, "", "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		q = q.Where(sq.Expr("(? < ?::real OR (? = ?::real AND n.id < ?))", search.rank(), c.Rank, search.rank(), c.Rank, c.ID))
+	} else if filter.PageToken != "" {
+		if c, err := utils.DecodePaginationToken(filter.PageToken); err == nil {
+			op := "<"
+			if dir == "ASC" {
+				op = ">"
+			}
+
+			// Keyset pagination filter:
+			//   (1) Include rows where n.<sortBy> is after the cursor value (c.Key),
+			//   OR
+			//   (2) If n.<sortBy> equals c.Key, use n.id as a tiebreaker to ensure stable ordering.
+			// This avoids duplicates and gaps compared to OFFSET/LIMIT pagination.
+			q = q.Where(fmt.Sprintf("(n.%s %s ? OR (n.%s = ? AND n.id %s ?))", sortBy, op, sortBy, op), c.Key, c.Key, c.ID)
+		}
+	}
+	return q, sortBy, dir, nil
+}
+
 func (d *Database) UpdateNote(ctx context.Context, in models.UpdateNoteInput) (*models.Note, error) {
 	d.Mu.Lock()
+	err := d.updateNote(ctx, in)
+	d.Mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return d.ViewNote(ctx, in.NoteID, models.GetNoteOptions{IncludeRevisions: false, IncludeAttachments: true})
+}
+
+// updateNote applies the update inside a single transaction. The caller must
+// hold the write lock.
+func (d *Database) updateNote(ctx context.Context, in models.UpdateNoteInput) error {
 	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
 	defer func() {
 		tx.Rollback()
 	}()
-	if err != nil {
-		return nil, err
+	if err := applyNoteUpdate(ctx, tx, in); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// applyNoteUpdate runs an UpdateNote inside tx.
+func applyNoteUpdate(ctx context.Context, tx *sqlx.Tx, in models.UpdateNoteInput) error {
+	if err := requireNoteRole(ctx, tx, in.NoteID, models.RoleEditor); err != nil {
+		return err
+	}
+
+	// The editor is credited with the new title and content, and with them
+	// the revision that later snapshots them.
+	editsText := in.Title != nil || in.Content != nil
+	editorKnown := in.Editor != nil && in.Editor.ID != ""
+	if editsText && editorKnown {
+		query, args, err := psql.Insert("actors").
+			Columns("id", "display_name", "avatar_url").
+			Values(in.Editor.ID, in.Editor.DisplayName, in.Editor.AvatarURL).
+			Suffix(upsertActorSuffix).
+			ToSql()
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
 	}
+
+	if in.CreateRevision && editsText {
+		if err := insertRevision(ctx, tx, in); err != nil {
+			return err
+		}
+	}
+
 	uq := psql.Update("notes")
 
 	if in.Title != nil {
@@ -495,119 +1130,170 @@ func (d *Database) UpdateNote(ctx context.Context, in models.UpdateNoteInput) (*
 	if in.Content != nil {
 		uq = uq.Set("content", *in.Content)
 	}
+	if editsText && editorKnown {
+		uq = uq.Set("last_editor_id", in.Editor.ID)
+	}
 	if in.IsPinned != nil {
 		uq = uq.Set("is_pinned", *in.IsPinned)
 	}
+	if in.Language != nil {
+		// The project's language is that of the project the note ends up in.
+		project := sq.Expr("notes.project_id")
+		if in.MoveToProject != nil {
+			project = sq.Expr("?", utils.NilIfEmpty(*in.MoveToProject))
+		}
+		uq = uq.Set("language", noteLanguage(utils.NilIfEmpty(*in.Language), project))
+	}
 
 	if in.IfMatchUpdatedAt != nil {
 		uq = uq.Where(sq.And{sq.Eq{"id": in.NoteID}, sq.Eq{"updated_at": *in.IfMatchUpdatedAt}})
 	} else {
 		uq = uq.Where(sq.Eq{"id": in.NoteID})
 	}
+	uq = uq.Where("deleted_at IS NULL")
 	uq = uq.Suffix("RETURNING id")
 	if sqlStr, args, err := uq.ToSql(); err == nil && len(args) > 0 {
 		var id string
-		if err := tx.GetContext(ctx, &id, sqlStr, args...); err != nil && errors.Is(err, sql.ErrNoRows) {
-			return nil, err
+		if err := tx.GetContext(ctx, &id, sqlStr, args...); err != nil {
+			if errors.Is(err, sql.ErrNoRows) && in.IfMatchUpdatedAt != nil {
+				return conflictOrMissing(ctx, tx, in.NoteID)
+			}
+			return languageError(err)
+		}
+	}
+
+	if in.MoveToProject != nil {
+		target := utils.NilIfEmpty(*in.MoveToProject)
+		if target != nil {
+			if err := checkNoteProject(ctx, tx, *target); err != nil {
+				return err
+			}
+		}
+		if _, err := moveNote(ctx, tx, in.NoteID, target, in.Editor); err != nil {
+			return err
 		}
 	}
 
 	if in.Tags != nil {
 		if _, err := tx.ExecContext(ctx, `DELETE FROM note_tags WHERE note_id=$1`, in.NoteID); err != nil {
-			return nil, err
+			return err
 		}
-		if len(*in.Tags) > 0 {
+		if tags := utils.NormalizeTags(*in.Tags); len(tags) > 0 {
 			tq := psql.Insert("note_tags").Columns("note_id", "tag")
-			for _, t := range *in.Tags {
+			for _, t := range tags {
 				tq = tq.Values(in.NoteID, t)
 			}
 			query, args, err := tq.ToSql()
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
 
-	if len(in.Attachments) > 0 {
-		for _, a := range in.Attachments {
-			q := psql.Insert("attachments").Columns("id", "note_id", "url", "file_name", "file_type", "uploaded_at", "sha256", "size_bytes").
-				Values(a.ID, a.NoteID, a.URL, a.FileName, a.FileType, a.UploadedAt, a.SHA256, a.SizeBytes).
-				Suffix(`ON CONFLICT (id) DO UPDATE SET 
-                    url=EXCLUDED.url, file_name=EXCLUDED.file_name, file_type=EXCLUDED.file_type,
-                    uploaded_at=EXCLUDED.uploaded_at, sha256=EXCLUDED.sha256, size_bytes=EXCLUDED.size_bytes`)
-			if query, args, err := q.ToSql(); err == nil {
-				tx.ExecContext(ctx, query, args...)
-			} else {
-				return nil, err
-			}
+	if err := InsertAttachment(ctx, tx, in.Attachments); err != nil {
+		return err
+	}
+	return recordNoteEvent(ctx, tx, models.NoteEventUpdated, in.NoteID)
+}
 
-		}
+// conflictOrMissing tells apart an update that matched no row because the note
+// does not exist from one rejected by if_match_updated_at.
+func conflictOrMissing(ctx context.Context, tx *sqlx.Tx, noteID string) error {
+	var exists bool
+	if err := tx.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM notes WHERE id=$1 AND deleted_at IS NULL)`, noteID); err != nil {
+		return err
 	}
-	if err := tx.Commit(); err != nil {
-		return nil, err
+	if !exists {
+		return sql.ErrNoRows
 	}
-	d.Mu.Unlock()
-	return d.ViewNote(ctx, in.NoteID, models.GetNoteOptions{IncludeRevisions: false, IncludeAttachments: true})
-
+	return status.Error(codes.FailedPrecondition, "note was modified since if_match_updated_at")
 }
 
-func (d *Database) DeleteNote(ctx context.Context, id string, hardDel bool) (bool, error) {
+func (d *Database) DeleteNote(ctx context.Context, in models.DeleteNoteInput) (bool, error) {
 	d.Mu.Lock()
 	defer d.Mu.Unlock()
 	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
 	defer func() {
 		tx.Rollback()
 	}()
+	deleted, err := deleteNote(ctx, tx, in)
 	if err != nil {
-		return false, fmt.Errorf("enable to start a transaction %s", err.Error())
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit failed after delete: %w", err)
 	}
-	if true {
-		childTables := []string{"note_tags", "attachments", "note_revisions"}
+	return deleted, nil
+}
 
-		for _, t := range childTables {
-			q, args, err := psql.Delete(t).
-				Where(sq.Eq{"note_id": id}).
-				ToSql()
-			if err != nil {
-				return false, fmt.Errorf("building delete for %s: %w", t, err)
-			}
-			if _, err := tx.ExecContext(ctx, q, args...); err != nil {
-				return false, fmt.Errorf("deleting from %s: %w", t, err)
-			}
+// deleteNote trashes or hard deletes a note inside tx.
+func deleteNote(ctx context.Context, tx *sqlx.Tx, in models.DeleteNoteInput) (bool, error) {
+	// Trashing can be undone, so editors may do it; only owners destroy notes.
+	role := models.RoleEditor
+	if in.Hard {
+		role = models.RoleOwner
+	}
+	if err := requireNoteRole(ctx, tx, in.NoteID, role); err != nil {
+		return false, err
+	}
+
+	if in.Hard {
+		// The event reads the note's project and tags, so record it first.
+		if err := recordNoteEvent(ctx, tx, models.NoteEventDeleted, in.NoteID); err != nil {
+			return false, err
 		}
+		return hardDeleteNote(ctx, tx, in.NoteID)
+	}
+	deleted, err := trashNote(ctx, tx, in.NoteID, in.DeletedBy)
+	if err == nil && deleted {
+		err = recordNoteEvent(ctx, tx, models.NoteEventDeleted, in.NoteID)
+	}
+	return deleted, err
+}
+
+// hardDeleteNote physically removes a note and its child rows.
+func hardDeleteNote(ctx context.Context, tx *sqlx.Tx, id string) (bool, error) {
+	childTables := []string{"note_tags", "attachments", "note_revisions"}
 
-		delQ, delArgs, err := psql.Delete("notes").
-			Where(sq.Eq{"id": id}).
+	for _, t := range childTables {
+		q, args, err := psql.Delete(t).
+			Where(sq.Eq{"note_id": id}).
 			ToSql()
 		if err != nil {
-			return false, fmt.Errorf("building delete for notes: %w", err)
-		}
-		res, err := tx.ExecContext(ctx, delQ, delArgs...)
-		if err != nil {
-			return false, fmt.Errorf("deleting note: %w", err)
-		}
-		ra, err := res.RowsAffected()
-		if err != nil {
-			return false, fmt.Errorf("rows affected (notes delete): %w", err)
-		}
-		if ra == 0 {
-			if err := tx.Commit(); err != nil {
-				return false, fmt.Errorf("commit failed after no-op delete: %w", err)
-			}
-			return false, nil
+			return false, fmt.Errorf("building delete for %s: %w", t, err)
 		}
-		if err := tx.Commit(); err != nil {
-			return false, fmt.Errorf("commit failed after delete: %w", err)
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return false, fmt.Errorf("deleting from %s: %w", t, err)
 		}
-
 	}
-	return true, nil
 
+	delQ, delArgs, err := psql.Delete("notes").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("building delete for notes: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, delQ, delArgs...)
+	if err != nil {
+		return false, fmt.Errorf("deleting note: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected (notes delete): %w", err)
+	}
+	return ra > 0, nil
 }
 
+// InsertAttachment adds attachments to their notes. An id that is already
+// taken can only update the metadata of a URL attachment on the same note;
+// attachments of other notes and uploaded blobs, whose sha256 blob dedup and
+// collection rely on, are left alone and fail with AlreadyExists.
 func InsertAttachment(ctx context.Context, tx *sqlx.Tx, attachment []models.Attachment) error {
 	if len(attachment) > 0 {
 		for _, a := range attachment {
@@ -615,16 +1301,22 @@ func InsertAttachment(ctx context.Context, tx *sqlx.Tx, attachment []models.Atta
 				Values(a.ID, a.NoteID, a.URL, a.FileName, a.FileType, a.UploadedAt, a.SHA256, a.SizeBytes).
 				Suffix(`ON CONFLICT (id) DO UPDATE SET 
                     url=EXCLUDED.url, file_name=EXCLUDED.file_name, file_type=EXCLUDED.file_type,
-                    uploaded_at=EXCLUDED.uploaded_at, sha256=EXCLUDED.sha256, size_bytes=EXCLUDED.size_bytes`)
+                    uploaded_at=EXCLUDED.uploaded_at, sha256=EXCLUDED.sha256, size_bytes=EXCLUDED.size_bytes
+                WHERE attachments.note_id = EXCLUDED.note_id AND attachments.blob_key IS NULL`)
 			query, args, err := q.ToSql()
 			if err != nil {
 				return fmt.Errorf("build attachments insert: %w", err)
 			}
 
-			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			res, err := tx.ExecContext(ctx, query, args...)
+			if err != nil {
 				return fmt.Errorf("exec attachments insert: %w", err)
 			}
-
+			if ra, err := res.RowsAffected(); err != nil {
+				return err
+			} else if ra == 0 {
+				return status.Errorf(codes.AlreadyExists, "attachment %s already exists", a.ID)
+			}
 		}
 	}
 	return nil
@@ -70,11 +70,17 @@ func TestCreateNote_sqlmock(t *testing.T) {
 		WithArgs(in.Author.ID, in.Author.DisplayName, in.Author.AvatarURL).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id=$1 FOR SHARE")).
+		WithArgs(proj).
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+
+	expectDuplicateCandidates(mock, sqlmock.NewRows([]string{"id"}))
+
 	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO notes")).
-		WithArgs(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false).
+		WithArgs(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false, nil, in.ProjectID).
 		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "project_id", "author_id", "title", "content", "is_pinned", "created_at", "updated_at",
-		}).AddRow(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false, now, now))
+			"id", "project_id", "author_id", "title", "content", "is_pinned", "language", "created_at", "updated_at",
+		}).AddRow(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false, "english", now, now))
 
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO note_tags")).
 		WithArgs(in.ID, "tag1", in.ID, "tag2").
@@ -84,6 +90,7 @@ func TestCreateNote_sqlmock(t *testing.T) {
 		WithArgs("a1", in.ID, "http://u", "f", "txt", sqlmock.AnyArg(), nil, int64(0)). // depending on your struct zero-values
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectNoteEvent(mock, models.NoteEventCreated, in.ID)
 	mock.ExpectCommit()
 
 	n, err := d.CreateNote(ctx, in)
@@ -155,6 +162,32 @@ func TestListNotes_ListAll(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestListNotes_TagFilters(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	rows := sqlmock.NewRows([]string{"id", "title", "tags"}).
+		AddRow("note-1", "Groceries", "{home,todo}").
+		AddRow("note-2", "Untagged", "{}")
+	mock.ExpectQuery(`(?s)^SELECT .*ARRAY_AGG\(nt\.tag ORDER BY nt\.tag\).* AS tags FROM notes n .*`+
+		`AND EXISTS \(SELECT 1 FROM note_tags nt, UNNEST\(\$1::text\[\]\) f\(tag\) WHERE .*\) `+
+		`AND NOT EXISTS \(SELECT 1 FROM UNNEST\(\$2::text\[\]\) f\(tag\) WHERE NOT EXISTS .*\) `+
+		`AND NOT EXISTS \(SELECT 1 FROM note_tags nt, UNNEST\(\$3::text\[\]\) f\(tag\) WHERE .*starts_with\(nt\.tag, f\.tag \|\| '/'\)\)\)`).
+		WithArgs("{\"home\",\"work\"}", "{\"todo\"}", "{\"archived\"}").
+		WillReturnRows(rows)
+
+	notes, _, err := d.ListNotes(context.Background(), models.ListNotesFilter{
+		TagsAny:  []string{"home", "work"},
+		TagsAll:  []string{"todo"},
+		TagsNone: []string{"archived"},
+	})
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	require.Equal(t, []string{"home", "todo"}, notes[0].Tags)
+	require.Nil(t, notes[1].Tags)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestViewNote_Basic(t *testing.T) {
 	d, mock, cleanup := newMockDatabase(t)
 	defer cleanup()
@@ -245,6 +278,7 @@ func TestUpdateNoteBasic(t *testing.T) {
 		WithArgs("att-1", noteID, "https://files/att-1", "file.png", "image/png", now, "deadbeef", int64(1234)).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
+	expectNoteEvent(mock, models.NoteEventUpdated, noteID)
 	mock.ExpectCommit()
 
 	cols := []string{
@@ -286,6 +320,7 @@ func TestDeleteNote_Success(t *testing.T) {
 	noteID := "note-123"
 
 	mock.ExpectBegin()
+	expectNoteEvent(mock, models.NoteEventDeleted, noteID)
 	var note_delete string = regexp.MustCompile(`DELETE\s+FROM\s+note_tags\s+WHERE\s+note_id\s*=\s*\$1`).String()
 	mock.ExpectExec(note_delete).
 		WithArgs(noteID).
@@ -305,7 +340,7 @@ func TestDeleteNote_Success(t *testing.T) {
 
 	mock.ExpectCommit()
 
-	ok, err := d.DeleteNote(context.Background(), noteID, true)
+	ok, err := d.DeleteNote(context.Background(), models.DeleteNoteInput{NoteID: noteID, Hard: true})
 	require.NoError(t, err)
 	require.True(t, ok)
 
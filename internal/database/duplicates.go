@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/shingle"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// duplicateSimilarity is the shingle similarity from which a note counts
+	// as a duplicate. One edited word in a twenty word note still reaches it.
+	duplicateSimilarity = 0.7
+	// maxDuplicateCandidates bounds how many recently updated notes a new
+	// note is compared with.
+	maxDuplicateCandidates = 200
+)
+
+// findDuplicates returns the live notes the caller can read, by in.Author or
+// in in.ProjectID, whose title and content nearly repeat in's, most similar
+// first. With in.RejectDuplicates any duplicate fails with AlreadyExists.
+func findDuplicates(ctx context.Context, tx *sqlx.Tx, in models.CreateNoteInput) ([]models.DuplicateNote, error) {
+	text := shingle.Of(noteText(in.Title, in.Content))
+	if len(text) == 0 {
+		return nil, nil
+	}
+
+	scope := sq.Or{sq.Eq{"n.author_id": in.Author.ID}}
+	if in.ProjectID != nil {
+		scope = append(scope, sq.Eq{"n.project_id": *in.ProjectID})
+	}
+	q := notesSelect().
+		Where("n.deleted_at IS NULL").
+		Where(scope).
+		OrderBy("n.updated_at DESC", "n.id").
+		Limit(maxDuplicateCandidates)
+	if visible := visibleNotes(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var rows []listNotesRow
+	if err := tx.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+
+	var duplicates []models.DuplicateNote
+	for _, r := range rows {
+		n := r.toNote()
+		if sim := shingle.Similarity(text, shingle.Of(noteText(n.Title, n.Content))); sim >= duplicateSimilarity {
+			duplicates = append(duplicates, models.DuplicateNote{Note: n, Similarity: float32(sim)})
+		}
+	}
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Similarity > duplicates[j].Similarity
+	})
+
+	if in.RejectDuplicates && len(duplicates) > 0 {
+		ids := make([]string, 0, len(duplicates))
+		for _, d := range duplicates {
+			ids = append(ids, d.Note.ID)
+		}
+		return nil, status.Errorf(codes.AlreadyExists, "note duplicates %s", strings.Join(ids, ", "))
+	}
+	return duplicates, nil
+}
+
+func noteText(title string, content *string) string {
+	if content == nil {
+		return title
+	}
+	return title + "\n" + *content
+}
@@ -0,0 +1,81 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expectDuplicateCandidates expects the lookup of the notes a new note is
+// checked against.
+func expectDuplicateCandidates(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectQuery(`(?s)^SELECT n\.id, .* FROM notes n .*WHERE n\.deleted_at IS NULL AND \(n\.author_id = \$\d+( OR n\.project_id = \$\d+)?\)` +
+		`.* ORDER BY n\.updated_at DESC, n\.id LIMIT 200$`).
+		WillReturnRows(rows)
+}
+
+func duplicateCandidates() *sqlmock.Rows {
+	now := time.Now().UTC()
+	return sqlmock.NewRows([]string{"id", "author_id", "title", "content", "created_at", "updated_at", "tags"}).
+		AddRow("note-old", "actor-1", "Checkout outage", "The payments service timed out while renewing its database credentials.", now, now, "{}").
+		AddRow("note-other", "actor-2", "Quarterly planning", "Hire two engineers.", now, now, "{}")
+}
+
+func duplicateInput(reject bool) models.CreateNoteInput {
+	return models.CreateNoteInput{
+		ID:               "note-new",
+		ProjectID:        ptrString("proj-1"),
+		Title:            "checkout outage",
+		Content:          ptrString("The payments service timed out while renewing its database credentials!"),
+		Author:           models.Actor{ID: "actor-1"},
+		RejectDuplicates: reject,
+	}
+}
+
+func TestCreateNote_WarnsAboutDuplicates(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+	now := time.Now().UTC()
+	in := duplicateInput(false)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO actors`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT archived_at FROM projects`).
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	expectDuplicateCandidates(mock, duplicateCandidates())
+	mock.ExpectQuery(`INSERT INTO notes`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "author_id", "title", "content", "is_pinned", "language", "created_at", "updated_at"}).
+			AddRow(in.ID, in.ProjectID, in.Author.ID, in.Title, in.Content, false, "english", now, now))
+	expectNoteEvent(mock, models.NoteEventCreated, in.ID)
+	mock.ExpectCommit()
+
+	n, err := d.CreateNote(context.Background(), in)
+	require.NoError(t, err)
+	require.Len(t, n.Duplicates, 1)
+	require.Equal(t, "note-old", n.Duplicates[0].Note.ID)
+	require.Equal(t, float32(1), n.Duplicates[0].Similarity)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateNote_RejectDuplicates(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO actors`).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(`SELECT archived_at FROM projects`).
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	expectDuplicateCandidates(mock, duplicateCandidates())
+	mock.ExpectRollback()
+
+	_, err := d.CreateNote(context.Background(), duplicateInput(true))
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.Contains(t, err.Error(), "note-old")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
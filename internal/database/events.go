@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+const (
+	noteEventsChannel = "note_events"
+
+	// noteEventsLockKey is the advisory lock held by outbox writers until
+	// commit. Ids then become visible in order, so a watcher that has seen
+	// id N can never miss a smaller id committed later.
+	noteEventsLockKey = 7_100_001
+
+	maxNoteEventsBatch = 500
+)
+
+// recordNoteEvent appends an event for noteID to the outbox inside tx. The
+// project, author and tags are read from the note as the transaction sees it;
+// a note that does not exist records nothing.
+func recordNoteEvent(ctx context.Context, tx *sqlx.Tx, eventType, noteID string) error {
+	_, err := tx.ExecContext(ctx, `WITH l AS (SELECT pg_advisory_xact_lock($1))
+INSERT INTO note_events (type, note_id, project_id, author_id, tags)
+SELECT $2, n.id, n.project_id, n.author_id, ARRAY(SELECT tag FROM note_tags WHERE note_id = n.id ORDER BY tag)
+FROM notes n, l
+WHERE n.id = $3`, noteEventsLockKey, eventType, noteID)
+	if err != nil {
+		return fmt.Errorf("recording note event: %w", err)
+	}
+	return nil
+}
+
+// ListNoteEvents returns events after filter.AfterID in id order, limited to
+// notes the caller in ctx can read.
+func (d *Database) ListNoteEvents(ctx context.Context, filter models.NoteEventFilter) ([]models.NoteEvent, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	limit := filter.Limit
+	if limit <= 0 || limit > maxNoteEventsBatch {
+		limit = maxNoteEventsBatch
+	}
+	q := psql.Select("id", "type", "note_id", "project_id", "author_id", "tags", "occurred_at").
+		From("note_events").
+		Where(sq.Gt{"id": filter.AfterID}).
+		OrderBy("id").
+		Limit(uint64(limit))
+	if filter.ProjectID != nil {
+		q = q.Where(sq.Eq{"project_id": *filter.ProjectID})
+	}
+	if filter.AuthorID != nil {
+		q = q.Where(sq.Eq{"author_id": *filter.AuthorID})
+	}
+	if filter.Tag != nil {
+		// Like the ListNotes tag filters, a tag also matches the tags nested
+		// below it.
+		tag := utils.NormalizeTag(*filter.Tag)
+		q = q.Where("EXISTS (SELECT 1 FROM unnest(tags) et WHERE "+tagUnder("et", "?")+")", tag, tag)
+	}
+	if c, ok := auth.FromContext(ctx); ok {
+		// Events outlive hard-deleted notes; those stay visible to their author.
+		q = q.Where(sq.Or{
+			sq.Eq{"author_id": c.Actor.ID},
+			sq.Expr("EXISTS (SELECT 1 FROM notes n WHERE n.id = note_events.note_id AND ?)", visibleNotes(ctx)),
+		})
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	type row struct {
+		models.NoteEvent
+		Tags pq.StringArray `db:"tags"`
+	}
+	var rows []row
+	if err := d.Db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	events := make([]models.NoteEvent, 0, len(rows))
+	for _, r := range rows {
+		ev := r.NoteEvent
+		ev.Tags = []string(r.Tags)
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// LatestNoteEventID returns the newest event id, 0 when there are none.
+func (d *Database) LatestNoteEventID(ctx context.Context) (int64, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	var id int64
+	if err := d.Db.GetContext(ctx, &id, `SELECT COALESCE(MAX(id), 0) FROM note_events`); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// PruneNoteEvents deletes events that happened before cutoff.
+func (d *Database) PruneNoteEvents(ctx context.Context, cutoff time.Time) (int64, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+
+	query, args, err := psql.Delete("note_events").
+		Where(sq.Lt{"occurred_at": cutoff}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	res, err := d.Db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// RunNoteEventPruner calls PruneNoteEvents every interval with events older
+// than retention until ctx is done.
+func (d *Database) RunNoteEventPruner(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := d.PruneNoteEvents(ctx, time.Now().Add(-retention))
+			if err != nil {
+				log.Printf("note event prune failed: %v", err)
+			} else if n > 0 {
+				log.Printf("pruned %d note events", n)
+			}
+		}
+	}
+}
+
+// SubscribeNoteEvents returns a channel that receives a value whenever new
+// events may be available, and a function to stop the subscription. Wake-ups
+// are coalesced; callers read the outbox to find out what changed. Without a
+// listen connection (tests) the channel never fires and callers rely on
+// polling.
+func (d *Database) SubscribeNoteEvents() (<-chan struct{}, func()) {
+	return d.events.subscribe(d.listenDSN)
+}
+
+// eventHub shares one LISTEN connection between all subscribers.
+type eventHub struct {
+	mu       sync.Mutex
+	subs     map[chan struct{}]struct{}
+	listener *pq.Listener
+}
+
+func (h *eventHub) subscribe(dsn string) (<-chan struct{}, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.listener == nil && dsn != "" {
+		h.listen(dsn)
+	}
+	if h.subs == nil {
+		h.subs = map[chan struct{}]struct{}{}
+	}
+	ch := make(chan struct{}, 1)
+	h.subs[ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		delete(h.subs, ch)
+		h.mu.Unlock()
+	}
+}
+
+// listen starts the shared listener. Failures are logged and retried on the
+// next subscribe; watchers keep working off their poll interval meanwhile.
+func (h *eventHub) listen(dsn string) {
+	l := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("note events listener: %v", err)
+		}
+	})
+	if err := l.Listen(noteEventsChannel); err != nil {
+		log.Printf("failed to listen on %s: %v", noteEventsChannel, err)
+		l.Close()
+		return
+	}
+	h.listener = l
+
+	go func() {
+		// A nil notification means the connection was re-established and
+		// notifications may have been lost, which is also worth a wake-up.
+		for range l.Notify {
+			h.broadcast()
+		}
+	}()
+}
+
+func (h *eventHub) broadcast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (h *eventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.listener != nil {
+		h.listener.Close()
+		h.listener = nil
+	}
+}
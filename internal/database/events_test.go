@@ -0,0 +1,56 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectNoteEvent expects the outbox insert every note mutation ends with.
+func expectNoteEvent(mock sqlmock.Sqlmock, eventType, noteID string) {
+	mock.ExpectExec(`(?s)WITH l AS \(SELECT pg_advisory_xact_lock\(\$1\)\)\s+INSERT INTO note_events .* WHERE n\.id = \$3`).
+		WithArgs(sqlmock.AnyArg(), eventType, noteID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestListNoteEvents_Filters(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, type, note_id, project_id, author_id, tags, occurred_at FROM note_events WHERE id > $1 AND project_id = $2 AND EXISTS (SELECT 1 FROM unnest(tags) et WHERE (et = $3 OR starts_with(et, $4 || '/'))) ORDER BY id LIMIT 100")).
+		WithArgs(int64(41), "proj-1", "eng", "eng").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "note_id", "project_id", "author_id", "tags", "occurred_at"}).
+			AddRow(int64(42), models.NoteEventUpdated, "note-1", "proj-1", "actor-1", "{eng/backend,grpc}", now))
+
+	events, err := d.ListNoteEvents(context.Background(), models.NoteEventFilter{
+		AfterID:   41,
+		ProjectID: ptrString("proj-1"),
+		Tag:       ptrString(" Eng "),
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, int64(42), events[0].ID)
+	require.Equal(t, []string{"eng/backend", "grpc"}, events[0].Tags)
+	require.Equal(t, "proj-1", *events[0].ProjectID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscribeNoteEvents_WithoutListener(t *testing.T) {
+	d, _, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	wake, unsubscribe := d.SubscribeNoteEvents()
+	defer unsubscribe()
+	select {
+	case <-wake:
+		t.Fatal("unexpected wake-up without a listener")
+	default:
+	}
+}
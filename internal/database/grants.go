@@ -0,0 +1,168 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ShareNote grants g.Role on a note, replacing any grant the principal already
+// has. Only owners can share.
+func (d *Database) ShareNote(ctx context.Context, noteID string, g models.Grant) (*models.Grant, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := requireNoteRole(ctx, tx, noteID, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	out, err := upsertGrant(ctx, tx, "note_grants", "note_id", noteID, g)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnshareNote removes the principal's grant on a note. Authors keep owning
+// their notes regardless of grants.
+func (d *Database) UnshareNote(ctx context.Context, noteID string, p models.Principal) (bool, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	if err := requireNoteRole(ctx, d.Db, noteID, models.RoleOwner); err != nil {
+		return false, err
+	}
+	return deleteGrant(ctx, d.Db, "note_grants", "note_id", noteID, p)
+}
+
+// ListNoteGrants returns the grants on a note to anyone who can read it.
+func (d *Database) ListNoteGrants(ctx context.Context, noteID string) ([]models.Grant, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+	if err := requireNoteRole(ctx, d.Db, noteID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	return listGrants(ctx, d.Db, "note_grants", "note_id", noteID)
+}
+
+// ShareProject grants g.Role on every note in a project. Only owners can
+// share.
+func (d *Database) ShareProject(ctx context.Context, projectID string, g models.Grant) (*models.Grant, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := requireProjectRole(ctx, tx, projectID, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	out, err := upsertGrant(ctx, tx, "project_grants", "project_id", projectID, g)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnshareProject removes the principal's grant on a project. The project's
+// owner keeps owning it regardless of grants.
+func (d *Database) UnshareProject(ctx context.Context, projectID string, p models.Principal) (bool, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	if err := requireProjectRole(ctx, d.Db, projectID, models.RoleOwner); err != nil {
+		return false, err
+	}
+	return deleteGrant(ctx, d.Db, "project_grants", "project_id", projectID, p)
+}
+
+// ListProjectGrants returns the grants on a project to its owner and anyone
+// with a grant.
+func (d *Database) ListProjectGrants(ctx context.Context, projectID string) ([]models.Grant, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+	if err := requireProjectRole(ctx, d.Db, projectID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	return listGrants(ctx, d.Db, "project_grants", "project_id", projectID)
+}
+
+// upsertGrant, deleteGrant and listGrants work on note_grants,
+// project_grants and saved_search_grants, which differ only in the column
+// naming the resource.
+
+func upsertGrant(ctx context.Context, tx *sqlx.Tx, table, keyColumn, key string, g models.Grant) (*models.Grant, error) {
+	var grantedBy *string
+	if c, ok := auth.FromContext(ctx); ok {
+		grantedBy = &c.Actor.ID
+	}
+	query, args, err := psql.Insert(table).
+		Columns(keyColumn, "principal_type", "principal_id", "role", "granted_by").
+		Values(key, g.Type, g.ID, g.Role, grantedBy).
+		Suffix(fmt.Sprintf(`ON CONFLICT (%s, principal_type, principal_id) DO UPDATE SET role=EXCLUDED.role, granted_by=EXCLUDED.granted_by
+			RETURNING principal_type, principal_id, role, granted_by, created_at`, keyColumn)).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var out models.Grant
+	if err := tx.GetContext(ctx, &out, query, args...); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func deleteGrant(ctx context.Context, db sqlx.ExecerContext, table, keyColumn, key string, p models.Principal) (bool, error) {
+	query, args, err := psql.Delete(table).
+		Where(sq.Eq{keyColumn: key, "principal_type": p.Type, "principal_id": p.ID}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return ra > 0, nil
+}
+
+func listGrants(ctx context.Context, q sqlx.QueryerContext, table, keyColumn, key string) ([]models.Grant, error) {
+	query, args, err := psql.Select("principal_type", "principal_id", "role", "granted_by", "created_at").
+		From(table).
+		Where(sq.Eq{keyColumn: key}).
+		OrderBy("created_at", "principal_type", "principal_id").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	grants := []models.Grant{}
+	if err := sqlx.SelectContext(ctx, q, &grants, query, args...); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
@@ -0,0 +1,99 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShareNote_OwnerUpsertsGrant(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectBegin()
+	expectNoteRank(mock, "note-1", 3)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO note_grants (note_id,principal_type,principal_id,role,granted_by) VALUES ($1,$2,$3,$4,$5) ON CONFLICT (note_id, principal_type, principal_id) DO UPDATE")).
+		WithArgs("note-1", models.PrincipalGroup, "hr", models.RoleViewer, "alice").
+		WillReturnRows(sqlmock.NewRows([]string{"principal_type", "principal_id", "role", "granted_by", "created_at"}).
+			AddRow(models.PrincipalGroup, "hr", "viewer", "alice", now))
+	mock.ExpectCommit()
+
+	g, err := d.ShareNote(callerCtx("alice"), "note-1", models.Grant{
+		Principal: models.Principal{Type: models.PrincipalGroup, ID: "hr"},
+		Role:      models.RoleViewer,
+	})
+	require.NoError(t, err)
+	require.Equal(t, models.RoleViewer, g.Role)
+	require.Equal(t, "alice", *g.GrantedBy)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareNote_EditorIsDenied(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNoteRank(mock, "note-1", 2)
+	mock.ExpectRollback()
+
+	_, err := d.ShareNote(callerCtx("bob"), "note-1", models.Grant{
+		Principal: models.Principal{Type: models.PrincipalUser, ID: "carol"},
+		Role:      models.RoleEditor,
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func expectProjectAccess(mock sqlmock.Sqlmock, rank int) {
+	mock.ExpectQuery(`(?s)SELECT GREATEST\(CASE WHEN p\.owner_id = \$1 .* FROM projects p LEFT JOIN project_grants pg ON pg\.project_id = p\.id WHERE p\.id = \$\d+ GROUP BY p\.id`).
+		WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow(rank))
+}
+
+func TestShareProject_EditorIsDenied(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectProjectAccess(mock, 2)
+	mock.ExpectRollback()
+
+	_, err := d.ShareProject(callerCtx("bob", "eng"), "proj-1", models.Grant{
+		Principal: models.Principal{Type: models.PrincipalGroup, ID: "hr"},
+		Role:      models.RoleViewer,
+	})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnshareProject_MissingProject(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`FROM projects p`).WillReturnRows(sqlmock.NewRows([]string{"rank", "has_grants"}))
+
+	_, err := d.UnshareProject(callerCtx("alice"), "nope", models.Principal{Type: models.PrincipalUser, ID: "bob"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNoteGrants_WithoutCaller(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT principal_type, principal_id, role, granted_by, created_at FROM note_grants WHERE note_id = $1 ORDER BY created_at, principal_type, principal_id")).
+		WithArgs("note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"principal_type", "principal_id", "role", "granted_by", "created_at"}))
+
+	grants, err := d.ListNoteGrants(context.Background(), "note-1")
+	require.NoError(t, err)
+	require.Empty(t, grants)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
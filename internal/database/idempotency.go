@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultIdempotencyTTL = 24 * time.Hour
+
+func (d *Database) idempotencyTTL() time.Duration {
+	if d.IdempotencyTTL > 0 {
+		return d.IdempotencyTTL
+	}
+	return defaultIdempotencyTTL
+}
+
+// createNoteFingerprint hashes the parts of a create request that a retry must
+// repeat verbatim. Server generated values (note ID, default upload times) are
+// left out.
+func createNoteFingerprint(in models.CreateNoteInput) string {
+	type attachment struct {
+		ID       string `json:"id"`
+		URL      string `json:"url"`
+		FileName string `json:"file_name"`
+		FileType string `json:"file_type"`
+	}
+	payload := struct {
+		ProjectID   *string      `json:"project_id"`
+		Title       string       `json:"title"`
+		Content     *string      `json:"content"`
+		Tags        []string     `json:"tags"`
+		Attachments []attachment `json:"attachments"`
+		Language    *string      `json:"language,omitempty"`
+	}{
+		ProjectID: in.ProjectID,
+		Title:     in.Title,
+		Content:   in.Content,
+		Tags:      append([]string(nil), in.Tags...),
+		Language:  in.Language,
+	}
+	sort.Strings(payload.Tags)
+	for _, a := range in.Attachment {
+		payload.Attachments = append(payload.Attachments, attachment{a.ID, a.URL, a.FileName, a.FileType})
+	}
+
+	b, _ := json.Marshal(payload)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// findIdempotentNote returns the note created by an earlier request with the
+// same author and key, or nil when the key is unused or expired.
+func findIdempotentNote(ctx context.Context, tx *sqlx.Tx, authorID, key, fingerprint string) (*models.Note, error) {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM idempotency_keys WHERE author_id=$1 AND expires_at <= NOW()`, authorID); err != nil {
+		return nil, err
+	}
+
+	query, args, err := psql.Select("fingerprint", "note_id").
+		From("idempotency_keys").
+		Where(sq.Eq{"author_id": authorID, "key": key}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var stored struct {
+		Fingerprint string `db:"fingerprint"`
+		NoteID      string `db:"note_id"`
+	}
+	if err := tx.GetContext(ctx, &stored, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, status.Error(codes.FailedPrecondition, "idempotency_key was already used with a different request")
+	}
+	return viewNote(ctx, tx, stored.NoteID, models.GetNoteOptions{IncludeAttachments: true, IncludeDeleted: true})
+}
+
+func saveIdempotencyKey(ctx context.Context, tx *sqlx.Tx, authorID, key, fingerprint, noteID string, ttl time.Duration) error {
+	query, args, err := psql.Insert("idempotency_keys").
+		Columns("author_id", "key", "fingerprint", "note_id", "expires_at").
+		Values(authorID, key, fingerprint, noteID, time.Now().Add(ttl)).
+		Suffix("ON CONFLICT (author_id, key) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if ra, err := res.RowsAffected(); err != nil {
+		return err
+	} else if ra == 0 {
+		return status.Error(codes.Aborted, "a request with the same idempotency_key is in progress, retry")
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package database_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func idempotentInput(title string) models.CreateNoteInput {
+	return models.CreateNoteInput{
+		ID:             "note-new",
+		Title:          title,
+		Author:         models.Actor{ID: "actor-1", DisplayName: ptrString("Alice"), AvatarURL: ptrString("NA")},
+		IdempotencyKey: ptrString("idem-1"),
+	}
+}
+
+// expectKeyLookup expects the actor upsert, expired key cleanup and key lookup
+// that start every CreateNote carrying an idempotency key.
+func expectKeyLookup(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE author_id=$1 AND expires_at <= NOW()")).
+		WithArgs("actor-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT fingerprint, note_id FROM idempotency_keys WHERE author_id = $1 AND key = $2")).
+		WithArgs("actor-1", "idem-1").
+		WillReturnRows(rows)
+}
+
+func TestCreateNote_IdempotencyKey(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	// First request: key is unused, the note and the key get stored.
+	var fingerprint string
+	expectKeyLookup(mock, sqlmock.NewRows([]string{"fingerprint", "note_id"}))
+	expectDuplicateCandidates(mock, sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO notes")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "author_id", "title", "content", "is_pinned", "created_at", "updated_at"}).
+			AddRow("note-new", nil, "actor-1", "Hello", nil, false, now, now))
+	expectNoteEvent(mock, models.NoteEventCreated, "note-new")
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys (author_id,key,fingerprint,note_id,expires_at) VALUES ($1,$2,$3,$4,$5) ON CONFLICT (author_id, key) DO NOTHING")).
+		WithArgs("actor-1", "idem-1", captureArg{&fingerprint}, "note-new", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	first, err := d.CreateNote(ctx, idempotentInput("Hello"))
+	require.NoError(t, err)
+	require.NotEmpty(t, fingerprint)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// Retry with the same payload returns the original note.
+	expectKeyLookup(mock, sqlmock.NewRows([]string{"fingerprint", "note_id"}).AddRow(fingerprint, first.ID))
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .*WHERE n\.id = \$1`).
+		WithArgs(first.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "title", "created_at", "updated_at", "tags"}).
+			AddRow(first.ID, "actor-1", "Hello", now, now, "{}"))
+	mock.ExpectCommit()
+
+	replay, err := d.CreateNote(ctx, idempotentInput("Hello"))
+	require.NoError(t, err)
+	require.Equal(t, first.ID, replay.ID)
+
+	// Reusing the key with another payload is rejected.
+	expectKeyLookup(mock, sqlmock.NewRows([]string{"fingerprint", "note_id"}).AddRow(fingerprint, first.ID))
+	mock.ExpectRollback()
+
+	_, err = d.CreateNote(ctx, idempotentInput("Something else"))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// captureArg matches any string argument and records it.
+type captureArg struct{ out *string }
+
+func (c captureArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	*c.out = s
+	return ok
+}
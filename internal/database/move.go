@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MoveNotes reassigns notes to another project in one transaction: either
+// every note moves or none does. Notes keep their ids, revisions and
+// attachments.
+func (d *Database) MoveNotes(ctx context.Context, in models.MoveNotesInput) ([]models.Note, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if in.ProjectID != nil {
+		if err := checkNoteProject(ctx, tx, *in.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+	ids := make([]string, 0, len(in.NoteIDs))
+	seen := make(map[string]bool, len(in.NoteIDs))
+	for _, id := range in.NoteIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+
+		moved, err := moveNote(ctx, tx, id, in.ProjectID, in.MovedBy)
+		if err != nil {
+			return nil, err
+		}
+		if moved {
+			if err := recordNoteEvent(ctx, tx, models.NoteEventUpdated, id); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	notes := make([]models.Note, 0, len(ids))
+	for _, id := range ids {
+		n, err := viewNote(ctx, d.Db, id, models.GetNoteOptions{})
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, *n)
+	}
+	return notes, nil
+}
+
+// moveNote sets a live note's project and adds the move to note_moves. The
+// caller needs editor access to the note and, once it is shared, to the
+// project the note leaves. Callers check the target with checkNoteProject.
+// It reports false when the note already is in the target project.
+func moveNote(ctx context.Context, tx *sqlx.Tx, noteID string, target *string, by *models.Actor) (bool, error) {
+	if err := requireNoteRole(ctx, tx, noteID, models.RoleEditor); err != nil {
+		return false, err
+	}
+	var from *string
+	if err := tx.GetContext(ctx, &from, `SELECT project_id FROM notes WHERE id=$1 AND deleted_at IS NULL FOR UPDATE`, noteID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, status.Error(codes.NotFound, "note not found")
+		}
+		return false, err
+	}
+	if sameProject(from, target) {
+		return false, nil
+	}
+	if from != nil {
+		if err := requireProjectRole(ctx, tx, *from, models.RoleEditor); err != nil {
+			return false, err
+		}
+	}
+
+	query, args, err := psql.Update("notes").
+		Set("project_id", target).
+		Where(sq.Eq{"id": noteID}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return false, err
+	}
+
+	var movedBy *string
+	if by != nil {
+		movedBy = &by.ID
+	}
+	query, args, err = psql.Insert("note_moves").
+		Columns("note_id", "from_project_id", "to_project_id", "moved_by").
+		Values(noteID, from, target, movedBy).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func sameProject(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
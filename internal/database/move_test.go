@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func expectMoveTarget(mock sqlmock.Sqlmock, projectID string) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id=$1 FOR SHARE")).
+		WithArgs(projectID).
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+}
+
+func expectNoteProject(mock sqlmock.Sqlmock, noteID string, projectID any) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT project_id FROM notes WHERE id=$1 AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs(noteID).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(projectID))
+}
+
+func TestMoveNotes_RecordsMoves(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectBegin()
+	expectMoveTarget(mock, "proj-2")
+	expectNoteProject(mock, "note-1", "proj-1")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET project_id = $1 WHERE id = $2")).
+		WithArgs("proj-2", "note-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO note_moves (note_id,from_project_id,to_project_id,moved_by) VALUES ($1,$2,$3,$4)")).
+		WithArgs("note-1", "proj-1", "proj-2", "actor-1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectNoteEvent(mock, models.NoteEventUpdated, "note-1")
+	// Already in the target: nothing to record.
+	expectNoteProject(mock, "note-2", "proj-2")
+	mock.ExpectCommit()
+
+	cols := []string{"id", "project_id", "author_id", "title", "content", "is_pinned", "created_at", "updated_at", "tags"}
+	for _, id := range []string{"note-1", "note-2"} {
+		mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .*WHERE n\.id = \$1`).
+			WithArgs(id).
+			WillReturnRows(sqlmock.NewRows(cols).AddRow(id, "proj-2", "actor-1", "t", "c", false, now, now, "{}"))
+	}
+
+	notes, err := d.MoveNotes(context.Background(), models.MoveNotesInput{
+		NoteIDs:   []string{"note-1", "note-2", "note-1"},
+		ProjectID: ptrString("proj-2"),
+		MovedBy:   &models.Actor{ID: "actor-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, notes, 2)
+	require.Equal(t, "proj-2", *notes[0].ProjectID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMoveNotes_AllOrNothing(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectNoteProject(mock, "note-1", "proj-1")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET project_id = $1 WHERE id = $2")).
+		WithArgs(nil, "note-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO note_moves")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectNoteEvent(mock, models.NoteEventUpdated, "note-1")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT project_id FROM notes WHERE id=$1 AND deleted_at IS NULL FOR UPDATE")).
+		WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}))
+	mock.ExpectRollback()
+
+	_, err := d.MoveNotes(context.Background(), models.MoveNotesInput{NoteIDs: []string{"note-1", "missing"}})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNote_LanguageFollowsMove(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE notes SET language = COALESCE($1::regconfig, (SELECT p.language FROM projects p WHERE p.id = $2), 'english') WHERE id = $3 AND deleted_at IS NULL RETURNING id")).
+		WithArgs(nil, "proj-2", "note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("note-1"))
+	expectMoveTarget(mock, "proj-2")
+	expectNoteProject(mock, "note-1", "proj-1")
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET project_id = $1 WHERE id = $2")).
+		WithArgs("proj-2", "note-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO note_moves")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectNoteEvent(mock, models.NoteEventUpdated, "note-1")
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .*WHERE n\.id = \$1`).
+		WithArgs("note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "author_id", "title", "language", "created_at", "updated_at", "tags"}).
+			AddRow("note-1", "proj-2", "actor-1", "t", "german", now, now, "{}"))
+
+	n, err := d.UpdateNote(context.Background(), models.UpdateNoteInput{
+		NoteID:        "note-1",
+		Language:      ptrString(""),
+		MoveToProject: ptrString("proj-2"),
+		Editor:        &models.Actor{ID: "actor-1"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "german", n.Language)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
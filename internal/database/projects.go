@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateProject stores a project and makes its owner the first owner grant,
+// so only people it is shared with can add notes to it.
+func (d *Database) CreateProject(ctx context.Context, in models.CreateProjectInput) (*models.Project, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	query, args, err := psql.Insert("actors").
+		Columns("id", "display_name", "avatar_url").
+		Values(in.Owner.ID, in.Owner.DisplayName, in.Owner.AvatarURL).
+		Suffix(upsertActorSuffix).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	query, args, err = psql.Insert("projects").
+		Columns("id", "name", "description", "owner_id", "language").
+		Values(in.ID, in.Name, in.Description, in.Owner.ID, sq.Expr("?::regconfig", in.Language)).
+		Suffix("RETURNING id, name, description, owner_id, created_at, updated_at, archived_at, language").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var p models.Project
+	if err := tx.GetContext(ctx, &p, query, args...); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, status.Error(codes.AlreadyExists, "project already exists")
+		}
+		return nil, languageError(err)
+	}
+
+	if _, err := upsertGrant(ctx, tx, "project_grants", "project_id", p.ID, models.Grant{
+		Principal: models.Principal{Type: models.PrincipalUser, ID: in.Owner.ID},
+		Role:      models.RoleOwner,
+	}); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	p.Owner = &in.Owner
+	return &p, nil
+}
+
+// GetProject returns a project the caller can see, archived or not.
+func (d *Database) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+	return getProject(ctx, d.Db, id)
+}
+
+func getProject(ctx context.Context, q sqlx.QueryerContext, id string) (*models.Project, error) {
+	query, args, err := projectsQuery(ctx).Where(sq.Eq{"p.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var row projectRow
+	if err := sqlx.GetContext(ctx, q, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "project not found")
+		}
+		return nil, err
+	}
+	p := row.toProject()
+	return &p, nil
+}
+
+// ListProjects returns the projects the caller can see ordered by name, with
+// the same keyset page tokens as ListNotes.
+func (d *Database) ListProjects(ctx context.Context, filter models.ListProjectsFilter) ([]models.Project, string, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	filter.PageSize = clampPageSize(filter.PageSize)
+	q := projectsQuery(ctx).
+		OrderBy("p.name", "p.id").
+		Limit(uint64(filter.PageSize))
+	if !filter.IncludeArchived {
+		q = q.Where("p.archived_at IS NULL")
+	}
+	if filter.PageToken != "" {
+		c, err := utils.DecodePaginationToken(filter.PageToken)
+		if err != nil || c.SortBy != "name" {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		q = q.Where("(p.name > ? OR (p.name = ? AND p.id > ?))", c.Key, c.Key, c.ID)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to build query: %v", err)
+	}
+	var rows []projectRow
+	if err := d.Db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, "", status.Errorf(codes.Internal, "query failed: %v", err)
+	}
+	projects := make([]models.Project, 0, len(rows))
+	for _, r := range rows {
+		projects = append(projects, r.toProject())
+	}
+
+	var next string
+	if len(projects) == filter.PageSize {
+		last := projects[len(projects)-1]
+		cur := utils.NotesPagination{
+			Key:       last.Name,
+			KeyType:   "string",
+			ID:        last.ID,
+			SortBy:    "name",
+			Direction: "ASC",
+		}
+		if s, err := utils.EncodePaginationToken(cur); err == nil {
+			next = s
+		}
+	}
+	return projects, next, nil
+}
+
+// UpdateProject renames a project or changes its description or language.
+// Only owners can. A new language applies to notes created afterwards.
+func (d *Database) UpdateProject(ctx context.Context, in models.UpdateProjectInput) (*models.Project, error) {
+	return d.changeProject(ctx, in.ProjectID, func(uq sq.UpdateBuilder) sq.UpdateBuilder {
+		if in.Name != nil {
+			uq = uq.Set("name", *in.Name)
+		}
+		if in.Description != nil {
+			uq = uq.Set("description", utils.NilIfEmpty(*in.Description))
+		}
+		if in.Language != nil {
+			uq = uq.Set("language", sq.Expr("?::regconfig", utils.NilIfEmpty(*in.Language)))
+		}
+		return uq
+	})
+}
+
+// ArchiveProject hides a project from ListProjects and stops new notes from
+// being added to it. Archiving twice keeps the first archived_at.
+func (d *Database) ArchiveProject(ctx context.Context, id string) (*models.Project, error) {
+	return d.changeProject(ctx, id, func(uq sq.UpdateBuilder) sq.UpdateBuilder {
+		return uq.Set("archived_at", sq.Expr("COALESCE(archived_at, NOW())"))
+	})
+}
+
+func (d *Database) changeProject(ctx context.Context, id string, set func(sq.UpdateBuilder) sq.UpdateBuilder) (*models.Project, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := requireProjectRole(ctx, tx, id, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	query, args, err := set(psql.Update("projects")).Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, languageError(err)
+	}
+	if ra, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if ra == 0 {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	p, err := getProject(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// checkNoteProject makes sure a note can be added to a project: it has to
+// exist and not be archived, and the caller needs to be an editor of it. The
+// row lock keeps the project from being archived meanwhile.
+func checkNoteProject(ctx context.Context, tx *sqlx.Tx, projectID string) error {
+	var archivedAt *time.Time
+	if err := tx.GetContext(ctx, &archivedAt, `SELECT archived_at FROM projects WHERE id=$1 FOR SHARE`, projectID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return status.Error(codes.NotFound, "project not found")
+		}
+		return err
+	}
+	if archivedAt != nil {
+		return status.Error(codes.FailedPrecondition, "project is archived")
+	}
+	return requireProjectRole(ctx, tx, projectID, models.RoleEditor)
+}
+
+// projectsQuery selects projects visible to the caller in ctx along with
+// their owner and how many live notes in them the caller can read.
+func projectsQuery(ctx context.Context) sq.SelectBuilder {
+	// The inner select keeps "?" placeholders, psql renumbers them for the whole statement.
+	count := sq.Select("COUNT(*)").
+		From("notes n").
+		Where("n.project_id = p.id").
+		Where("n.deleted_at IS NULL")
+	if visible := visibleNotes(ctx); visible != nil {
+		count = count.Where(visible)
+	}
+	q := psql.Select(
+		"p.id", "p.name", "p.description", "p.owner_id", "p.created_at", "p.updated_at", "p.archived_at", "p.language",
+		"a.display_name AS owner_display_name", "a.avatar_url AS owner_avatar_url",
+	).
+		Column(sq.Alias(count, "note_count")).
+		From("projects p").
+		LeftJoin("actors a ON a.id = p.owner_id")
+	if visible := visibleProjects(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	return q
+}
+
+type projectRow struct {
+	models.Project
+	OwnerName      *string `db:"owner_display_name"`
+	OwnerAvatarURL *string `db:"owner_avatar_url"`
+}
+
+func (r projectRow) toProject() models.Project {
+	p := r.Project
+	p.Owner = &models.Actor{ID: p.OwnerID, DisplayName: r.OwnerName, AvatarURL: r.OwnerAvatarURL}
+	return p
+}
@@ -0,0 +1,173 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var projectColumns = []string{
+	"id", "name", "description", "owner_id", "created_at", "updated_at", "archived_at", "language",
+	"owner_display_name", "owner_avatar_url", "note_count",
+}
+
+func TestCreateProject_GrantsOwner(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).
+		WithArgs("alice", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO projects (id,name,description,owner_id,language) VALUES ($1,$2,$3,$4,$5::regconfig) RETURNING")).
+		WithArgs("security", "Security", nil, "alice", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "owner_id", "created_at", "updated_at", "archived_at", "language"}).
+			AddRow("security", "Security", nil, "alice", now, now, nil, nil))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO project_grants")).
+		WithArgs("security", models.PrincipalUser, "alice", models.RoleOwner, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"principal_type", "principal_id", "role", "granted_by", "created_at"}).
+			AddRow(models.PrincipalUser, "alice", "owner", nil, now))
+	mock.ExpectCommit()
+
+	p, err := d.CreateProject(context.Background(), models.CreateProjectInput{
+		ID:    "security",
+		Name:  "Security",
+		Owner: models.Actor{ID: "alice"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "alice", p.OwnerID)
+	require.Equal(t, "alice", p.Owner.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProject_Duplicate(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO projects")).WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	_, err := d.CreateProject(context.Background(), models.CreateProjectInput{ID: "p1", Name: "P", Owner: models.Actor{ID: "alice"}})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProject_UnknownLanguage(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO projects")).
+		WithArgs("p1", "P", nil, "alice", "klingon").
+		WillReturnError(&pq.Error{Code: "42704", Message: `text search configuration "klingon" does not exist`})
+	mock.ExpectRollback()
+
+	_, err := d.CreateProject(context.Background(), models.CreateProjectInput{
+		ID: "p1", Name: "P", Owner: models.Actor{ID: "alice"}, Language: ptrString("klingon"),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateProject_BeginErrorIsInternal(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+	_, err := d.CreateProject(context.Background(), models.CreateProjectInput{
+		ID:    "security",
+		Name:  "Security",
+		Owner: models.Actor{ID: "alice"},
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListProjects_CountsVisibleNotes(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(`(?s)\(SELECT COUNT\(\*\) FROM notes n WHERE n\.project_id = p\.id AND n\.deleted_at IS NULL AND \(n\.author_id = \$1.*\) AS note_count FROM projects p LEFT JOIN actors a ON a\.id = p\.owner_id WHERE \(p\.owner_id = \$\d+.* AND p\.archived_at IS NULL ORDER BY p\.name, p\.id LIMIT 10`).
+		WillReturnRows(sqlmock.NewRows(projectColumns).
+			AddRow("security", "Security", nil, "alice", now, now, nil, "german", "Alice", nil, int64(4)))
+
+	projects, next, err := d.ListProjects(callerCtx("bob", "eng"), models.ListProjectsFilter{})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Len(t, projects, 1)
+	require.Equal(t, int64(4), projects[0].NoteCount)
+	require.Equal(t, "Alice", *projects[0].Owner.DisplayName)
+	require.Equal(t, "german", *projects[0].Language)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestArchiveProject_RequiresOwner(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	expectProjectAccess(mock, 2)
+	mock.ExpectRollback()
+
+	_, err := d.ArchiveProject(callerCtx("bob"), "security")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateNote_ArchivedProject(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id=$1 FOR SHARE")).
+		WithArgs("old").
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(time.Now()))
+	mock.ExpectRollback()
+
+	_, err := d.CreateNote(context.Background(), models.CreateNoteInput{
+		ID:        "note-1",
+		ProjectID: ptrString("old"),
+		Title:     "t",
+		Author:    models.Actor{ID: "alice"},
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateNote_UnsharedProjectIsOwnerOnly(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT archived_at FROM projects WHERE id=$1 FOR SHARE")).
+		WithArgs("security").
+		WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	expectProjectAccess(mock, 0)
+	mock.ExpectRollback()
+
+	_, err := d.CreateNote(callerCtx("bob"), models.CreateNoteInput{
+		ID:        "note-1",
+		ProjectID: ptrString("security"),
+		Title:     "t",
+		Author:    models.Actor{ID: "bob"},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
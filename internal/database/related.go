@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+)
+
+const (
+	defaultRelatedNotes = 10
+	maxRelatedNotes     = 50
+	// relatedQueryTerms is how many of the source note's most distinctive
+	// terms candidates are scored against.
+	relatedQueryTerms = 32
+)
+
+// relatedScores scores every note sharing a term with the source note by
+// BM25 (k1 = 1.2, b = 0.75) over note_terms, using the source's
+// relatedQueryTerms terms of highest tf-idf as the query.
+const relatedScores = `SELECT o.note_id, SUM(q.idf * o.tf * 2.2 / (o.tf + 1.2 * (0.25 + 0.75 * l.length / c.avg_length))) AS score
+	FROM (
+		SELECT t.term, ln(1 + (c.n - s.doc_count + 0.5) / (s.doc_count + 0.5)) AS idf
+		FROM note_terms t
+		JOIN term_stats s ON s.term = t.term
+		CROSS JOIN corpus c
+		WHERE t.note_id = ?
+		ORDER BY t.tf * ln(1 + (c.n - s.doc_count + 0.5) / (s.doc_count + 0.5)) DESC, t.term
+		LIMIT ?
+	) q
+	JOIN note_terms o ON o.term = q.term
+	JOIN note_lengths l ON l.note_id = o.note_id
+	CROSS JOIN corpus c
+	WHERE o.note_id <> ?
+	GROUP BY o.note_id`
+
+// GetRelatedNotes returns up to limit live notes the caller can read that
+// share the most distinctive terms of noteID, best first. The caller needs to
+// be able to read noteID itself.
+func (d *Database) GetRelatedNotes(ctx context.Context, noteID string, limit int) ([]models.RelatedNote, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+	if err := requireNoteRole(ctx, d.Db, noteID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultRelatedNotes
+	} else if limit > maxRelatedNotes {
+		limit = maxRelatedNotes
+	}
+
+	q := notesSelect().
+		Prefix("WITH corpus AS (SELECT COUNT(*)::float8 AS n, GREATEST(AVG(length), 1)::float8 AS avg_length FROM note_lengths)").
+		Column("r.score").
+		JoinClause(sq.Expr("JOIN ("+relatedScores+") r ON r.note_id = n.id", noteID, relatedQueryTerms, noteID)).
+		Where("n.deleted_at IS NULL").
+		OrderBy("r.score DESC", "n.id").
+		Limit(uint64(limit))
+	if visible := visibleNotes(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		listNotesRow
+		Score float32 `db:"score"`
+	}
+	if err := d.Db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	related := make([]models.RelatedNote, 0, len(rows))
+	for _, r := range rows {
+		related = append(related, models.RelatedNote{Note: r.toNote(), Score: r.Score})
+	}
+	return related, nil
+}
@@ -0,0 +1,45 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetRelatedNotes(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)^WITH corpus AS \(.*FROM note_lengths\) SELECT n\.id, .*, r\.score FROM notes n LEFT JOIN actors a ON a\.id = n\.author_id `+
+		`JOIN \(SELECT o\.note_id, .*WHERE t\.note_id = \$1 .*LIMIT \$2 .*WHERE o\.note_id <> \$3 GROUP BY o\.note_id\) r ON r\.note_id = n\.id `+
+		`WHERE n\.deleted_at IS NULL ORDER BY r\.score DESC, n\.id LIMIT 50$`).
+		WithArgs("note-1", 32, "note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "score"}).
+			AddRow("note-2", "Checkout outage", 3.5).
+			AddRow("note-3", "Payments latency", 1.25))
+
+	related, err := d.GetRelatedNotes(context.Background(), "note-1", 500)
+	require.NoError(t, err)
+	require.Len(t, related, 2)
+	require.Equal(t, "note-2", related[0].Note.ID)
+	require.Equal(t, float32(3.5), related[0].Score)
+	require.Equal(t, "Payments latency", related[1].Note.Title)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetRelatedNotes_UnreadableSource(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)SELECT GREATEST\(.*FROM notes n WHERE n\.id = \$\d+`).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := d.GetRelatedNotes(callerCtx("bob"), "note-1", 0)
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
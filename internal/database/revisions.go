@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// insertRevision snapshots the current title/content of a note into
+// note_revisions before it gets overwritten, credited to whoever last changed
+// them and dated to when the note was last updated. The snapshot is skipped
+// when the update would not change either field.
+func insertRevision(ctx context.Context, tx *sqlx.Tx, in models.UpdateNoteInput) error {
+	changed := sq.Or{}
+	if in.Title != nil {
+		changed = append(changed, sq.NotEq{"n.title": *in.Title})
+	}
+	if in.Content != nil {
+		changed = append(changed, sq.Expr("n.content IS DISTINCT FROM ?", *in.Content))
+	}
+
+	// The inner select keeps "?" placeholders, psql renumbers them for the whole statement.
+	snapshot := sq.Select().
+		Column("?", uuid.NewString()).
+		Columns("n.id", "n.title", "COALESCE(n.content, '')").
+		Columns("COALESCE(n.last_editor_id, n.author_id)", "n.updated_at").
+		From("notes n").
+		Where(sq.Eq{"n.id": in.NoteID}).
+		Where(changed)
+	if in.IfMatchUpdatedAt != nil {
+		snapshot = snapshot.Where(sq.Eq{"n.updated_at": *in.IfMatchUpdatedAt})
+	}
+
+	query, args, err := psql.Insert("note_revisions").
+		Columns("id", "note_id", "title", "content", "editor_id", "edited_at").
+		Select(snapshot).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+// ListNoteRevisions returns the revisions of a note, newest first, using the
+// same keyset pagination tokens as ListNotes.
+func (d *Database) ListNoteRevisions(ctx context.Context, filter models.ListNoteRevisionsFilter) ([]models.NoteRevision, string, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	if err := requireNoteRole(ctx, d.Db, filter.NoteID, models.RoleViewer); err != nil {
+		return nil, "", err
+	}
+
+	filter.PageSize = clampPageSize(filter.PageSize)
+
+	q := psql.Select(
+		"r.id", "r.note_id", "r.title", "r.content", "r.editor_id", "r.edited_at",
+		"e.display_name AS editor_display_name", "e.avatar_url AS editor_avatar_url",
+	).
+		From("note_revisions r").
+		LeftJoin("actors e ON e.id = r.editor_id").
+		Where(sq.Eq{"r.note_id": filter.NoteID}).
+		OrderBy("r.edited_at DESC", "r.id DESC").
+		Limit(uint64(filter.PageSize))
+
+	if filter.PageToken != "" {
+		c, err := utils.DecodePaginationToken(filter.PageToken)
+		if err != nil || c.SortBy != "edited_at" {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		q = q.Where("(r.edited_at < ? OR (r.edited_at = ? AND r.id < ?))", c.Key, c.Key, c.ID)
+	}
+
+	sqlStr, args, err := q.ToSql()
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to build query: %v", err)
+	}
+
+	type row struct {
+		models.NoteRevision
+		EditorName      *string `db:"editor_display_name"`
+		EditorAvatarURL *string `db:"editor_avatar_url"`
+	}
+	var rows []row
+	if err := d.Db.SelectContext(ctx, &rows, sqlStr, args...); err != nil {
+		return nil, "", status.Errorf(codes.Internal, "query failed: %v", err)
+	}
+
+	revisions := make([]models.NoteRevision, 0, len(rows))
+	for _, row := range rows {
+		r := row.NoteRevision
+		r.Editor = &models.Actor{ID: r.EditorID, DisplayName: row.EditorName, AvatarURL: row.EditorAvatarURL}
+		revisions = append(revisions, r)
+	}
+
+	var next string
+	if len(revisions) == filter.PageSize {
+		last := revisions[len(revisions)-1]
+		cur := utils.NotesPagination{
+			Key:       last.EditedAt.UTC().Format(time.RFC3339Nano),
+			KeyType:   "time",
+			ID:        last.ID,
+			SortBy:    "edited_at",
+			Direction: "DESC",
+		}
+		if s, err := utils.EncodePaginationToken(cur); err == nil {
+			next = s
+		}
+	}
+	return revisions, next, nil
+}
+
+// RestoreNoteRevision copies a revision's title and content back onto the note.
+// The state being replaced is recorded as a new revision so the restore can be
+// undone the same way.
+func (d *Database) RestoreNoteRevision(ctx context.Context, in models.RestoreNoteRevisionInput) (*models.Note, error) {
+	d.Mu.Lock()
+	err := d.restoreNoteRevision(ctx, in)
+	d.Mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return d.ViewNote(ctx, in.NoteID, models.GetNoteOptions{IncludeRevisions: false, IncludeAttachments: true})
+}
+
+func (d *Database) restoreNoteRevision(ctx context.Context, in models.RestoreNoteRevisionInput) error {
+	if err := requireNoteRole(ctx, d.Db, in.NoteID, models.RoleEditor); err != nil {
+		return err
+	}
+	rev, err := getRevision(ctx, d.Db, in.NoteID, in.RevisionID)
+	if err != nil {
+		return err
+	}
+
+	return d.updateNote(ctx, models.UpdateNoteInput{
+		NoteID:           in.NoteID,
+		Title:            &rev.Title,
+		Content:          &rev.Content,
+		IfMatchUpdatedAt: in.IfMatchUpdatedAt,
+		Editor:           in.Editor,
+		CreateRevision:   true,
+	})
+}
+
+// GetNoteRevision returns a single revision of a note.
+func (d *Database) GetNoteRevision(ctx context.Context, noteID, revisionID string) (*models.NoteRevision, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+	if err := requireNoteRole(ctx, d.Db, noteID, models.RoleViewer); err != nil {
+		return nil, err
+	}
+	return getRevision(ctx, d.Db, noteID, revisionID)
+}
+
+func getRevision(ctx context.Context, q sqlx.QueryerContext, noteID, revisionID string) (*models.NoteRevision, error) {
+	query, args, err := psql.Select("id", "note_id", "title", "content", "editor_id", "edited_at").
+		From("note_revisions").
+		Where(sq.Eq{"id": revisionID, "note_id": noteID}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var rev models.NoteRevision
+	if err := sqlx.GetContext(ctx, q, &rev, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "revision not found")
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
+
+func clampPageSize(size int) int {
+	if size < 10 {
+		return 10
+	} else if size > 100 {
+		return 100
+	}
+	return size
+}
@@ -0,0 +1,189 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUpdateNote_RecordsRevision(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	noteID := "note-123"
+	editor := models.Actor{ID: "actor-2", DisplayName: ptrString("Bob"), AvatarURL: ptrString("https://avatar/actor-2")}
+
+	in := models.UpdateNoteInput{
+		NoteID:         noteID,
+		Title:          ptrString("New title"),
+		Editor:         &editor,
+		CreateRevision: true,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).
+		WithArgs(editor.ID, editor.DisplayName, editor.AvatarURL).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// The replaced title is credited to whoever wrote it and when, not to
+	// the editor replacing it now.
+	mock.ExpectExec(`(?s)INSERT INTO note_revisions \(id,note_id,title,content,editor_id,edited_at\) SELECT \$1, n\.id, n\.title, COALESCE\(n\.content, ''\), COALESCE\(n\.last_editor_id, n\.author_id\), n\.updated_at FROM notes n WHERE n\.id = \$2 AND \(n\.title <> \$3\)`).
+		WithArgs(sqlmock.AnyArg(), noteID, "New title").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE notes SET title = $1, last_editor_id = $2 WHERE id = $3 AND deleted_at IS NULL RETURNING id")).
+		WithArgs("New title", editor.ID, noteID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(noteID))
+	expectNoteEvent(mock, models.NoteEventUpdated, noteID)
+	mock.ExpectCommit()
+
+	cols := []string{
+		"id", "project_id", "author_id", "title", "content", "is_pinned", "created_at", "updated_at",
+		"author_display_name", "author_avatar_url", "tags",
+	}
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .* WHERE n\.id = \$1`).
+		WithArgs(noteID).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(noteID, nil, "actor-1", "New title", nil, false, now, now, "Alice", nil, "{}"))
+
+	note, err := d.UpdateNote(context.Background(), in)
+	require.NoError(t, err)
+	require.Equal(t, "New title", note.Title)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNoteRevisions_Paginates(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	cols := []string{"id", "note_id", "title", "content", "editor_id", "edited_at", "editor_display_name", "editor_avatar_url"}
+	rows := sqlmock.NewRows(cols)
+	for i := 0; i < 10; i++ {
+		rows.AddRow("rev-"+strconv.Itoa(i), "note-1", "Title "+strconv.Itoa(i), "content", "actor-1", now.Add(-time.Duration(i)*time.Minute), "Alice", nil)
+	}
+
+	mock.ExpectQuery(`(?s)^SELECT .* FROM note_revisions r LEFT JOIN actors e ON e\.id = r\.editor_id WHERE r\.note_id = \$1 ORDER BY r\.edited_at DESC, r\.id DESC LIMIT 10`).
+		WithArgs("note-1").
+		WillReturnRows(rows)
+
+	revisions, next, err := d.ListNoteRevisions(context.Background(), models.ListNoteRevisionsFilter{NoteID: "note-1"})
+	require.NoError(t, err)
+	require.Len(t, revisions, 10)
+	require.NotEmpty(t, next)
+	require.Equal(t, "Alice", *revisions[0].Editor.DisplayName)
+
+	mock.ExpectQuery(`(?s)^SELECT .* FROM note_revisions r .*WHERE r\.note_id = \$1 AND \(r\.edited_at < \$2 OR \(r\.edited_at = \$3 AND r\.id < \$4\)\)`).
+		WithArgs("note-1", sqlmock.AnyArg(), sqlmock.AnyArg(), "rev-9").
+		WillReturnRows(sqlmock.NewRows(cols))
+
+	revisions, next, err = d.ListNoteRevisions(context.Background(), models.ListNoteRevisionsFilter{NoteID: "note-1", PageToken: next})
+	require.NoError(t, err)
+	require.Empty(t, revisions)
+	require.Empty(t, next)
+
+	_, _, err = d.ListNoteRevisions(context.Background(), models.ListNoteRevisionsFilter{NoteID: "note-1", PageToken: "garbage"})
+	require.Error(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreNoteRevision(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	noteID := "note-1"
+
+	mock.ExpectQuery(`(?s)^SELECT id, note_id, title, content, editor_id, edited_at FROM note_revisions WHERE id = \$1 AND note_id = \$2`).
+		WithArgs("rev-1", noteID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "note_id", "title", "content", "editor_id", "edited_at"}).
+			AddRow("rev-1", noteID, "Old title", "old content", "actor-1", now.Add(-time.Hour)))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`(?s)INSERT INTO note_revisions .* WHERE n\.id = \$2 AND \(n\.title <> \$3 OR n\.content IS DISTINCT FROM \$4\) AND n\.updated_at = \$5`).
+		WithArgs(sqlmock.AnyArg(), noteID, "Old title", "old content", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("UPDATE\\s+notes.*RETURNING\\s+id").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(noteID))
+	expectNoteEvent(mock, models.NoteEventUpdated, noteID)
+	mock.ExpectCommit()
+
+	cols := []string{
+		"id", "project_id", "author_id", "title", "content", "is_pinned", "created_at", "updated_at",
+		"author_display_name", "author_avatar_url", "tags",
+	}
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .* WHERE n\.id = \$1`).
+		WithArgs(noteID).
+		WillReturnRows(sqlmock.NewRows(cols).AddRow(noteID, nil, "actor-1", "Old title", "old content", false, now, now, "Alice", nil, "{}"))
+
+	note, err := d.RestoreNoteRevision(context.Background(), models.RestoreNoteRevisionInput{
+		NoteID:           noteID,
+		RevisionID:       "rev-1",
+		IfMatchUpdatedAt: &now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Old title", note.Title)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNote_IfMatchConflict(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	stale := time.Now().UTC().Add(-time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE\\s+notes.*RETURNING\\s+id").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM notes WHERE id=$1 AND deleted_at IS NULL)")).
+		WithArgs("note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
+
+	_, err := d.UpdateNote(context.Background(), models.UpdateNoteInput{
+		NoteID:           "note-1",
+		IsPinned:         ptrBool(true),
+		IfMatchUpdatedAt: &stale,
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestViewNote_IncludesRevisionsAndAttachments(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	cols := []string{"id", "author_id", "title", "created_at", "updated_at", "tags", "revisions", "attachments"}
+	revisions := `[
+		{"id":"rev-3","note_id":"note-1","title":"t3","content":"c3","editor_id":"actor-1","edited_at":"2026-01-03T10:00:00+00:00","editor":{"id":"actor-1","display_name":"Alice","avatar_url":null}},
+		{"id":"rev-2","note_id":"note-1","title":"t2","content":"c2","editor_id":"actor-1","edited_at":"2026-01-02T10:00:00+00:00","editor":{"id":"actor-1","display_name":"Alice","avatar_url":null}},
+		{"id":"rev-1","note_id":"note-1","title":"t1","content":"c1","editor_id":"actor-1","edited_at":"2026-01-01T10:00:00+00:00","editor":{"id":"actor-1","display_name":"Alice","avatar_url":null}}
+	]`
+	attachments := `[{"id":"att-1","note_id":"note-1","url":"https://files/att-1","file_name":"a.png","file_type":"image/png","uploaded_at":"2026-01-01T10:00:00.5+00:00","sha256":null,"size_bytes":12}]`
+	now := time.Now().UTC()
+
+	mock.ExpectQuery(`(?s)^SELECT .*rv\.revisions, att\.attachments FROM notes n .*LEFT JOIN LATERAL .*LIMIT 3\s+\) r.* rv ON TRUE LEFT JOIN LATERAL .* att ON TRUE WHERE n\.id = \$1`).
+		WithArgs("note-1").
+		WillReturnRows(sqlmock.NewRows(cols).AddRow("note-1", "actor-1", "Title", now, now, "{}", revisions, attachments))
+
+	note, err := d.ViewNote(context.Background(), "note-1", models.GetNoteOptions{
+		IncludeRevisions:   true,
+		IncludeAttachments: true,
+		RevisionsLimit:     2,
+	})
+	require.NoError(t, err)
+	require.Len(t, note.Revisions, 2)
+	require.True(t, note.HasMoreRevisions)
+	require.Equal(t, "rev-3", note.Revisions[0].ID)
+	require.Equal(t, "Alice", *note.Revisions[0].Editor.DisplayName)
+	require.Len(t, note.Attachments, 1)
+	require.Equal(t, int64(12), *note.Attachments[0].SizeBytes)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
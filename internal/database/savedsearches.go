@@ -0,0 +1,211 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CreateSavedSearch stores a saved search owned by in.Owner.
+func (d *Database) CreateSavedSearch(ctx context.Context, in models.CreateSavedSearchInput) (*models.SavedSearch, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	query, args, err := psql.Insert("actors").
+		Columns("id", "display_name", "avatar_url").
+		Values(in.Owner.ID, in.Owner.DisplayName, in.Owner.AvatarURL).
+		Suffix(upsertActorSuffix).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, err
+	}
+
+	query, args, err = psql.Insert("saved_searches").
+		Columns("id", "name", "owner_id", "request").
+		Values(in.ID, in.Name, in.Owner.ID, string(in.Request)).
+		Suffix("RETURNING id, name, owner_id, request, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var s models.SavedSearch
+	if err := tx.GetContext(ctx, &s, query, args...); err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return nil, status.Error(codes.AlreadyExists, "saved search already exists")
+		}
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	s.Owner = &in.Owner
+	return &s, nil
+}
+
+// GetSavedSearch returns a saved search the caller owns or has a grant on.
+func (d *Database) GetSavedSearch(ctx context.Context, id string) (*models.SavedSearch, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	query, args, err := savedSearchesQuery(ctx).Where(sq.Eq{"s.id": id}).ToSql()
+	if err != nil {
+		return nil, err
+	}
+	var row savedSearchRow
+	if err := d.Db.GetContext(ctx, &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "saved search not found")
+		}
+		return nil, err
+	}
+	s := row.toSavedSearch()
+	return &s, nil
+}
+
+// ListSavedSearches returns the saved searches the caller owns or has a grant
+// on, ordered by name, with the same keyset page tokens as ListProjects.
+func (d *Database) ListSavedSearches(ctx context.Context, filter models.ListSavedSearchesFilter) ([]models.SavedSearch, string, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	filter.PageSize = clampPageSize(filter.PageSize)
+	q := savedSearchesQuery(ctx).
+		OrderBy("s.name", "s.id").
+		Limit(uint64(filter.PageSize))
+	if filter.PageToken != "" {
+		c, err := utils.DecodePaginationToken(filter.PageToken)
+		if err != nil || c.SortBy != "name" {
+			return nil, "", status.Error(codes.InvalidArgument, "invalid page token")
+		}
+		q = q.Where("(s.name > ? OR (s.name = ? AND s.id > ?))", c.Key, c.Key, c.ID)
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to build query: %v", err)
+	}
+	var rows []savedSearchRow
+	if err := d.Db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, "", status.Errorf(codes.Internal, "query failed: %v", err)
+	}
+	searches := make([]models.SavedSearch, 0, len(rows))
+	for _, r := range rows {
+		searches = append(searches, r.toSavedSearch())
+	}
+
+	var next string
+	if len(searches) == filter.PageSize {
+		last := searches[len(searches)-1]
+		cur := utils.NotesPagination{
+			Key:       last.Name,
+			KeyType:   "string",
+			ID:        last.ID,
+			SortBy:    "name",
+			Direction: "ASC",
+		}
+		if s, err := utils.EncodePaginationToken(cur); err == nil {
+			next = s
+		}
+	}
+	return searches, next, nil
+}
+
+// DeleteSavedSearch removes a saved search and its grants. Only owners can.
+func (d *Database) DeleteSavedSearch(ctx context.Context, id string) (bool, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	if err := requireSavedSearchRole(ctx, d.Db, id, models.RoleOwner); err != nil {
+		return false, err
+	}
+	res, err := d.Db.ExecContext(ctx, `DELETE FROM saved_searches WHERE id=$1`, id)
+	if err != nil {
+		return false, err
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return ra > 0, nil
+}
+
+// ShareSavedSearch grants g.Role on a saved search, replacing any grant the
+// principal already has. Only owners can share.
+func (d *Database) ShareSavedSearch(ctx context.Context, id string, g models.Grant) (*models.Grant, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := requireSavedSearchRole(ctx, tx, id, models.RoleOwner); err != nil {
+		return nil, err
+	}
+	out, err := upsertGrant(ctx, tx, "saved_search_grants", "saved_search_id", id, g)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UnshareSavedSearch removes the principal's grant on a saved search.
+func (d *Database) UnshareSavedSearch(ctx context.Context, id string, p models.Principal) (bool, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	if err := requireSavedSearchRole(ctx, d.Db, id, models.RoleOwner); err != nil {
+		return false, err
+	}
+	return deleteGrant(ctx, d.Db, "saved_search_grants", "saved_search_id", id, p)
+}
+
+// savedSearchesQuery selects the saved searches visible to the caller in ctx
+// along with their owner.
+func savedSearchesQuery(ctx context.Context) sq.SelectBuilder {
+	q := psql.Select(
+		"s.id", "s.name", "s.owner_id", "s.request", "s.created_at", "s.updated_at",
+		"a.display_name AS owner_display_name", "a.avatar_url AS owner_avatar_url",
+	).
+		From("saved_searches s").
+		LeftJoin("actors a ON a.id = s.owner_id")
+	if c, ok := auth.FromContext(ctx); ok {
+		q = q.Where(sq.Expr("? > 0", savedSearchRankExpr(c)))
+	}
+	return q
+}
+
+type savedSearchRow struct {
+	models.SavedSearch
+	OwnerName      *string `db:"owner_display_name"`
+	OwnerAvatarURL *string `db:"owner_avatar_url"`
+}
+
+func (r savedSearchRow) toSavedSearch() models.SavedSearch {
+	s := r.SavedSearch
+	s.Owner = &models.Actor{ID: s.OwnerID, DisplayName: r.OwnerName, AvatarURL: r.OwnerAvatarURL}
+	return s
+}
@@ -0,0 +1,82 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateSavedSearch(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	req := `{"query":"pinned:true tag:incident"}`
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).
+		WithArgs("alice", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO saved_searches (id,name,owner_id,request) VALUES ($1,$2,$3,$4) RETURNING")).
+		WithArgs("s1", "Incidents", "alice", req).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "owner_id", "request", "created_at", "updated_at"}).
+			AddRow("s1", "Incidents", "alice", []byte(req), now, now))
+	mock.ExpectCommit()
+
+	s, err := d.CreateSavedSearch(context.Background(), models.CreateSavedSearchInput{
+		ID: "s1", Name: "Incidents", Request: []byte(req), Owner: models.Actor{ID: "alice"},
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, req, string(s.Request))
+	require.Equal(t, "alice", s.Owner.ID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListSavedSearches_OwnedOrGranted(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectQuery(`(?s)FROM saved_searches s LEFT JOIN actors a ON a\.id = s\.owner_id WHERE GREATEST\(\s*CASE WHEN s\.owner_id = \$1 THEN 3 ELSE 0 END,.*saved_search_grants sg.*\) > 0 ORDER BY s\.name, s\.id LIMIT 10`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "owner_id", "request", "created_at", "updated_at", "owner_display_name", "owner_avatar_url"}).
+			AddRow("s1", "Incidents", "alice", []byte(`{}`), now, now, "Alice", nil))
+
+	searches, next, err := d.ListSavedSearches(callerCtx("bob", "oncall"), models.ListSavedSearchesFilter{})
+	require.NoError(t, err)
+	require.Empty(t, next)
+	require.Len(t, searches, 1)
+	require.Equal(t, "Alice", *searches[0].Owner.DisplayName)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteSavedSearch_RequiresOwner(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)SELECT GREATEST\(.*FROM saved_searches s WHERE s\.id = \$\d+`).
+		WillReturnRows(sqlmock.NewRows([]string{"greatest"}).AddRow(1))
+
+	_, err := d.DeleteSavedSearch(callerCtx("bob"), "s1")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountNotes(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)^SELECT COUNT\(\*\) FROM \(SELECT n\.id, .* WHERE n\.deleted_at IS NULL AND n\.is_pinned = \$1 ORDER BY n\.updated_at ASC, n\.id ASC\) AS matches$`).
+		WithArgs(true).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	n, err := d.CountNotes(context.Background(), models.ListNotesFilter{Query: ptrString("pinned:true"), PageToken: "ignored"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
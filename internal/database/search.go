@@ -0,0 +1,179 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/search"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ts_headline wraps matches in these private use characters, which
+// splitHeadline turns into offsets.
+const (
+	headlineStart = '\ue000'
+	headlineStop  = '\ue001'
+)
+
+var headlineOptions = fmt.Sprintf(`StartSel=%c, StopSel=%c, MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "`, headlineStart, headlineStop)
+
+// noteSearch is a compiled ListNotes query over notes aliased n joined with
+// their author's actors row aliased a. Values only ever reach the database as
+// arguments.
+type noteSearch struct {
+	cond sq.Sqlizer // nil for an empty query
+	// tsquery ORs together the words and phrases a match may contain, nil
+	// when the query has none.
+	tsquery sq.Sqlizer
+	// config is the text search configuration words are read in.
+	config sq.Sqlizer
+}
+
+// parseNoteSearch fails with InvalidArgument, naming the position, for a
+// malformed query. Words are read in language, or in each note's own
+// language when it is nil; only a fixed language lets the planner use
+// idx_notes_search_vector.
+func parseNoteSearch(query string, language *string) (noteSearch, error) {
+	node, err := search.Parse(query)
+	if err != nil {
+		var serr *search.Error
+		if errors.As(err, &serr) {
+			return noteSearch{}, status.Errorf(codes.InvalidArgument, "invalid query at position %d: %s", serr.Pos, serr.Msg)
+		}
+		return noteSearch{}, err
+	}
+	if node == nil {
+		return noteSearch{}, nil
+	}
+	s := noteSearch{config: sq.Expr("n.language")}
+	if language != nil {
+		s.config = sq.Expr("?::regconfig", *language)
+	}
+	s.cond = s.compile(node)
+	if terms := search.Terms(node); len(terms) > 0 {
+		parts := make([]string, 0, len(terms))
+		args := make([]interface{}, 0, 2*len(terms))
+		for _, t := range terms {
+			parts = append(parts, tsqueryFunc(t)+"(?, ?)")
+			args = append(args, s.config, t.Value)
+		}
+		s.tsquery = sq.Expr("("+strings.Join(parts, " || ")+")", args...)
+	}
+	return s, nil
+}
+
+// rank is the ts_rank_cd of a note against the search's words and phrases.
+func (s noteSearch) rank() sq.Sqlizer {
+	return sq.Expr("ts_rank_cd(n.search_vector, ?)", s.tsquery)
+}
+
+// headline is an excerpt of title and content around the best matches.
+func (s noteSearch) headline() sq.Sqlizer {
+	return sq.Expr(`ts_headline(?, coalesce(n.title,'') || E'\n' || coalesce(n.content,''), ?, ?)`, s.config, s.tsquery, headlineOptions)
+}
+
+// splitHeadline strips the match markers out of a ts_headline result and
+// returns where they were, in characters.
+func splitHeadline(h string) (string, []models.TextRange) {
+	var b strings.Builder
+	var ranges []models.TextRange
+	n, start := 0, -1
+	for _, r := range h {
+		switch r {
+		case headlineStart:
+			start = n
+		case headlineStop:
+			if start >= 0 && n > start {
+				ranges = append(ranges, models.TextRange{Start: start, End: n})
+			}
+			start = -1
+		default:
+			b.WriteRune(r)
+			n++
+		}
+	}
+	return b.String(), ranges
+}
+
+func tsqueryFunc(t search.Text) string {
+	if t.Phrase {
+		return "phraseto_tsquery"
+	}
+	return "plainto_tsquery"
+}
+
+func (s noteSearch) compile(node search.Node) sq.Sqlizer {
+	switch n := node.(type) {
+	case search.And:
+		and := sq.And{}
+		for _, c := range n.Nodes {
+			and = append(and, s.compile(c))
+		}
+		return and
+	case search.Or:
+		or := sq.Or{}
+		for _, c := range n.Nodes {
+			or = append(or, s.compile(c))
+		}
+		return or
+	case search.Not:
+		// NULL (say, no display name to compare) has to count as no match,
+		// or negating it would drop the row as well.
+		return sq.Expr("NOT COALESCE(?, false)", s.compile(n.Node))
+	case search.Text:
+		document := "n.search_vector"
+		if n.InTitle {
+			// Titles are the lexemes weighted A.
+			document = "ts_filter(n.search_vector, '{a}')"
+		}
+		return sq.Expr(fmt.Sprintf("%s @@ %s(?, ?)", document, tsqueryFunc(n)), s.config, n.Value)
+	case search.Field:
+		switch n.Name {
+		case "tag":
+			tag := utils.NormalizeTag(n.Value)
+			return sq.Expr("EXISTS (SELECT 1 FROM note_tags nt WHERE nt.note_id = n.id AND "+tagUnder("nt.tag", "?")+")", tag, tag)
+		case "author":
+			return sq.Expr("(n.author_id = ? OR lower(a.display_name) = lower(?))", n.Value, n.Value)
+		default:
+			return sq.Expr("(n.project_id = ? OR EXISTS (SELECT 1 FROM projects sp WHERE sp.id = n.project_id AND lower(sp.name) = lower(?)))", n.Value, n.Value)
+		}
+	case search.Pinned:
+		return sq.Eq{"n.is_pinned": n.Value}
+	case search.DateRange:
+		column := "n.updated_at"
+		if n.Name == "created" {
+			column = "n.created_at"
+		}
+		and := sq.And{}
+		if !n.From.IsZero() {
+			and = append(and, sq.GtOrEq{column: n.From})
+		}
+		if !n.To.IsZero() {
+			and = append(and, sq.Lt{column: n.To})
+		}
+		return and
+	}
+	panic(fmt.Sprintf("search: unexpected node %T", node))
+}
+
+// languageError turns the error for an unknown text search configuration
+// into InvalidArgument and returns any other error unchanged.
+func languageError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && (pqErr.Code == "42704" || pqErr.Code == "3F000") {
+		return status.Errorf(codes.InvalidArgument, "unknown language: %s", pqErr.Message)
+	}
+	return err
+}
+
+// noteLanguage is the language a note is given: language when set, else the
+// language of the project projectID selects, else english.
+func noteLanguage(language *string, projectID sq.Sqlizer) sq.Sqlizer {
+	return sq.Expr("COALESCE(?::regconfig, (SELECT p.language FROM projects p WHERE p.id = ?), 'english')", language, projectID)
+}
@@ -0,0 +1,111 @@
+package database_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListNotes_SearchQuery(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE n.deleted_at IS NULL AND "+
+		"((n.search_vector @@ phraseto_tsquery(n.language, $6) "+
+		"OR ts_filter(n.search_vector, '{a}') @@ plainto_tsquery(n.language, $7)) "+
+		"AND NOT COALESCE(EXISTS (SELECT 1 FROM note_tags nt WHERE nt.note_id = n.id AND (nt.tag = $8 OR starts_with(nt.tag, $9 || '/'))), false) "+
+		"AND n.is_pinned = $10 "+
+		"AND (n.updated_at >= $11))")).
+		WithArgs("release notes", "deploy", "release notes", "deploy", sqlmock.AnyArg(),
+			"release notes", "deploy", "eng/old", "eng/old", true, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, _, err := d.ListNotes(context.Background(), models.ListNotesFilter{
+		Query: ptrString(`("release notes" OR title:deploy) -tag:Eng/Old pinned:true updated:>2026-01-01`),
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotes_MalformedQuery(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	_, _, err := d.ListNotes(context.Background(), models.ListNotesFilter{Query: ptrString(`deploy "release`)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "position 8")
+
+	err = d.StreamNotes(context.Background(), models.ListNotesFilter{Query: ptrString("a OR")}, func(models.Note) error { return nil })
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotes_Relevance(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	query := "deploy -draft"
+	rows := sqlmock.NewRows([]string{"id", "title", "search_rank", "search_snippet"})
+	for i := 0; i < 10; i++ {
+		rows.AddRow(fmt.Sprintf("note-%d", i), "t", float32(1)/float32(i+1), "how to \ue000deploy\ue001 the \ue000deployer\ue001 …")
+	}
+	mock.ExpectQuery(`(?s)ts_rank_cd\(n\.search_vector, \(plainto_tsquery\(n\.language, \$1\)\)\)\) AS search_rank, .*ORDER BY search_rank DESC, n\.id DESC LIMIT 10$`).
+		WillReturnRows(rows)
+
+	notes, next, err := d.ListNotes(context.Background(), models.ListNotesFilter{Query: &query, SortBy: "relevance"})
+	require.NoError(t, err)
+	require.Len(t, notes, 10)
+	require.Equal(t, "how to deploy the deployer …", notes[0].Match.Snippet)
+	require.Equal(t, []models.TextRange{{Start: 7, End: 13}, {Start: 18, End: 26}}, notes[0].Match.Highlights)
+	require.NotEmpty(t, next)
+
+	// The next page continues below the last rank.
+	mock.ExpectQuery(`(?s)AND \(ts_rank_cd\(.*\) < \$\d+::real OR \(ts_rank_cd\(.*\) = \$\d+::real AND n\.id < \$\d+\)\) ORDER BY search_rank DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, _, err = d.ListNotes(context.Background(), models.ListNotesFilter{Query: &query, SortBy: "relevance", PageToken: next})
+	require.NoError(t, err)
+
+	// Ranks from another search mean nothing for this one.
+	other := "release"
+	_, _, err = d.ListNotes(context.Background(), models.ListNotesFilter{Query: &other, SortBy: "relevance", PageToken: next})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	// Nor do ranks from the same words read in another language.
+	_, _, err = d.ListNotes(context.Background(), models.ListNotesFilter{Query: &query, Language: ptrString("german"), SortBy: "relevance", PageToken: next})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotes_SearchLanguage(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE n.deleted_at IS NULL AND n.search_vector @@ plainto_tsquery($7::regconfig, $8)")).
+		WithArgs("german", "häuser", "german", "german", "häuser", sqlmock.AnyArg(), "german", "häuser").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, _, err := d.ListNotes(context.Background(), models.ListNotesFilter{Query: ptrString("häuser"), Language: ptrString("german")})
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnError(&pq.Error{Code: "42704", Message: `text search configuration "klingon" does not exist`})
+	_, _, err = d.ListNotes(context.Background(), models.ListNotesFilter{Query: ptrString("qapla"), Language: ptrString("klingon")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotes_RelevanceNeedsWords(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	_, _, err := d.ListNotes(context.Background(), models.ListNotesFilter{Query: ptrString("tag:eng -draft"), SortBy: "relevance"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
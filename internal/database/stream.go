@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamFetchSize is how many rows StreamNotes pulls from the cursor at once.
+const streamFetchSize = 500
+
+// StreamNotes calls fn for every note matching filter, in the ListNotes sort
+// order, without paging limits. Rows come from a server-side cursor in a
+// read-only snapshot, fetched one batch at a time, so a slow fn (a client
+// applying backpressure) holds at most one batch in memory. An error from fn
+// or a cancelled ctx stops the stream.
+func (d *Database) StreamNotes(ctx context.Context, filter models.ListNotesFilter, fn func(models.Note) error) error {
+	q, _, _, err := listNotesQuery(ctx, filter, false)
+	if err != nil {
+		return err
+	}
+	sqlStr, args, err := q.ToSql()
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to build query: %v", err)
+	}
+
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, "DECLARE notes_stream NO SCROLL CURSOR FOR "+sqlStr, args...); err != nil {
+		return fmt.Errorf("declaring cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM notes_stream", streamFetchSize)
+	for {
+		var rows []listNotesRow
+		if err := tx.SelectContext(ctx, &rows, fetch); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return status.FromContextError(ctxErr).Err()
+			}
+			return fmt.Errorf("fetching notes: %w", err)
+		}
+		for _, row := range rows {
+			if err := fn(row.toNote()); err != nil {
+				return err
+			}
+		}
+		if len(rows) < streamFetchSize {
+			break
+		}
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,65 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamNotes(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	cols := []string{"id", "project_id", "author_id", "title", "content", "is_pinned", "created_at", "updated_at", "author_display_name", "author_avatar_url"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DECLARE notes_stream NO SCROLL CURSOR FOR SELECT n.id")).
+		WithArgs("proj-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FETCH FORWARD 500 FROM notes_stream")).
+		WillReturnRows(sqlmock.NewRows(cols).
+			AddRow("note-1", "proj-1", "actor-1", "one", nil, false, now, now, "Alice", nil).
+			AddRow("note-2", "proj-1", "actor-1", "two", nil, false, now, now, "Alice", nil))
+	mock.ExpectCommit()
+
+	var got []models.Note
+	err := d.StreamNotes(context.Background(), models.ListNotesFilter{ProjectID: ptrString("proj-1"), PageSize: 10}, func(n models.Note) error {
+		got = append(got, n)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "Alice", *got[1].Author.DisplayName)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamNotes_StopsOnCallbackError(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	now := time.Now().UTC()
+	mock.ExpectBegin()
+	mock.ExpectExec("DECLARE notes_stream").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("FETCH FORWARD").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "title", "created_at", "updated_at"}).
+			AddRow("note-1", "actor-1", "one", now, now).
+			AddRow("note-2", "actor-1", "two", now, now))
+	mock.ExpectRollback()
+
+	sendErr := errors.New("client went away")
+	calls := 0
+	err := d.StreamNotes(context.Background(), models.ListNotesFilter{}, func(models.Note) error {
+		calls++
+		return sendErr
+	})
+	require.ErrorIs(t, err, sendErr)
+	require.Equal(t, 1, calls)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
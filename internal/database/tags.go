@@ -0,0 +1,215 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListTags returns the tags on live notes the caller can read, most used
+// first, optionally only those starting with filter.Prefix. With filter.Tree
+// it returns every tag path instead, parents included, ordered by path.
+func (d *Database) ListTags(ctx context.Context, filter models.ListTagsFilter) ([]models.TagCount, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	var q sq.SelectBuilder
+	col := "nt.tag"
+	if filter.Tree {
+		// Every tag counts toward itself and each of its ancestors.
+		col = "p.path"
+		q = psql.Select(
+			"p.path AS tag",
+			"COUNT(DISTINCT nt.note_id) FILTER (WHERE nt.tag = p.path) AS note_count",
+			"COUNT(DISTINCT nt.note_id) AS total_count",
+		).
+			From("note_tags nt").
+			Join("notes n ON n.id = nt.note_id").
+			JoinClause(`CROSS JOIN LATERAL (
+				SELECT array_to_string((string_to_array(nt.tag, '/'))[1:i], '/') AS path
+				FROM generate_series(1, cardinality(string_to_array(nt.tag, '/'))) i
+			) p`).
+			GroupBy("p.path").
+			OrderBy("p.path")
+	} else {
+		q = psql.Select("nt.tag", "COUNT(*) AS note_count").
+			From("note_tags nt").
+			Join("notes n ON n.id = nt.note_id").
+			GroupBy("nt.tag").
+			OrderBy("note_count DESC", "nt.tag").
+			Limit(uint64(clampPageSize(filter.PageSize)))
+	}
+	q = q.Where("n.deleted_at IS NULL")
+	if filter.ProjectID != nil {
+		q = q.Where(sq.Eq{"n.project_id": *filter.ProjectID})
+	}
+	if visible := visibleNotes(ctx); visible != nil {
+		q = q.Where(visible)
+	}
+	if prefix := utils.NormalizeTag(filter.Prefix); prefix != "" {
+		q = q.Where(col+` LIKE ? ESCAPE '\'`, escapeLike(prefix)+"%")
+	}
+
+	query, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	tags := []models.TagCount{}
+	if err := d.Db.SelectContext(ctx, &tags, query, args...); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// MergeTags replaces the source tags with the target on every note in scope,
+// trashed ones included, and reports how many notes changed. Nested tags keep
+// their place below the new name: merging "eng" into "dev" turns "eng/db"
+// into "dev/db". Renaming a tag is merging it alone into its new name.
+func (d *Database) MergeTags(ctx context.Context, in models.MergeTagsInput) (int, error) {
+	target := utils.NormalizeTag(in.Target)
+	var sources []string
+	for _, t := range utils.NormalizeTags(in.Sources) {
+		if t == target {
+			continue
+		}
+		if isTagUnder(target, t) {
+			return 0, status.Errorf(codes.InvalidArgument, "cannot merge %q into %q nested below it", t, target)
+		}
+		sources = append(sources, t)
+	}
+	return d.rewriteTags(ctx, in.ProjectID, sources, func(tag, source string) string {
+		return target + tag[len(source):]
+	})
+}
+
+// DeleteTag removes a tag and the tags nested below it from every note in
+// scope and reports how many notes had any of them.
+func (d *Database) DeleteTag(ctx context.Context, projectID *string, tag string) (int, error) {
+	return d.rewriteTags(ctx, projectID, []string{utils.NormalizeTag(tag)}, nil)
+}
+
+// rewriteTags removes tags, and the tags nested below them, from the notes in
+// scope. When replace is set each removed tag is put back as replace(tag,
+// source), source being the entry of tags it was removed for. Each changed
+// note gets an updated event.
+func (d *Database) rewriteTags(ctx context.Context, projectID *string, tags []string, replace func(tag, source string) string) (int, error) {
+	tags = outermostTags(tags)
+	if len(tags) == 0 {
+		return 0, nil
+	}
+
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	q := psql.Select("n.id").
+		From("notes n").
+		Where(sq.Expr("EXISTS (SELECT 1 FROM note_tags nt, UNNEST(?::text[]) f(tag) WHERE nt.note_id = n.id AND "+tagUnder("nt.tag", "f.tag")+")", pq.Array(tags))).
+		OrderBy("n.id").
+		Suffix("FOR UPDATE OF n")
+	if projectID != nil {
+		q = q.Where(sq.Eq{"n.project_id": *projectID})
+	}
+	if c, ok := auth.FromContext(ctx); ok {
+		q = q.Where(sq.Expr("? >= ?", noteRankExpr(c), roleRanks[models.RoleEditor]))
+	}
+	query, args, err := q.ToSql()
+	if err != nil {
+		return 0, err
+	}
+	var ids []string
+	if err := tx.SelectContext(ctx, &ids, query, args...); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	// Removing first means a replacement can never be removed again, even
+	// when it happens to match one of tags.
+	var removed []struct {
+		NoteID string `db:"note_id"`
+		Tag    string `db:"tag"`
+		Source string `db:"source"`
+	}
+	if err := tx.SelectContext(ctx, &removed, `DELETE FROM note_tags nt USING UNNEST($2::text[]) f(tag)
+WHERE nt.note_id = ANY($1) AND `+tagUnder("nt.tag", "f.tag")+`
+RETURNING nt.note_id, nt.tag, f.tag AS source`, pq.Array(ids), pq.Array(tags)); err != nil {
+		return 0, err
+	}
+	if replace != nil && len(removed) > 0 {
+		iq := psql.Insert("note_tags").Columns("note_id", "tag")
+		for _, r := range removed {
+			iq = iq.Values(r.NoteID, replace(r.Tag, r.Source))
+		}
+		query, args, err := iq.Suffix("ON CONFLICT DO NOTHING").ToSql()
+		if err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return 0, err
+		}
+	}
+	for _, id := range ids {
+		if err := recordNoteEvent(ctx, tx, models.NoteEventUpdated, id); err != nil {
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// tagUnder matches the tag column col against the filter tag f: the tag
+// itself or any tag nested below it.
+func tagUnder(col, f string) string {
+	return fmt.Sprintf("(%[1]s = %[2]s OR starts_with(%[1]s, %[2]s || '/'))", col, f)
+}
+
+// isTagUnder reports whether tag is parent or nested below it.
+func isTagUnder(tag, parent string) bool {
+	return tag == parent || strings.HasPrefix(tag, parent+"/")
+}
+
+// outermostTags drops empty tags and those nested below another one in tags,
+// which are covered by it already.
+func outermostTags(tags []string) []string {
+	var out []string
+	for _, t := range tags {
+		if t == "" {
+			continue
+		}
+		covered := false
+		for _, o := range tags {
+			if o != "" && o != t && isTagUnder(t, o) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
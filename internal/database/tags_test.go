@@ -0,0 +1,120 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMergeTags_RewritesNestedTags(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)^SELECT n\.id FROM notes n WHERE EXISTS \(.*UNNEST\(\$1::text\[\]\).*\) AND n\.project_id = \$2 ORDER BY n\.id FOR UPDATE OF n`).
+		WithArgs(`{"eng","ops"}`, "proj-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("note-1").AddRow("note-2"))
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM note_tags nt USING UNNEST($2::text[]) f(tag)")).
+		WithArgs(`{"note-1","note-2"}`, `{"eng","ops"}`).
+		WillReturnRows(sqlmock.NewRows([]string{"note_id", "tag", "source"}).
+			AddRow("note-1", "eng", "eng").
+			AddRow("note-1", "eng/db", "eng").
+			AddRow("note-2", "ops", "ops"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO note_tags (note_id,tag) VALUES ($1,$2),($3,$4),($5,$6) ON CONFLICT DO NOTHING")).
+		WithArgs("note-1", "dev", "note-1", "dev/db", "note-2", "dev").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	expectNoteEvent(mock, models.NoteEventUpdated, "note-1")
+	expectNoteEvent(mock, models.NoteEventUpdated, "note-2")
+	mock.ExpectCommit()
+
+	n, err := d.MergeTags(context.Background(), models.MergeTagsInput{
+		ProjectID: ptrString("proj-1"),
+		Sources:   []string{"Eng", " eng / db", "ops", "DEV"},
+		Target:    " Dev ",
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMergeTags_TargetBelowSource(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	_, err := d.MergeTags(context.Background(), models.MergeTagsInput{Sources: []string{"eng"}, Target: "eng/misc"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListTags_Tree(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)^SELECT p\.path AS tag, .* AS note_count, COUNT\(DISTINCT nt\.note_id\) AS total_count FROM note_tags nt .*CROSS JOIN LATERAL .* WHERE n\.deleted_at IS NULL AND p\.path LIKE \$1 .*GROUP BY p\.path ORDER BY p\.path$`).
+		WithArgs("eng%").
+		WillReturnRows(sqlmock.NewRows([]string{"tag", "note_count", "total_count"}).
+			AddRow("eng", 0, 3).
+			AddRow("eng/backend", 2, 3))
+
+	tags, err := d.ListTags(context.Background(), models.ListTagsFilter{Prefix: "Eng", Tree: true})
+	require.NoError(t, err)
+	require.Equal(t, []models.TagCount{{Tag: "eng", TotalCount: 3}, {Tag: "eng/backend", NoteCount: 2, TotalCount: 3}}, tags)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDeleteTag_OnlyEditableNotes(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`(?s)^SELECT n\.id FROM notes n WHERE EXISTS \(.*\) AND GREATEST\(.*\) >= \$\d+ ORDER BY n\.id FOR UPDATE OF n`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	n, err := d.DeleteTag(callerCtx("bob"), nil, "bug")
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListTags_PrefixIsEscaped(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT nt.tag, COUNT(*) AS note_count FROM note_tags nt JOIN notes n ON n.id = nt.note_id WHERE n.deleted_at IS NULL AND nt.tag LIKE $1 ESCAPE '\' GROUP BY nt.tag ORDER BY note_count DESC, nt.tag LIMIT 10`)).
+		WithArgs(`to\_do%`).
+		WillReturnRows(sqlmock.NewRows([]string{"tag", "note_count"}).AddRow("to_do", 4))
+
+	tags, err := d.ListTags(context.Background(), models.ListTagsFilter{Prefix: " To_Do"})
+	require.NoError(t, err)
+	require.Equal(t, []models.TagCount{{Tag: "to_do", NoteCount: 4}}, tags)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNote_NormalizesTags(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM note_tags WHERE note_id=$1")).
+		WithArgs("note-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO note_tags (note_id,tag) VALUES ($1,$2),($3,$4)")).
+		WithArgs("note-1", "bug", "note-1", "needs review").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expectNoteEvent(mock, models.NoteEventUpdated, "note-1")
+	mock.ExpectCommit()
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title"}).AddRow("note-1", "t"))
+
+	tags := []string{"Bug", "needs   Review", " bug", ""}
+	_, err := d.UpdateNote(context.Background(), models.UpdateNoteInput{NoteID: "note-1", Tags: &tags})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"strings"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	sq "github.com/Masterminds/squirrel"
+)
+
+// maxSuggestions caps the "did you mean" alternatives of SearchTitles.
+const maxSuggestions = 5
+
+// SearchTitles returns the live notes the caller can read whose title is
+// close to filter.Query, best first, scored by pg_trgm word similarity so
+// that a typo or a partial title still matches. The suggestions are existing
+// titles and tags resembling the whole query.
+func (d *Database) SearchTitles(ctx context.Context, filter models.SearchTitlesFilter) ([]models.TitleMatch, []models.Suggestion, error) {
+	d.Mu.RLock()
+	defer d.Mu.RUnlock()
+
+	text := strings.TrimSpace(filter.Query)
+	scope := func(q sq.SelectBuilder) sq.SelectBuilder {
+		q = q.Where("n.deleted_at IS NULL")
+		if filter.ProjectID != nil {
+			q = q.Where(sq.Eq{"n.project_id": *filter.ProjectID})
+		}
+		if visible := visibleNotes(ctx); visible != nil {
+			q = q.Where(visible)
+		}
+		return q
+	}
+
+	// <% and % are the operators idx_notes_title_trgm and
+	// idx_note_tags_tag_trgm can answer, each with its pg_trgm threshold.
+	query, args, err := scope(notesSelect().
+		Column(sq.Alias(sq.Expr("word_similarity(?, n.title)", text), "similarity")).
+		Where("? <% n.title", text)).
+		OrderBy("similarity DESC", "n.id").
+		Limit(uint64(clampPageSize(filter.PageSize))).
+		ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows []struct {
+		listNotesRow
+		Similarity float32 `db:"similarity"`
+	}
+	if err := d.Db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, err
+	}
+	matches := make([]models.TitleMatch, 0, len(rows))
+	for _, r := range rows {
+		matches = append(matches, models.TitleMatch{Note: r.toNote(), Similarity: r.Similarity})
+	}
+
+	// The inner selects keep "?" placeholders, psql renumbers them for the whole statement.
+	tags := scope(sq.Select("nt.tag AS text", "'"+models.SuggestionTag+"' AS kind").
+		Column(sq.Alias(sq.Expr("similarity(nt.tag, ?)", text), "similarity")).
+		From("note_tags nt").
+		Join("notes n ON n.id = nt.note_id").
+		Where("nt.tag % ?", text).
+		Where(sq.NotEq{"nt.tag": utils.NormalizeTag(text)})).
+		GroupBy("nt.tag")
+	titles := scope(sq.Select("n.title AS text", "'"+models.SuggestionTitle+"' AS kind").
+		Column(sq.Alias(sq.Expr("similarity(n.title, ?)", text), "similarity")).
+		From("notes n").
+		Where("n.title % ?", text).
+		Where("lower(n.title) <> lower(?)", text)).
+		GroupBy("n.title").
+		Suffix("UNION ALL ?", tags)
+	query, args, err = psql.Select("s.text", "s.kind", "s.similarity").
+		FromSelect(titles, "s").
+		OrderBy("s.similarity DESC", "s.text").
+		Limit(maxSuggestions).
+		ToSql()
+	if err != nil {
+		return nil, nil, err
+	}
+	suggestions := []models.Suggestion{}
+	if err := d.Db.SelectContext(ctx, &suggestions, query, args...); err != nil {
+		return nil, nil, err
+	}
+	return matches, suggestions, nil
+}
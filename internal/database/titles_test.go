@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchTitles(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)word_similarity\(\$1, n\.title\)\) AS similarity FROM notes n .*WHERE \$2 <% n\.title AND n\.deleted_at IS NULL AND n\.project_id = \$3 ORDER BY similarity DESC, n\.id LIMIT 10$`).
+		WithArgs("deplyment gude", "deplyment gude", "proj-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "similarity"}).AddRow("n1", "Deployment guide", float32(0.7)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT s.text, s.kind, s.similarity FROM (SELECT n.title AS text, 'title' AS kind, (similarity(n.title, $1)) AS similarity FROM notes n "+
+		"WHERE n.title % $2 AND lower(n.title) <> lower($3) AND n.deleted_at IS NULL AND n.project_id = $4 GROUP BY n.title "+
+		"UNION ALL SELECT nt.tag AS text, 'tag' AS kind, (similarity(nt.tag, $5)) AS similarity FROM note_tags nt JOIN notes n ON n.id = nt.note_id "+
+		"WHERE nt.tag % $6 AND nt.tag <> $7 AND n.deleted_at IS NULL AND n.project_id = $8 GROUP BY nt.tag) AS s "+
+		"ORDER BY s.similarity DESC, s.text LIMIT 5")).
+		WithArgs("deplyment gude", "deplyment gude", "deplyment gude", "proj-1",
+			"deplyment gude", "deplyment gude", "deplyment gude", "proj-1").
+		WillReturnRows(sqlmock.NewRows([]string{"text", "kind", "similarity"}).
+			AddRow("Deployment guide", "title", float32(0.45)).
+			AddRow("deployment", "tag", float32(0.4)))
+
+	matches, suggestions, err := d.SearchTitles(context.Background(), models.SearchTitlesFilter{
+		Query:     " deplyment gude ",
+		ProjectID: ptrString("proj-1"),
+	})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "Deployment guide", matches[0].Note.Title)
+	require.Equal(t, float32(0.7), matches[0].Similarity)
+	require.Equal(t, []models.Suggestion{
+		{Text: "Deployment guide", Kind: models.SuggestionTitle, Similarity: 0.45},
+		{Text: "deployment", Kind: models.SuggestionTag, Similarity: 0.4},
+	}, suggestions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
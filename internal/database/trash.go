@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// trashNote moves a note to the trash. Notes that are already trashed are
+// left untouched and reported as not deleted.
+func trashNote(ctx context.Context, tx *sqlx.Tx, id string, by *models.Actor) (bool, error) {
+	var deletedBy *string
+	if by != nil && by.ID != "" {
+		aq := psql.Insert("actors").
+			Columns("id", "display_name", "avatar_url").
+			Values(by.ID, by.DisplayName, by.AvatarURL).
+			Suffix(upsertActorSuffix)
+		query, args, err := aq.ToSql()
+		if err != nil {
+			return false, err
+		}
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return false, err
+		}
+		deletedBy = &by.ID
+	}
+
+	query, args, err := psql.Update("notes").
+		Set("deleted_at", sq.Expr("NOW()")).
+		Set("deleted_by", deletedBy).
+		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NULL").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("building trash update: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("trashing note: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected (notes trash): %w", err)
+	}
+	return ra > 0, nil
+}
+
+// RestoreNote takes a note out of the trash.
+func (d *Database) RestoreNote(ctx context.Context, id string) (*models.Note, error) {
+	d.Mu.Lock()
+	err := d.restoreNote(ctx, id)
+	d.Mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return d.ViewNote(ctx, id, models.GetNoteOptions{IncludeAttachments: true})
+}
+
+func (d *Database) restoreNote(ctx context.Context, id string) error {
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := requireNoteRole(ctx, tx, id, models.RoleEditor); err != nil {
+		return err
+	}
+
+	query, args, err := psql.Update("notes").
+		Set("deleted_at", nil).
+		Set("deleted_by", nil).
+		Where(sq.Eq{"id": id}).
+		Where("deleted_at IS NOT NULL").
+		ToSql()
+	if err != nil {
+		return err
+	}
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if ra, err := res.RowsAffected(); err != nil {
+		return err
+	} else if ra == 0 {
+		return status.Error(codes.NotFound, "note not found in trash")
+	}
+	if err := recordNoteEvent(ctx, tx, models.NoteEventUpdated, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// PurgeNote permanently deletes a note that is already in the trash.
+func (d *Database) PurgeNote(ctx context.Context, id string) (bool, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	tx, err := d.Db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return false, status.Errorf(codes.Internal, "unable to start a transaction: %v", err)
+	}
+	defer func() {
+		tx.Rollback()
+	}()
+
+	if err := requireNoteRole(ctx, tx, id, models.RoleOwner); err != nil {
+		return false, err
+	}
+
+	var trashed bool
+	if err := tx.GetContext(ctx, &trashed, `SELECT EXISTS(SELECT 1 FROM notes WHERE id=$1 AND deleted_at IS NOT NULL)`, id); err != nil {
+		return false, err
+	}
+	if !trashed {
+		return false, status.Error(codes.NotFound, "note not found in trash")
+	}
+	deleted, err := hardDeleteNote(ctx, tx, id)
+	if err != nil {
+		return false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit failed after purge: %w", err)
+	}
+	return deleted, nil
+}
+
+// PurgeTrash hard-deletes every note trashed before cutoff. Child rows go with
+// the ON DELETE CASCADE foreign keys.
+func (d *Database) PurgeTrash(ctx context.Context, cutoff time.Time) (int64, error) {
+	d.Mu.Lock()
+	defer d.Mu.Unlock()
+	query, args, err := psql.Delete("notes").
+		Where("deleted_at IS NOT NULL").
+		Where(sq.Lt{"deleted_at": cutoff}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+	res, err := d.Db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// RunTrashPurger calls PurgeTrash every interval with notes older than
+// retention until ctx is done.
+func (d *Database) RunTrashPurger(ctx context.Context, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := d.PurgeTrash(ctx, time.Now().Add(-retention))
+			if err != nil {
+				log.Printf("trash purge failed: %v", err)
+			} else if n > 0 {
+				log.Printf("purged %d notes from the trash", n)
+			}
+		}
+	}
+}
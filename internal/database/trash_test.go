@@ -0,0 +1,103 @@
+package database_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeleteNote_SoftDeleteByDefault(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	by := models.Actor{ID: "actor-2", DisplayName: ptrString("Bob"), AvatarURL: ptrString("NA")}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO actors")).
+		WithArgs(by.ID, by.DisplayName, by.AvatarURL).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at = NOW(), deleted_by = $1 WHERE id = $2 AND deleted_at IS NULL")).
+		WithArgs(by.ID, "note-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectNoteEvent(mock, models.NoteEventDeleted, "note-1")
+	mock.ExpectCommit()
+
+	ok, err := d.DeleteNote(context.Background(), models.DeleteNoteInput{NoteID: "note-1", DeletedBy: &by})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotes_HidesTrash(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .*WHERE n\.deleted_at IS NULL`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, _, err := d.ListNotes(context.Background(), models.ListNotesFilter{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`(?s)^SELECT .* FROM notes n .*WHERE n\.deleted_at IS NOT NULL .*ORDER BY n\.deleted_at DESC, n\.id DESC`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, _, err = d.ListNotes(context.Background(), models.ListNotesFilter{OnlyDeleted: true, SortBy: "deleted_at", SortDesc: true})
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRestoreNote_NotInTrash(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notes SET deleted_at = $1, deleted_by = $2 WHERE id = $3 AND deleted_at IS NOT NULL")).
+		WithArgs(nil, nil, "note-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err := d.RestoreNote(context.Background(), "note-1")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeNote(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS(SELECT 1 FROM notes WHERE id=$1 AND deleted_at IS NOT NULL)")).
+		WithArgs("note-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	for _, table := range []string{"note_tags", "attachments", "note_revisions"} {
+		mock.ExpectExec("DELETE FROM " + table).WithArgs("note-1").WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(`DELETE FROM notes WHERE id = \$1`).WithArgs("note-1").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	ok, err := d.PurgeNote(context.Background(), "note-1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeTrash(t *testing.T) {
+	d, mock, cleanup := newMockDatabase(t)
+	defer cleanup()
+
+	cutoff := time.Now().Add(-time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM notes WHERE deleted_at IS NOT NULL AND deleted_at < $1")).
+		WithArgs(cutoff).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	n, err := d.PurgeTrash(context.Background(), cutoff)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
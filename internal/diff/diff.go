@@ -0,0 +1,297 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type Op int
+
+const (
+	Equal Op = iota
+	Insert
+	Delete
+)
+
+type Granularity int
+
+const (
+	Line Granularity = iota
+	Word
+)
+
+// maxEditDistance bounds the work done by the Myers search. Past it the
+// differing middle section is reported as a single delete + insert.
+const maxEditDistance = 2000
+
+type Span struct {
+	Op   Op
+	Text string
+}
+
+// Hunk is a group of changes with surrounding context. Starts are 1-based and
+// counts are in tokens (lines or words, depending on the granularity).
+type Hunk struct {
+	FromStart int
+	FromCount int
+	ToStart   int
+	ToCount   int
+	Spans     []Span
+}
+
+type edit struct {
+	op   Op
+	text string
+}
+
+// Compute returns the hunks that turn a into b. context is the number of
+// unchanged tokens kept around each change.
+func Compute(a, b string, g Granularity, context int) []Hunk {
+	edits := diffTokens(Split(a, g), Split(b, g))
+	var hunks []Hunk
+	for _, r := range group(edits, context) {
+		hunks = append(hunks, toHunk(edits, r))
+	}
+	return hunks
+}
+
+// Unified renders a line based unified diff of a and b.
+func Unified(fromName, toName, a, b string, context int) string {
+	edits := diffTokens(Split(a, Line), Split(b, Line))
+	ranges := group(edits, context)
+	if len(ranges) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for _, r := range ranges {
+		h := toHunk(edits, r)
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", unifiedRange(h.FromStart, h.FromCount), unifiedRange(h.ToStart, h.ToCount))
+		for _, e := range edits[r[0]:r[1]] {
+			prefix := " "
+			switch e.op {
+			case Insert:
+				prefix = "+"
+			case Delete:
+				prefix = "-"
+			}
+			sb.WriteString(prefix)
+			sb.WriteString(e.text)
+			if !strings.HasSuffix(e.text, "\n") {
+				sb.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+func unifiedRange(start, count int) string {
+	if count == 0 {
+		start--
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// Split breaks s into tokens. Line tokens keep their trailing newline; word
+// tokens alternate between runs of whitespace and runs of everything else so
+// joining the tokens gives back s.
+func Split(s string, g Granularity) []string {
+	if s == "" {
+		return nil
+	}
+	var tokens []string
+	switch g {
+	case Word:
+		start := 0
+		prevSpace := false
+		for i, r := range s {
+			space := unicode.IsSpace(r)
+			if i > start && space != prevSpace {
+				tokens = append(tokens, s[start:i])
+				start = i
+			}
+			prevSpace = space
+		}
+		tokens = append(tokens, s[start:])
+	default:
+		for len(s) > 0 {
+			i := strings.IndexByte(s, '\n')
+			if i < 0 {
+				tokens = append(tokens, s)
+				break
+			}
+			tokens = append(tokens, s[:i+1])
+			s = s[i+1:]
+		}
+	}
+	return tokens
+}
+
+// diffTokens returns the edit script turning a into b, one edit per token.
+func diffTokens(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for _, t := range a[:prefix] {
+		edits = append(edits, edit{Equal, t})
+	}
+	edits = append(edits, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, t := range a[len(a)-suffix:] {
+		edits = append(edits, edit{Equal, t})
+	}
+	return edits
+}
+
+// myers is the O(ND) algorithm from "An O(ND) Difference Algorithm and Its
+// Variations" (Myers, 1986), keeping one frontier per round for backtracking.
+func myers(a, b []string) []edit {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= max && d <= maxEditDistance; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+
+	if !found {
+		edits := make([]edit, 0, n+m)
+		for _, t := range a {
+			edits = append(edits, edit{Delete, t})
+		}
+		for _, t := range b {
+			edits = append(edits, edit{Insert, t})
+		}
+		return edits
+	}
+
+	var rev []edit
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		frontier := trace[d]
+		at := func(k int) int { return frontier[k+d] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			rev = append(rev, edit{Equal, a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			rev = append(rev, edit{Insert, b[y-1]})
+		} else {
+			rev = append(rev, edit{Delete, a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		rev = append(rev, edit{Equal, a[x-1]})
+		x--
+		y--
+	}
+
+	edits := make([]edit, len(rev))
+	for i, e := range rev {
+		edits[len(rev)-1-i] = e
+	}
+	return edits
+}
+
+// group returns [start, end) ranges of edits covering each hunk, merging
+// changes whose context would overlap.
+func group(edits []edit, context int) [][2]int {
+	if context < 0 {
+		context = 0
+	}
+	var ranges [][2]int
+	lastChange := -1
+	for i, e := range edits {
+		if e.op == Equal {
+			continue
+		}
+		if len(ranges) > 0 && i-lastChange-1 <= 2*context {
+			ranges[len(ranges)-1][1] = i + 1
+		} else {
+			if len(ranges) > 0 {
+				ranges[len(ranges)-1][1] = min(lastChange+1+context, len(edits))
+			}
+			ranges = append(ranges, [2]int{max(i-context, 0), i + 1})
+		}
+		lastChange = i
+	}
+	if len(ranges) > 0 {
+		ranges[len(ranges)-1][1] = min(lastChange+1+context, len(edits))
+	}
+	return ranges
+}
+
+func toHunk(edits []edit, r [2]int) Hunk {
+	h := Hunk{FromStart: 1, ToStart: 1}
+	for _, e := range edits[:r[0]] {
+		if e.op != Insert {
+			h.FromStart++
+		}
+		if e.op != Delete {
+			h.ToStart++
+		}
+	}
+	for _, e := range edits[r[0]:r[1]] {
+		if e.op != Insert {
+			h.FromCount++
+		}
+		if e.op != Delete {
+			h.ToCount++
+		}
+		if n := len(h.Spans); n > 0 && h.Spans[n-1].Op == e.op {
+			h.Spans[n-1].Text += e.text
+		} else {
+			h.Spans = append(h.Spans, Span{Op: e.op, Text: e.text})
+		}
+	}
+	return h
+}
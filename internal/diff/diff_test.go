@@ -0,0 +1,76 @@
+package diff_test
+
+import (
+	"strings"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/diff"
+	"github.com/stretchr/testify/require"
+)
+
+// apply rebuilds both sides from the hunks of a full-context diff.
+func apply(hunks []diff.Hunk) (string, string) {
+	var from, to strings.Builder
+	for _, h := range hunks {
+		for _, s := range h.Spans {
+			if s.Op != diff.Insert {
+				from.WriteString(s.Text)
+			}
+			if s.Op != diff.Delete {
+				to.WriteString(s.Text)
+			}
+		}
+	}
+	return from.String(), to.String()
+}
+
+func TestCompute_Line(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\n"
+	b := "one\n2\nthree\nfour\nfive\n"
+
+	hunks := diff.Compute(a, b, diff.Line, 1)
+	require.Len(t, hunks, 1)
+	h := hunks[0]
+	require.Equal(t, 1, h.FromStart)
+	require.Equal(t, 4, h.FromCount)
+	require.Equal(t, 1, h.ToStart)
+	require.Equal(t, 5, h.ToCount)
+	require.Equal(t, []diff.Span{
+		{Op: diff.Equal, Text: "one\n"},
+		{Op: diff.Delete, Text: "two\n"},
+		{Op: diff.Insert, Text: "2\n"},
+		{Op: diff.Equal, Text: "three\nfour\n"},
+		{Op: diff.Insert, Text: "five\n"},
+	}, h.Spans)
+
+	from, to := apply(diff.Compute(a, b, diff.Line, 100))
+	require.Equal(t, a, from)
+	require.Equal(t, b, to)
+}
+
+func TestCompute_SplitsDistantChanges(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\n"
+	b := "A\nb\nc\nd\ne\nf\ng\nH\n"
+
+	hunks := diff.Compute(a, b, diff.Line, 1)
+	require.Len(t, hunks, 2)
+	require.Equal(t, 1, hunks[0].FromStart)
+	require.Equal(t, 7, hunks[1].FromStart)
+	require.Equal(t, 2, hunks[1].FromCount)
+}
+
+func TestCompute_Word(t *testing.T) {
+	hunks := diff.Compute("the quick brown fox", "the slow brown fox jumps", diff.Word, 0)
+	require.Len(t, hunks, 2)
+	require.Equal(t, []diff.Span{{Op: diff.Delete, Text: "quick"}, {Op: diff.Insert, Text: "slow"}}, hunks[0].Spans)
+	require.Equal(t, []diff.Span{{Op: diff.Insert, Text: " jumps"}}, hunks[1].Spans)
+
+	require.Empty(t, diff.Compute("same text", "same text", diff.Word, 3))
+}
+
+func TestUnified(t *testing.T) {
+	out := diff.Unified("a/rev-1", "b/current", "one\ntwo\n", "one\nthree", 3)
+	require.Equal(t, "--- a/rev-1\n+++ b/current\n@@ -1,2 +1,2 @@\n one\n-two\n+three\n\\ No newline at end of file\n", out)
+
+	require.Empty(t, diff.Unified("a", "b", "x\n", "x\n", 3))
+}
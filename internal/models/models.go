@@ -9,39 +9,69 @@ type Note struct {
 	Title       string         `db:"title"`
 	Content     *string        `db:"content"`
 	IsPinned    bool           `db:"is_pinned"`
+	Language    string         `db:"language"` // text search configuration
 	Tags        []string       `db:"-"`
 	CreatedAt   time.Time      `db:"created_at"`
 	UpdatedAt   time.Time      `db:"updated_at"`
+	DeletedAt   *time.Time     `db:"deleted_at"`
+	DeletedByID *string        `db:"deleted_by"`
 	Author      *Actor         `db:"-"`
 	Revisions   []NoteRevision `db:"-"`
 	Attachments []Attachment   `db:"-"`
+
+	HasMoreRevisions bool `db:"-"` // Revisions was cut at GetNoteOptions.RevisionsLimit
+
+	Match *NoteMatch `db:"-"` // set by ListNotes when the query has words to search for
+
+	Duplicates []DuplicateNote `db:"-"` // set by CreateNote, see CreateNoteInput.RejectDuplicates
+}
+
+// DuplicateNote is an existing note that a new one nearly repeats.
+type DuplicateNote struct {
+	Note       Note
+	Similarity float32
 }
 
+// NoteMatch says how well a note matched a search and where.
+type NoteMatch struct {
+	Rank    float32
+	Snippet string
+	// Highlights are [Start, End) character offsets into Snippet.
+	Highlights []TextRange
+}
+
+type TextRange struct {
+	Start, End int
+}
+
+// Actor, Attachment and NoteRevision also carry json tags because ViewNote
+// loads them as json_agg arrays.
 type Actor struct {
-	ID          string  `db:"id"`
-	DisplayName *string `db:"display_name"`
-	AvatarURL   *string `db:"avatar_url"`
+	ID          string  `db:"id" json:"id"`
+	DisplayName *string `db:"display_name" json:"display_name"`
+	AvatarURL   *string `db:"avatar_url" json:"avatar_url"`
 }
 
 type Attachment struct {
-	ID         string    `db:"id"`
-	URL        string    `db:"url"`
-	FileName   string    `db:"file_name"`
-	FileType   string    `db:"file_type"`
-	UploadedAt time.Time `db:"uploaded_at"`
-	SHA256     *string   `db:"sha256"`
-	SizeBytes  *int64    `db:"size_bytes"`
-	NoteID     string    `db:"note_id"`
+	ID         string    `db:"id" json:"id"`
+	URL        string    `db:"url" json:"url"`
+	FileName   string    `db:"file_name" json:"file_name"`
+	FileType   string    `db:"file_type" json:"file_type"`
+	UploadedAt time.Time `db:"uploaded_at" json:"uploaded_at"`
+	SHA256     *string   `db:"sha256" json:"sha256"`
+	SizeBytes  *int64    `db:"size_bytes" json:"size_bytes"`
+	NoteID     string    `db:"note_id" json:"note_id"`
+	BlobKey    *string   `db:"blob_key" json:"blob_key"` // set when the bytes live in the server's blob store
 }
 
 type NoteRevision struct {
-	ID       string    `db:"id"`
-	NoteID   string    `db:"note_id"`
-	Title    string    `db:"title"`
-	Content  string    `db:"content"`
-	EditorID string    `db:"editor_id"`
-	EditedAt time.Time `db:"edited_at"`
-	Editor   *Actor    `db:"-"` // nested struct, filled separately from join
+	ID       string    `db:"id" json:"id"`
+	NoteID   string    `db:"note_id" json:"note_id"`
+	Title    string    `db:"title" json:"title"`
+	Content  string    `db:"content" json:"content"`
+	EditorID string    `db:"editor_id" json:"editor_id"`
+	EditedAt time.Time `db:"edited_at" json:"edited_at"`
+	Editor   *Actor    `db:"-" json:"editor"` // nested struct, filled separately from join
 }
 
 type CreateNoteInput struct {
@@ -53,6 +83,10 @@ type CreateNoteInput struct {
 	Author         Actor
 	Attachment     []Attachment
 	IdempotencyKey *string
+	Language       *string // nil takes the project's language
+	// RejectDuplicates fails the create when it would duplicate a note
+	// instead of returning the duplicates with the new note.
+	RejectDuplicates bool
 }
 
 type UpdateNoteInput struct {
@@ -65,19 +99,231 @@ type UpdateNoteInput struct {
 	Editor           *Actor
 	CreateRevision   bool
 	Attachments      []Attachment
+	MoveToProject    *string // "" takes the note out of its project
+	Language         *string // "" goes back to the project's language
+}
+
+type RestoreNoteRevisionInput struct {
+	NoteID           string
+	RevisionID       string
+	Editor           *Actor
+	IfMatchUpdatedAt *time.Time
+}
+
+// MoveNotesInput moves notes into ProjectID, or out of any project when it
+// is nil.
+type MoveNotesInput struct {
+	NoteIDs   []string
+	ProjectID *string
+	MovedBy   *Actor
+}
+
+type DeleteNoteInput struct {
+	NoteID    string
+	Hard      bool
+	DeletedBy *Actor
 }
 
 type GetNoteOptions struct {
 	IncludeRevisions   bool
 	IncludeAttachments bool
+	IncludeDeleted     bool
+	RevisionsLimit     int // newest revisions to return, 0 means the default
 }
 
 type ListNotesFilter struct {
 	ProjectID *string
-	UserID    *string // author_id
-	Query     *string // full-text search across title+content
-	SortBy    string  // "updated_at", "created_at", "title", "is_pinned"
+	UserID    *string  // author_id
+	Query     *string  // search query, see package search
+	TagsAny   []string // at least one of these tags
+	TagsAll   []string // every one of these tags
+	TagsNone  []string // none of these tags
+	Language  *string  // configuration Query is read in, each note's own when nil
+	SortBy    string   // "updated_at", "created_at", "title", "is_pinned", "relevance"
 	SortDesc  bool
 	PageSize  int
 	PageToken string
+
+	IncludeDeleted bool
+	OnlyDeleted    bool // trash listing, allows SortBy "deleted_at"
+}
+
+type SearchTitlesFilter struct {
+	Query     string
+	ProjectID *string
+	PageSize  int
+}
+
+// TitleMatch is a note whose title is close to a SearchTitles query.
+type TitleMatch struct {
+	Note       Note
+	Similarity float32
+}
+
+// RelatedNote is a note sharing distinctive terms with another one. Score is
+// only comparable within one GetRelatedNotes call.
+type RelatedNote struct {
+	Note  Note
+	Score float32
+}
+
+const (
+	SuggestionTitle = "title"
+	SuggestionTag   = "tag"
+)
+
+// Suggestion is an existing title or tag that resembles a search query.
+type Suggestion struct {
+	Text       string  `db:"text"`
+	Kind       string  `db:"kind"`
+	Similarity float32 `db:"similarity"`
+}
+
+type ListNoteRevisionsFilter struct {
+	NoteID    string
+	PageSize  int
+	PageToken string
+}
+
+const (
+	NoteEventCreated = "created"
+	NoteEventUpdated = "updated"
+	NoteEventDeleted = "deleted"
+)
+
+// NoteEvent is a row of the note_events outbox written by every note mutation.
+type NoteEvent struct {
+	ID         int64     `db:"id"`
+	Type       string    `db:"type"`
+	NoteID     string    `db:"note_id"`
+	ProjectID  *string   `db:"project_id"`
+	AuthorID   string    `db:"author_id"`
+	Tags       []string  `db:"-"`
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+type NoteEventFilter struct {
+	AfterID   int64
+	ProjectID *string
+	AuthorID  *string
+	Tag       *string
+	Limit     int
+}
+
+// Role is an access level on a note or project. Each role includes the ones
+// below it: owners can share, editors can change content, viewers can read.
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleOwner  Role = "owner"
+)
+
+const (
+	PrincipalUser  = "user"
+	PrincipalGroup = "group"
+)
+
+// Principal is who a grant is for: an actor id or a group name from the
+// caller's token.
+type Principal struct {
+	Type string `db:"principal_type"`
+	ID   string `db:"principal_id"`
+}
+
+type Grant struct {
+	Principal
+	Role      Role      `db:"role"`
+	GrantedBy *string   `db:"granted_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type Project struct {
+	ID          string     `db:"id"`
+	Name        string     `db:"name"`
+	Description *string    `db:"description"`
+	OwnerID     string     `db:"owner_id"`
+	CreatedAt   time.Time  `db:"created_at"`
+	UpdatedAt   time.Time  `db:"updated_at"`
+	ArchivedAt  *time.Time `db:"archived_at"`
+	Language    *string    `db:"language"` // default for its notes, nil means english
+	Owner       *Actor     `db:"-"`
+
+	NoteCount int64 `db:"note_count"` // live notes the caller can see
+}
+
+type CreateProjectInput struct {
+	ID          string
+	Name        string
+	Description *string
+	Owner       Actor
+	Language    *string
+}
+
+type UpdateProjectInput struct {
+	ProjectID   string
+	Name        *string
+	Description *string
+	Language    *string // "" clears it
+}
+
+type ListProjectsFilter struct {
+	IncludeArchived bool
+	PageSize        int
+	PageToken       string
+}
+
+// TagCount is a tag and how many live notes carry it. TotalCount, which also
+// counts notes with tags nested below it, is only set for tree listings.
+type TagCount struct {
+	Tag        string `db:"tag"`
+	NoteCount  int    `db:"note_count"`
+	TotalCount int    `db:"total_count"`
+}
+
+type ListTagsFilter struct {
+	ProjectID *string
+	Prefix    string
+	PageSize  int
+	Tree      bool // every tag path with rolled-up counts, ignores PageSize
+}
+
+// MergeTagsInput replaces every source tag with Target on the notes in
+// ProjectID, or on all notes when it is nil.
+type MergeTagsInput struct {
+	ProjectID *string
+	Sources   []string
+	Target    string
+}
+
+// SavedSearch is a named ListNotes request. Request holds the request as
+// protobuf JSON; this package leaves it to the server to interpret.
+type SavedSearch struct {
+	ID        string    `db:"id"`
+	Name      string    `db:"name"`
+	OwnerID   string    `db:"owner_id"`
+	Request   []byte    `db:"request"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+	Owner     *Actor    `db:"-"`
+}
+
+type CreateSavedSearchInput struct {
+	ID      string
+	Name    string
+	Request []byte
+	Owner   Actor
+}
+
+type ListSavedSearchesFilter struct {
+	PageSize  int
+	PageToken string
+}
+
+// BatchResult is one item of a batch call: the note it produced, or why the
+// item failed when the batch allows partial failure.
+type BatchResult struct {
+	Note *Note
+	Err  error
 }
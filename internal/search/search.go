@@ -0,0 +1,521 @@
+// Package search parses the ListNotes query language:
+//
+//	deploy "release notes"       both terms, anywhere in title or content
+//	deploy OR release            either term
+//	-draft                       notes without the term
+//	(a OR b) -c                  grouping
+//	title:deploy title:"a b"     in the title only
+//	tag:eng/backend              tagged eng/backend or a tag nested below it
+//	author:alice project:infra   by author and project id or name
+//	pinned:true                  pinned notes
+//	updated:>2026-01-01          dates compare with >, >=, <, <= or a day,
+//	created:2026-01-01..2026-01-31  or an inclusive range of days
+//	updated:>=today-7d           days can be relative: today, today-Nd, today-Nw
+//
+// Terms next to each other must all match; AND may be written out. OR and AND
+// are only operators in upper case. Queries are limited to 4096 characters,
+// 64 terms and 32 levels of nested groups and exclusions.
+package search
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxTerms bounds the size of the SQL a single query compiles to.
+const maxTerms = 64
+
+// maxDepth bounds how deeply groups and exclusions nest, and with it the
+// parser's recursion; maxLength bounds the query, in characters.
+const (
+	maxDepth  = 32
+	maxLength = 4096
+)
+
+const dateLayout = "2006-01-02"
+
+// Node is one of And, Or, Not, Text, Field, Pinned and DateRange.
+type Node interface {
+	node()
+}
+
+type And struct{ Nodes []Node }
+
+type Or struct{ Nodes []Node }
+
+type Not struct{ Node Node }
+
+// Text matches a word or phrase against title and content, or the title
+// alone when InTitle is set.
+type Text struct {
+	Value   string
+	Phrase  bool
+	InTitle bool
+}
+
+// Field matches notes whose tag, author or project is Value. Name is "tag",
+// "author" or "project".
+type Field struct {
+	Name  string
+	Value string
+}
+
+type Pinned struct{ Value bool }
+
+// DateRange matches notes whose Name ("updated" or "created") timestamp is in
+// [From, To). A zero bound is open.
+type DateRange struct {
+	Name     string
+	From, To time.Time
+}
+
+func (And) node()       {}
+func (Or) node()        {}
+func (Not) node()       {}
+func (Text) node()      {}
+func (Field) node()     {}
+func (Pinned) node()    {}
+func (DateRange) node() {}
+
+// Error is a malformed query. Pos is the 1-based position, in characters, of
+// the offending part of the query.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// Parse parses query. An empty query parses to a nil Node. Errors are always
+// *Error.
+func Parse(query string) (Node, error) {
+	return ParseAt(query, time.Now())
+}
+
+// ParseAt is Parse with relative days counted from the UTC day of now.
+func ParseAt(query string, now time.Time) (Node, error) {
+	if utf8.RuneCountInString(query) > maxLength {
+		return nil, &Error{Pos: maxLength + 1, Msg: fmt.Sprintf("query too long, at most %d characters are allowed", maxLength)}
+	}
+	toks, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	p := &parser{toks: toks, end: len([]rune(query)) + 1, today: today}
+	if len(toks) == 0 {
+		return nil, nil
+	}
+	n, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t != nil {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+	}
+	return n, nil
+}
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokPhrase
+	tokField
+	tokOr
+	tokAnd
+	tokNot
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	pos  int
+	// text is the word or phrase; for tokField the field name.
+	text string
+	// For tokField: the value, whether it was quoted, and where it starts.
+	value    string
+	phrase   bool
+	valuePos int
+}
+
+func (t *token) String() string {
+	switch t.kind {
+	case tokOr:
+		return "OR"
+	case tokAnd:
+		return "AND"
+	case tokNot:
+		return `"-"`
+	case tokLParen:
+		return `"("`
+	case tokRParen:
+		return `")"`
+	case tokField:
+		return fmt.Sprintf("%q", t.text+":")
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+var fields = map[string]bool{
+	"title": true, "tag": true, "author": true, "project": true,
+	"pinned": true, "updated": true, "created": true,
+}
+
+func lex(query string) ([]token, error) {
+	rs := []rune(query)
+	var toks []token
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		pos := i + 1
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen, pos: pos})
+			i++
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen, pos: pos})
+			i++
+		case r == '"':
+			s, next, err := quoted(rs, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, token{kind: tokPhrase, pos: pos, text: s})
+			i = next
+		case r == '-' && (i == 0 || !isWordRune(rs[i-1])):
+			if i+1 == len(rs) || unicode.IsSpace(rs[i+1]) || rs[i+1] == ')' {
+				return nil, &Error{Pos: pos, Msg: `nothing to exclude after "-"`}
+			}
+			toks = append(toks, token{kind: tokNot, pos: pos})
+			i++
+		default:
+			start := i
+			for i < len(rs) && isWordRune(rs[i]) && rs[i] != ':' {
+				i++
+			}
+			word := string(rs[start:i])
+			if i < len(rs) && rs[i] == ':' && fields[strings.ToLower(word)] {
+				t := token{kind: tokField, pos: pos, text: strings.ToLower(word), valuePos: i + 2}
+				i++
+				if i < len(rs) && rs[i] == '"' {
+					s, next, err := quoted(rs, i)
+					if err != nil {
+						return nil, err
+					}
+					t.value, t.phrase, i = s, true, next
+				} else {
+					vs := i
+					for i < len(rs) && isWordRune(rs[i]) {
+						i++
+					}
+					t.value = string(rs[vs:i])
+				}
+				if t.value == "" {
+					return nil, &Error{Pos: t.valuePos, Msg: fmt.Sprintf("missing value for %s:", t.text)}
+				}
+				toks = append(toks, t)
+				continue
+			}
+			// Not a field: the colon and whatever follows are part of the word.
+			for i < len(rs) && isWordRune(rs[i]) {
+				i++
+			}
+			word = string(rs[start:i])
+			switch word {
+			case "OR":
+				toks = append(toks, token{kind: tokOr, pos: pos})
+			case "AND":
+				toks = append(toks, token{kind: tokAnd, pos: pos})
+			default:
+				toks = append(toks, token{kind: tokWord, pos: pos, text: word})
+			}
+		}
+	}
+	return toks, nil
+}
+
+// quoted reads the phrase whose opening quote is at rs[i], returning it and
+// the index after the closing quote.
+func quoted(rs []rune, i int) (string, int, error) {
+	for j := i + 1; j < len(rs); j++ {
+		if rs[j] == '"' {
+			s := strings.Join(strings.Fields(string(rs[i+1:j])), " ")
+			if s == "" {
+				return "", 0, &Error{Pos: i + 1, Msg: "empty phrase"}
+			}
+			return s, j + 1, nil
+		}
+	}
+	return "", 0, &Error{Pos: i + 1, Msg: "unterminated phrase"}
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.IsSpace(r) && r != '(' && r != ')' && r != '"'
+}
+
+type parser struct {
+	toks  []token
+	i     int
+	end   int // position reported for errors at the end of the query
+	terms int
+	depth int // groups and exclusions unary is nested in
+	today time.Time
+}
+
+func (p *parser) peek() *token {
+	if p.i < len(p.toks) {
+		return &p.toks[p.i]
+	}
+	return nil
+}
+
+func (p *parser) pos() int {
+	if t := p.peek(); t != nil {
+		return t.pos
+	}
+	return p.end
+}
+
+// or := and ("OR" and)*
+func (p *parser) or() (Node, error) {
+	n, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	nodes := []Node{n}
+	for t := p.peek(); t != nil && t.kind == tokOr; t = p.peek() {
+		p.i++
+		n, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+	return Or{Nodes: nodes}, nil
+}
+
+// and := unary (["AND"] unary)*
+func (p *parser) and() (Node, error) {
+	var nodes []Node
+	for {
+		t := p.peek()
+		if t != nil && t.kind == tokAnd {
+			if len(nodes) == 0 {
+				return nil, &Error{Pos: t.pos, Msg: "AND needs a term before it"}
+			}
+			p.i++
+			t = p.peek()
+			if t == nil || t.kind == tokOr || t.kind == tokRParen || t.kind == tokAnd {
+				return nil, &Error{Pos: p.pos(), Msg: "AND needs a term after it"}
+			}
+		}
+		if t == nil || t.kind == tokOr || t.kind == tokRParen {
+			break
+		}
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	switch len(nodes) {
+	case 0:
+		if t := p.peek(); t != nil && t.kind == tokRParen {
+			return nil, &Error{Pos: t.pos, Msg: `unexpected ")"`}
+		}
+		return nil, &Error{Pos: p.pos(), Msg: "OR needs a term on each side"}
+	case 1:
+		return nodes[0], nil
+	}
+	return And{Nodes: nodes}, nil
+}
+
+// unary := "-" unary | "(" or ")" | term
+func (p *parser) unary() (Node, error) {
+	t := p.peek()
+	if t.kind == tokNot || t.kind == tokLParen {
+		p.depth++
+		defer func() { p.depth-- }()
+		if p.depth > maxDepth {
+			return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("nested too deeply, at most %d levels are allowed", maxDepth)}
+		}
+	}
+	switch t.kind {
+	case tokNot:
+		p.i++
+		if next := p.peek(); next == nil || next.kind == tokOr || next.kind == tokAnd {
+			return nil, &Error{Pos: t.pos, Msg: `nothing to exclude after "-"`}
+		}
+		n, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return Not{Node: n}, nil
+	case tokLParen:
+		p.i++
+		if next := p.peek(); next != nil && next.kind == tokRParen {
+			return nil, &Error{Pos: next.pos, Msg: "empty group"}
+		}
+		n, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if next := p.peek(); next == nil || next.kind != tokRParen {
+			return nil, &Error{Pos: t.pos, Msg: `missing ")" for this "("`}
+		}
+		p.i++
+		return n, nil
+	}
+	p.i++
+	p.terms++
+	if p.terms > maxTerms {
+		return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("too many terms, at most %d are allowed", maxTerms)}
+	}
+	switch t.kind {
+	case tokWord:
+		return Text{Value: t.text}, nil
+	case tokPhrase:
+		return Text{Value: t.text, Phrase: true}, nil
+	case tokField:
+		return p.field(t)
+	}
+	return nil, &Error{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s", t)}
+}
+
+func (p *parser) field(t *token) (Node, error) {
+	switch t.text {
+	case "title":
+		return Text{Value: t.value, Phrase: t.phrase, InTitle: true}, nil
+	case "tag", "author", "project":
+		return Field{Name: t.text, Value: t.value}, nil
+	case "pinned":
+		switch strings.ToLower(t.value) {
+		case "true", "yes":
+			return Pinned{Value: true}, nil
+		case "false", "no":
+			return Pinned{Value: false}, nil
+		}
+		return nil, &Error{Pos: t.valuePos, Msg: `pinned: takes "true" or "false"`}
+	}
+	return p.dateRange(t)
+}
+
+// dateRange reads ">day", ">=day", "<day", "<=day", "day" or "day..day".
+// Days are UTC.
+func (p *parser) dateRange(t *token) (Node, error) {
+	v := t.value
+	r := DateRange{Name: t.text}
+	day := func(s string, pos int) (time.Time, error) {
+		if d, ok := p.relativeDay(s); ok {
+			return d, nil
+		}
+		d, err := time.Parse(dateLayout, s)
+		if err != nil {
+			return time.Time{}, &Error{Pos: pos, Msg: fmt.Sprintf("invalid date %q, want YYYY-MM-DD, today or today-Nd", s)}
+		}
+		return d, nil
+	}
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if !strings.HasPrefix(v, op) {
+			continue
+		}
+		d, err := day(v[len(op):], t.valuePos+len(op))
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case ">=":
+			r.From = d
+		case ">":
+			r.From = d.AddDate(0, 0, 1)
+		case "<=":
+			r.To = d.AddDate(0, 0, 1)
+		case "<":
+			r.To = d
+		}
+		return r, nil
+	}
+	if from, to, ok := strings.Cut(v, ".."); ok {
+		f, err := day(from, t.valuePos)
+		if err != nil {
+			return nil, err
+		}
+		l, err := day(to, t.valuePos+len([]rune(from))+2)
+		if err != nil {
+			return nil, err
+		}
+		if l.Before(f) {
+			return nil, &Error{Pos: t.valuePos, Msg: "date range ends before it starts"}
+		}
+		r.From, r.To = f, l.AddDate(0, 0, 1)
+		return r, nil
+	}
+	d, err := day(v, t.valuePos)
+	if err != nil {
+		return nil, err
+	}
+	r.From, r.To = d, d.AddDate(0, 0, 1)
+	return r, nil
+}
+
+// relativeDay reads "today", "today-Nd" (N days ago) and "today-Nw" (N
+// weeks ago).
+func (p *parser) relativeDay(s string) (time.Time, bool) {
+	s = strings.ToLower(s)
+	if s == "today" {
+		return p.today, true
+	}
+	ago, ok := strings.CutPrefix(s, "today-")
+	if !ok || len(ago) < 2 {
+		return time.Time{}, false
+	}
+	days := 1
+	switch ago[len(ago)-1] {
+	case 'd':
+	case 'w':
+		days = 7
+	default:
+		return time.Time{}, false
+	}
+	n, err := strconv.Atoi(ago[:len(ago)-1])
+	if err != nil || n < 0 || n > 100000 {
+		return time.Time{}, false
+	}
+	return p.today.AddDate(0, 0, -n*days), true
+}
+
+// Terms returns the Text nodes of n that a match has to or may contain, in
+// query order: everything except what is excluded with "-".
+func Terms(n Node) []Text {
+	var out []Text
+	var walk func(Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case And:
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case Or:
+			for _, c := range n.Nodes {
+				walk(c)
+			}
+		case Text:
+			out = append(out, n)
+		}
+	}
+	walk(n)
+	return out
+}
@@ -0,0 +1,168 @@
+package search_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/search"
+	"github.com/stretchr/testify/require"
+)
+
+func day(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		query string
+		want  search.Node
+	}{
+		{"", nil},
+		{"  ", nil},
+		{"deploy", search.Text{Value: "deploy"}},
+		{`deploy "release  notes"`, search.And{Nodes: []search.Node{
+			search.Text{Value: "deploy"},
+			search.Text{Value: "release notes", Phrase: true},
+		}}},
+		{"a OR b c", search.Or{Nodes: []search.Node{
+			search.Text{Value: "a"},
+			search.And{Nodes: []search.Node{search.Text{Value: "b"}, search.Text{Value: "c"}}},
+		}}},
+		{"a AND (b OR c) -draft", search.And{Nodes: []search.Node{
+			search.Text{Value: "a"},
+			search.Or{Nodes: []search.Node{search.Text{Value: "b"}, search.Text{Value: "c"}}},
+			search.Not{Node: search.Text{Value: "draft"}},
+		}}},
+		{"e-mail or", search.And{Nodes: []search.Node{search.Text{Value: "e-mail"}, search.Text{Value: "or"}}}},
+		{`Title:"weekly sync" tag:eng/backend`, search.And{Nodes: []search.Node{
+			search.Text{Value: "weekly sync", Phrase: true, InTitle: true},
+			search.Field{Name: "tag", Value: "eng/backend"},
+		}}},
+		{"-author:alice project:infra", search.And{Nodes: []search.Node{
+			search.Not{Node: search.Field{Name: "author", Value: "alice"}},
+			search.Field{Name: "project", Value: "infra"},
+		}}},
+		{"pinned:true", search.Pinned{Value: true}},
+		{"https://example.com", search.Text{Value: "https://example.com"}},
+		{"updated:>2026-01-01", search.DateRange{Name: "updated", From: day("2026-01-02")}},
+		{"updated:>=2026-01-01", search.DateRange{Name: "updated", From: day("2026-01-01")}},
+		{"created:<2026-01-01", search.DateRange{Name: "created", To: day("2026-01-01")}},
+		{"created:<=2026-01-01", search.DateRange{Name: "created", To: day("2026-01-02")}},
+		{"created:2026-01-01", search.DateRange{Name: "created", From: day("2026-01-01"), To: day("2026-01-02")}},
+		{"updated:2026-01-01..2026-01-31", search.DateRange{Name: "updated", From: day("2026-01-01"), To: day("2026-02-01")}},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			got, err := search.Parse(c.query)
+			require.NoError(t, err)
+			require.Equal(t, c.want, got)
+		})
+	}
+}
+
+func TestParseAt_RelativeDays(t *testing.T) {
+	now := time.Date(2026, 3, 10, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*3600))
+	cases := []struct {
+		query string
+		want  search.Node
+	}{
+		{"updated:today", search.DateRange{Name: "updated", From: day("2026-03-11"), To: day("2026-03-12")}},
+		{"updated:>=today-7d", search.DateRange{Name: "updated", From: day("2026-03-04")}},
+		{"created:<TODAY-2w", search.DateRange{Name: "created", To: day("2026-02-25")}},
+		{"created:today-1d..today", search.DateRange{Name: "created", From: day("2026-03-10"), To: day("2026-03-12")}},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			got, err := search.ParseAt(c.query, now)
+			require.NoError(t, err)
+			require.Equal(t, c.want, got)
+		})
+	}
+
+	for _, q := range []string{"updated:today-7", "updated:today-xd", "updated:today--1d"} {
+		_, err := search.ParseAt(q, now)
+		var serr *search.Error
+		require.ErrorAs(t, err, &serr, q)
+		require.Equal(t, 9, serr.Pos, q)
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		query string
+		pos   int
+	}{
+		{`deploy "release notes`, 8},
+		{`""`, 1},
+		{"a OR", 5},
+		{"OR a", 1},
+		{"a OR OR b", 6},
+		{"(a b", 1},
+		{"a b)", 4},
+		{"()", 2},
+		{"a -", 3},
+		{"title:", 7},
+		{"pinned:maybe", 8},
+		{"updated:>2026-13-01", 10},
+		{"updated:2026-01-01..01/02/2026", 21},
+		{"updated:2026-02-01..2026-01-01", 9},
+		{"AND a", 1},
+		{"été -", 5},
+	}
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			_, err := search.Parse(c.query)
+			var serr *search.Error
+			require.ErrorAs(t, err, &serr)
+			require.Equal(t, c.pos, serr.Pos, serr.Msg)
+		})
+	}
+}
+
+func TestParse_TooManyTerms(t *testing.T) {
+	q := ""
+	for i := 0; i < 65; i++ {
+		q += "x "
+	}
+	_, err := search.Parse(q)
+	var serr *search.Error
+	require.ErrorAs(t, err, &serr)
+	require.Equal(t, 129, serr.Pos)
+}
+
+func TestParse_DeepNesting(t *testing.T) {
+	_, err := search.Parse(strings.Repeat("(", 32) + "x" + strings.Repeat(")", 32))
+	require.NoError(t, err)
+
+	cases := []struct {
+		query string
+		pos   int
+	}{
+		{strings.Repeat("(", 33) + "x" + strings.Repeat(")", 33), 33},
+		{strings.Repeat("-(", 20) + "x", 33},
+		{strings.Repeat("(-", 20) + "x", 33},
+		// Would overflow the stack if it reached the parser.
+		{strings.Repeat("(", 1900000), 4097},
+	}
+	for _, c := range cases {
+		_, err := search.Parse(c.query)
+		var serr *search.Error
+		require.ErrorAs(t, err, &serr)
+		require.Equal(t, c.pos, serr.Pos, serr.Msg)
+	}
+}
+
+func TestTerms(t *testing.T) {
+	n, err := search.Parse(`deploy -draft (title:"weekly sync" OR -(x y)) tag:eng`)
+	require.NoError(t, err)
+	require.Equal(t, []search.Text{
+		{Value: "deploy"},
+		{Value: "weekly sync", Phrase: true, InTitle: true},
+	}, search.Terms(n))
+	require.Nil(t, search.Terms(nil))
+}
@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+
+	"dovakin0007.com/notes-grpc/internal/blobstore"
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	maxAttachmentBytes = 64 << 20
+	downloadChunkSize  = 64 << 10
+)
+
+func (s *noteServiceServer) UploadAttachment(stream pb.NoteService_UploadAttachmentServer) error {
+	if s.blobs == nil {
+		return status.Error(codes.Unimplemented, "attachment storage is not configured")
+	}
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	meta := first.GetMetadata()
+	if meta == nil || meta.GetNoteId() == "" || meta.GetFileName() == "" {
+		return status.Error(codes.InvalidArgument, "the first message must carry metadata with note_id and file_name")
+	}
+	// Refuse callers who could not attach the upload before taking any of it.
+	if err := s.db.CheckNoteRole(ctx, meta.GetNoteId(), models.RoleEditor); err != nil {
+		return err
+	}
+
+	// The bytes land under a temporary key first; the final key is their
+	// SHA-256, which is only known once the whole stream has been read.
+	id := uuid.NewString()
+	tmpKey := "upload-" + id
+	hasher := sha256.New()
+	body := &uploadReader{stream: stream, limit: maxAttachmentBytes}
+	size, err := s.blobs.Put(ctx, tmpKey, io.TeeReader(body, hasher))
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "storing attachment: %v", err)
+	}
+	sum := hex.EncodeToString(hasher.Sum(nil))
+
+	if want := meta.GetSha256(); want != "" && want != sum {
+		s.deleteBlob(tmpKey)
+		return status.Errorf(codes.InvalidArgument, "sha256 mismatch: client sent %s, server computed %s", want, sum)
+	}
+
+	// Claim before moving so the collector cannot remove the shared blob
+	// between the move and the attachment insert. Identical content may
+	// already be stored under sum; replacing it is harmless.
+	key := sum
+	if err := s.db.ClaimBlob(ctx, key, size); err != nil {
+		s.deleteBlob(tmpKey)
+		return err
+	}
+	if err := s.blobs.Move(ctx, tmpKey, key); err != nil {
+		s.deleteBlob(tmpKey)
+		return status.Errorf(codes.Internal, "storing attachment: %v", err)
+	}
+
+	att, err := s.db.CreateAttachment(ctx, models.Attachment{
+		ID:        id,
+		NoteID:    meta.GetNoteId(),
+		URL:       "attachment://" + id,
+		FileName:  meta.GetFileName(),
+		FileType:  meta.GetFileType(),
+		SHA256:    &sum,
+		SizeBytes: &size,
+		BlobKey:   &key,
+	})
+	if err != nil {
+		// Other attachments may share the blob; the collector removes it
+		// if nothing ends up referencing it.
+		return err
+	}
+	return stream.SendAndClose(utils.AttachmentModelToProto(*att))
+}
+
+func (s *noteServiceServer) DownloadAttachment(req *pb.DownloadAttachmentRequest, stream pb.NoteService_DownloadAttachmentServer) error {
+	if s.blobs == nil {
+		return status.Error(codes.Unimplemented, "attachment storage is not configured")
+	}
+	if req.GetAttachmentId() == "" {
+		return status.Error(codes.InvalidArgument, "attachment_id is required")
+	}
+	ctx := stream.Context()
+
+	att, err := s.db.GetAttachment(ctx, req.GetAttachmentId())
+	if err != nil {
+		return err
+	}
+	if att.BlobKey == nil {
+		return status.Errorf(codes.FailedPrecondition, "attachment is stored externally at %s", att.URL)
+	}
+
+	blob, _, err := s.blobs.Get(ctx, *att.BlobKey)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return status.Error(codes.DataLoss, "attachment bytes are missing")
+		}
+		return status.Errorf(codes.Internal, "opening attachment: %v", err)
+	}
+	defer blob.Close()
+
+	if err := stream.Send(&pb.DownloadAttachmentResponse{
+		Payload: &pb.DownloadAttachmentResponse_Metadata{Metadata: utils.AttachmentModelToProto(*att)},
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+		n, err := blob.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&pb.DownloadAttachmentResponse{
+				Payload: &pb.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]},
+			}); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "reading attachment: %v", err)
+		}
+	}
+}
+
+func (s *noteServiceServer) deleteBlob(key string) {
+	if err := s.blobs.Delete(context.Background(), key); err != nil {
+		log.Printf("failed to delete blob %s: %v", key, err)
+	}
+}
+
+// uploadReader turns the chunk messages of an upload stream into an io.Reader.
+type uploadReader struct {
+	stream pb.NoteService_UploadAttachmentServer
+	buf    []byte
+	read   int64
+	limit  int64
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	for len(u.buf) == 0 {
+		msg, err := u.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if msg.GetMetadata() != nil {
+			return 0, status.Error(codes.InvalidArgument, "metadata may only be sent in the first message")
+		}
+		u.buf = msg.GetChunk()
+	}
+	n := copy(p, u.buf)
+	u.buf = u.buf[n:]
+	u.read += int64(n)
+	if u.read > u.limit {
+		return 0, status.Errorf(codes.ResourceExhausted, "attachment exceeds %d bytes", u.limit)
+	}
+	return n, nil
+}
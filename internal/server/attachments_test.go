@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/blobstore"
+	"dovakin0007.com/notes-grpc/internal/server"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func newAttachmentClient(t *testing.T, mock *mockStore, blobs blobstore.Store) pb.NoteServiceClient {
+	t.Helper()
+	srv := grpc.NewServer()
+	pb.RegisterNoteServiceServer(srv, server.NewNoteServiceServerWithBlobStore(mock, blobs))
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(dialerWithServer(t, srv)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewNoteServiceClient(conn)
+}
+
+func upload(t *testing.T, client pb.NoteServiceClient, meta *pb.UploadAttachmentMetadata, chunks ...[]byte) (*pb.Attachment, error) {
+	t.Helper()
+	stream, err := client.UploadAttachment(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.UploadAttachmentRequest{
+		Payload: &pb.UploadAttachmentRequest_Metadata{Metadata: meta},
+	}))
+	for _, c := range chunks {
+		require.NoError(t, stream.Send(&pb.UploadAttachmentRequest{
+			Payload: &pb.UploadAttachmentRequest_Chunk{Chunk: c},
+		}))
+	}
+	return stream.CloseAndRecv()
+}
+
+func TestUploadAndDownloadAttachment(t *testing.T) {
+	blobs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+	client := newAttachmentClient(t, &mockStore{}, blobs)
+
+	body := bytes.Repeat([]byte("notes-grpc "), 20000)
+	sum := sha256.Sum256(body)
+
+	att, err := upload(t, client,
+		&pb.UploadAttachmentMetadata{NoteId: "note-1", FileName: "big.txt", FileType: "text/plain"},
+		body[:100000], body[100000:],
+	)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(sum[:]), att.GetSha256())
+	require.Equal(t, int64(len(body)), att.GetSizeBytes())
+	require.Equal(t, "big.txt", att.GetFileName())
+
+	stream, err := client.DownloadAttachment(context.Background(), &pb.DownloadAttachmentRequest{AttachmentId: att.GetId()})
+	require.NoError(t, err)
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, att.GetId(), first.GetMetadata().GetId())
+
+	var got bytes.Buffer
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		got.Write(msg.GetChunk())
+	}
+	require.Equal(t, body, got.Bytes())
+}
+
+func TestUploadAttachment_ChecksumMismatch(t *testing.T) {
+	blobs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+	client := newAttachmentClient(t, &mockStore{}, blobs)
+
+	_, err = upload(t, client,
+		&pb.UploadAttachmentMetadata{NoteId: "note-1", FileName: "a.txt", Sha256: ptrString("not-the-sum")},
+		[]byte("hello"),
+	)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = upload(t, client, &pb.UploadAttachmentMetadata{FileName: "a.txt"}, []byte("hello"))
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUploadAttachment_DeduplicatesBySHA256(t *testing.T) {
+	dir := t.TempDir()
+	blobs, err := blobstore.NewFileSystem(dir)
+	require.NoError(t, err)
+	mock := &mockStore{}
+	client := newAttachmentClient(t, mock, blobs)
+
+	body := []byte("the same design pdf")
+	sum := sha256.Sum256(body)
+	key := hex.EncodeToString(sum[:])
+
+	first, err := upload(t, client, &pb.UploadAttachmentMetadata{NoteId: "note-1", FileName: "design.pdf"}, body)
+	require.NoError(t, err)
+	second, err := upload(t, client, &pb.UploadAttachmentMetadata{NoteId: "note-2", FileName: "design.pdf"}, body)
+	require.NoError(t, err)
+	require.NotEqual(t, first.GetId(), second.GetId())
+
+	require.Equal(t, key, *mock.attachments[first.GetId()].BlobKey)
+	require.Equal(t, key, *mock.attachments[second.GetId()].BlobKey)
+
+	var files []string
+	require.NoError(t, filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, filepath.Base(path))
+		}
+		return err
+	}))
+	require.Equal(t, []string{key}, files)
+}
+
+// putCountingStore counts the blobs written to it.
+type putCountingStore struct {
+	blobstore.Store
+	puts int
+}
+
+func (s *putCountingStore) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	s.puts++
+	return s.Store.Put(ctx, key, r)
+}
+
+func TestUploadAttachment_ViewerIsRefusedUpFront(t *testing.T) {
+	fs, err := blobstore.NewFileSystem(t.TempDir())
+	require.NoError(t, err)
+	blobs := &putCountingStore{Store: fs}
+	mock := &mockStore{roleErr: status.Error(codes.PermissionDenied, "editor role required on note")}
+	client := newAttachmentClient(t, mock, blobs)
+
+	stream, err := client.UploadAttachment(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.UploadAttachmentRequest{
+		Payload: &pb.UploadAttachmentRequest_Metadata{Metadata: &pb.UploadAttachmentMetadata{NoteId: "note-1", FileName: "a.txt"}},
+	}))
+	// The stream stays open: the server answers without waiting for any data.
+	err = stream.RecvMsg(&pb.Attachment{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Zero(t, blobs.puts)
+	require.Empty(t, mock.attachments)
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatchItems caps batch calls, which hold the store lock for the whole
+// batch.
+const maxBatchItems = 500
+
+func (s *noteServiceServer) BatchGetNotes(ctx context.Context, req *pb.BatchGetNotesRequest) (*pb.BatchNotesResponse, error) {
+	ids := req.GetIds()
+	opts := models.GetNoteOptions{
+		IncludeAttachments: req.GetIncludeAttachments(),
+		IncludeDeleted:     req.GetIncludeDeleted(),
+	}
+	return runBatch(len(ids), req.GetAllowPartialFailure(),
+		func(i int) (string, error) {
+			if ids[i] == "" {
+				return "", errors.New("id is required")
+			}
+			return ids[i], nil
+		},
+		func(items []string, partial bool) ([]models.BatchResult, error) {
+			return s.db.BatchGetNotes(ctx, items, opts, partial)
+		})
+}
+
+func (s *noteServiceServer) BatchCreateNotes(ctx context.Context, req *pb.BatchCreateNotesRequest) (*pb.BatchNotesResponse, error) {
+	reqs := req.GetRequests()
+	return runBatch(len(reqs), req.GetAllowPartialFailure(),
+		func(i int) (models.CreateNoteInput, error) {
+			if reqs[i].GetTitle() == "" {
+				return models.CreateNoteInput{}, errors.New("title is required")
+			}
+			author := callerOr(ctx, reqs[i].GetAuthor())
+			if author == nil {
+				return models.CreateNoteInput{}, errors.New("author is required")
+			}
+			in := utils.ToCreateNoteInput(reqs[i])
+			in.Author = *author
+			return in, nil
+		},
+		func(items []models.CreateNoteInput, partial bool) ([]models.BatchResult, error) {
+			return s.db.BatchCreateNotes(ctx, items, partial)
+		})
+}
+
+func (s *noteServiceServer) BatchUpdateNotes(ctx context.Context, req *pb.BatchUpdateNotesRequest) (*pb.BatchNotesResponse, error) {
+	reqs := req.GetRequests()
+	return runBatch(len(reqs), req.GetAllowPartialFailure(),
+		func(i int) (models.UpdateNoteInput, error) {
+			if reqs[i].GetNoteId() == "" {
+				return models.UpdateNoteInput{}, errors.New("note_id is required")
+			}
+			var in models.UpdateNoteInput
+			utils.UpdatesNotesMask(&in, reqs[i])
+			in.Editor = callerOr(ctx, reqs[i].GetUser())
+			return in, nil
+		},
+		func(items []models.UpdateNoteInput, partial bool) ([]models.BatchResult, error) {
+			return s.db.BatchUpdateNotes(ctx, items, partial)
+		})
+}
+
+func (s *noteServiceServer) BatchDeleteNotes(ctx context.Context, req *pb.BatchDeleteNotesRequest) (*pb.BatchNotesResponse, error) {
+	reqs := req.GetRequests()
+	return runBatch(len(reqs), req.GetAllowPartialFailure(),
+		func(i int) (models.DeleteNoteInput, error) {
+			if reqs[i].GetNoteId() == "" {
+				return models.DeleteNoteInput{}, errors.New("note_id is required")
+			}
+			in := utils.ProtoToDeleteNoteInput(reqs[i])
+			in.DeletedBy = callerOr(ctx, reqs[i].GetUser())
+			return in, nil
+		},
+		func(items []models.DeleteNoteInput, partial bool) ([]models.BatchResult, error) {
+			return s.db.BatchDeleteNotes(ctx, items, partial)
+		})
+}
+
+// runBatch validates the n request items with prepare, hands the valid ones to
+// the store and lays the results out in request order. Without partial any
+// invalid item fails the call before the store is touched.
+func runBatch[T any](n int, partial bool, prepare func(i int) (T, error), run func(items []T, partial bool) ([]models.BatchResult, error)) (*pb.BatchNotesResponse, error) {
+	if n == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one item is required")
+	}
+	if n > maxBatchItems {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d items are allowed", maxBatchItems)
+	}
+
+	results := make([]*pb.BatchNoteResult, n)
+	items := make([]T, 0, n)
+	index := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		item, err := prepare(i)
+		if err != nil {
+			if !partial {
+				return nil, status.Errorf(codes.InvalidArgument, "item %d: %v", i, err)
+			}
+			results[i] = &pb.BatchNoteResult{Status: status.New(codes.InvalidArgument, err.Error()).Proto()}
+			continue
+		}
+		items = append(items, item)
+		index = append(index, i)
+	}
+	if len(items) == 0 {
+		return &pb.BatchNotesResponse{Results: results}, nil
+	}
+
+	out, err := run(items, partial)
+	if err != nil {
+		return nil, updateErrorToStatus(err)
+	}
+	for j, r := range out {
+		res := &pb.BatchNoteResult{Status: status.New(codes.OK, "").Proto()}
+		if r.Err != nil {
+			res.Status = status.Convert(updateErrorToStatus(r.Err)).Proto()
+		} else if r.Note != nil {
+			res.Note = utils.NoteToProto(*r.Note)
+			res.Duplicates = utils.DuplicatesToProto(r.Note.Duplicates)
+		}
+		results[index[j]] = res
+	}
+	return &pb.BatchNotesResponse{Results: results}, nil
+}
@@ -0,0 +1,90 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBatchGetNotes_PartialFailure(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	resp, err := client.BatchGetNotes(context.Background(), &pb.BatchGetNotesRequest{
+		Ids:                 []string{"n1", "missing", "", "n2"},
+		AllowPartialFailure: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResults(), 4)
+
+	require.Equal(t, int32(codes.OK), resp.GetResults()[0].GetStatus().GetCode())
+	require.Equal(t, "n1", resp.GetResults()[0].GetNote().GetId())
+	require.Equal(t, int32(codes.NotFound), resp.GetResults()[1].GetStatus().GetCode())
+	require.Nil(t, resp.GetResults()[1].GetNote())
+	require.Equal(t, int32(codes.InvalidArgument), resp.GetResults()[2].GetStatus().GetCode())
+	require.Equal(t, "n2", resp.GetResults()[3].GetNote().GetId())
+}
+
+func TestBatchUpdateNotes_AllOrNothing(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	_, err := client.BatchUpdateNotes(context.Background(), &pb.BatchUpdateNotesRequest{
+		Requests: []*pb.UpdateNoteRequest{{NoteId: "n1"}, {NoteId: "missing"}},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.BatchUpdateNotes(context.Background(), &pb.BatchUpdateNotesRequest{
+		Requests: []*pb.UpdateNoteRequest{{NoteId: "n1"}, {}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "item 1")
+}
+
+func TestBatchCreateNotes(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	resp, err := client.BatchCreateNotes(context.Background(), &pb.BatchCreateNotesRequest{
+		Requests: []*pb.CreateNoteRequest{
+			{Title: "first", Author: &pb.ActorRef{Id: "user-1"}},
+			{Title: "second", Author: &pb.ActorRef{Id: "user-1"}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResults(), 2)
+	require.Equal(t, "second", resp.GetResults()[1].GetNote().GetTitle())
+}
+
+func TestBatchCreateNotes_Duplicates(t *testing.T) {
+	mock := &mockStore{
+		duplicates: []models.DuplicateNote{{Note: models.Note{ID: "note-old", Title: "first"}, Similarity: 0.8}},
+	}
+	client := newTestClient(t, mock)
+
+	resp, err := client.BatchCreateNotes(context.Background(), &pb.BatchCreateNotesRequest{
+		Requests: []*pb.CreateNoteRequest{{Title: "first", Author: &pb.ActorRef{Id: "user-1"}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResults(), 1)
+	dups := resp.GetResults()[0].GetDuplicates()
+	require.Len(t, dups, 1)
+	require.Equal(t, "note-old", dups[0].GetNote().GetId())
+	require.Equal(t, float32(0.8), dups[0].GetSimilarity())
+}
+
+func TestBatchDeleteNotes_Limits(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	_, err := client.BatchDeleteNotes(context.Background(), &pb.BatchDeleteNotesRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	reqs := make([]*pb.DeleteNoteRequest, 501)
+	for i := range reqs {
+		reqs[i] = &pb.DeleteNoteRequest{NoteId: "n1"}
+	}
+	_, err = client.BatchDeleteNotes(context.Background(), &pb.BatchDeleteNotesRequest{Requests: reqs})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
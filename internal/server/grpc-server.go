@@ -8,8 +8,13 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"time"
 
+	"dovakin0007.com/notes-grpc/internal/auth"
+	"dovakin0007.com/notes-grpc/internal/blobstore"
 	"dovakin0007.com/notes-grpc/internal/database"
+	"dovakin0007.com/notes-grpc/internal/diff"
 	"dovakin0007.com/notes-grpc/internal/models"
 	"dovakin0007.com/notes-grpc/internal/utils"
 	pb "dovakin0007.com/notes-grpc/notes"
@@ -24,25 +29,82 @@ type Store interface {
 	CreateNote(ctx context.Context, in models.CreateNoteInput) (*models.Note, error)
 	UpdateNote(ctx context.Context, in models.UpdateNoteInput) (*models.Note, error)
 	ListNotes(ctx context.Context, in models.ListNotesFilter) ([]models.Note, string, error)
+	StreamNotes(ctx context.Context, filter models.ListNotesFilter, fn func(models.Note) error) error
 	ViewNote(ctx context.Context, id string, opts models.GetNoteOptions) (*models.Note, error)
-	DeleteNote(ctx context.Context, id string, hard bool) (bool, error)
+	DeleteNote(ctx context.Context, in models.DeleteNoteInput) (bool, error)
+	MoveNotes(ctx context.Context, in models.MoveNotesInput) ([]models.Note, error)
+	BatchGetNotes(ctx context.Context, ids []string, opts models.GetNoteOptions, partial bool) ([]models.BatchResult, error)
+	BatchCreateNotes(ctx context.Context, ins []models.CreateNoteInput, partial bool) ([]models.BatchResult, error)
+	BatchUpdateNotes(ctx context.Context, ins []models.UpdateNoteInput, partial bool) ([]models.BatchResult, error)
+	BatchDeleteNotes(ctx context.Context, ins []models.DeleteNoteInput, partial bool) ([]models.BatchResult, error)
+	RestoreNote(ctx context.Context, id string) (*models.Note, error)
+	PurgeNote(ctx context.Context, id string) (bool, error)
+	ListNoteRevisions(ctx context.Context, in models.ListNoteRevisionsFilter) ([]models.NoteRevision, string, error)
+	RestoreNoteRevision(ctx context.Context, in models.RestoreNoteRevisionInput) (*models.Note, error)
+	GetNoteRevision(ctx context.Context, noteID, revisionID string) (*models.NoteRevision, error)
+	CheckNoteRole(ctx context.Context, noteID string, min models.Role) error
+	CreateAttachment(ctx context.Context, a models.Attachment) (*models.Attachment, error)
+	GetAttachment(ctx context.Context, id string) (*models.Attachment, error)
+	ClaimBlob(ctx context.Context, key string, size int64) error
+	ListNoteEvents(ctx context.Context, filter models.NoteEventFilter) ([]models.NoteEvent, error)
+	LatestNoteEventID(ctx context.Context) (int64, error)
+	SubscribeNoteEvents() (<-chan struct{}, func())
+	CreateProject(ctx context.Context, in models.CreateProjectInput) (*models.Project, error)
+	GetProject(ctx context.Context, id string) (*models.Project, error)
+	ListProjects(ctx context.Context, filter models.ListProjectsFilter) ([]models.Project, string, error)
+	UpdateProject(ctx context.Context, in models.UpdateProjectInput) (*models.Project, error)
+	ArchiveProject(ctx context.Context, id string) (*models.Project, error)
+	ShareNote(ctx context.Context, noteID string, g models.Grant) (*models.Grant, error)
+	UnshareNote(ctx context.Context, noteID string, p models.Principal) (bool, error)
+	ListNoteGrants(ctx context.Context, noteID string) ([]models.Grant, error)
+	ShareProject(ctx context.Context, projectID string, g models.Grant) (*models.Grant, error)
+	UnshareProject(ctx context.Context, projectID string, p models.Principal) (bool, error)
+	ListProjectGrants(ctx context.Context, projectID string) ([]models.Grant, error)
+	ListTags(ctx context.Context, filter models.ListTagsFilter) ([]models.TagCount, error)
+	MergeTags(ctx context.Context, in models.MergeTagsInput) (int, error)
+	DeleteTag(ctx context.Context, projectID *string, tag string) (int, error)
+	SearchTitles(ctx context.Context, filter models.SearchTitlesFilter) ([]models.TitleMatch, []models.Suggestion, error)
+	CountNotes(ctx context.Context, filter models.ListNotesFilter) (int64, error)
+	CreateSavedSearch(ctx context.Context, in models.CreateSavedSearchInput) (*models.SavedSearch, error)
+	GetSavedSearch(ctx context.Context, id string) (*models.SavedSearch, error)
+	ListSavedSearches(ctx context.Context, filter models.ListSavedSearchesFilter) ([]models.SavedSearch, string, error)
+	DeleteSavedSearch(ctx context.Context, id string) (bool, error)
+	ShareSavedSearch(ctx context.Context, id string, g models.Grant) (*models.Grant, error)
+	UnshareSavedSearch(ctx context.Context, id string, p models.Principal) (bool, error)
+	GetRelatedNotes(ctx context.Context, noteID string, limit int) ([]models.RelatedNote, error)
 }
 
 type GrpcServer struct {
 	Addr         string
 	grpcServer   *grpc.Server
 	healthServer *health.Server
+	stopJobs     context.CancelFunc
 }
 
 type noteServiceServer struct {
 	pb.UnimplementedNoteServiceServer
 
-	db database.Store
+	db    database.Store
+	blobs blobstore.Store
+
+	// Events older than this may have been pruned, so WatchNotes rejects
+	// cursors past it. Zero disables the check.
+	eventRetention time.Duration
 }
 
 func NewNoteServiceServer() *noteServiceServer {
+	blobDir := os.Getenv("NOTES_BLOB_DIR")
+	if blobDir == "" {
+		blobDir = "data/blobs"
+	}
+	blobs, err := blobstore.NewFileSystem(blobDir)
+	if err != nil {
+		log.Fatalf("failed to open blob store: %v", err)
+	}
 	return &noteServiceServer{
-		db: database.GetDb(),
+		db:             database.GetDb(),
+		blobs:          blobs,
+		eventRetention: envDuration("NOTES_EVENT_RETENTION", 7*24*time.Hour),
 	}
 }
 
@@ -52,17 +114,32 @@ func NewNoteServiceServerWithStore(s database.Store) *noteServiceServer {
 	}
 }
 
+func NewNoteServiceServerWithBlobStore(s database.Store, blobs blobstore.Store) *noteServiceServer {
+	return &noteServiceServer{
+		db:    s,
+		blobs: blobs,
+	}
+}
+
 func (s *noteServiceServer) CreateNote(ctx context.Context, req *pb.CreateNoteRequest) (*pb.NoteResponse, error) {
-	if req == nil || req.Title == "" || req.Author == nil {
+	if req == nil || req.Title == "" {
 		return nil, status.Error(codes.InvalidArgument, "project_id and title are required")
 	}
+	author := callerOr(ctx, req.Author)
+	if author == nil {
+		return nil, status.Error(codes.InvalidArgument, "author is required")
+	}
 
 	input := utils.ToCreateNoteInput(req)
+	input.Author = *author
 	note, err := s.db.CreateNote(ctx, input)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "failed to create note")
 		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "unable to insert into db: %v", err)
 	}
 
@@ -71,7 +148,8 @@ func (s *noteServiceServer) CreateNote(ctx context.Context, req *pb.CreateNoteRe
 	}
 
 	return &pb.NoteResponse{
-		Note: utils.NoteToProto(*note),
+		Note:       utils.NoteToProto(*note),
+		Duplicates: utils.DuplicatesToProto(note.Duplicates),
 	}, nil
 }
 
@@ -82,13 +160,11 @@ func (s *noteServiceServer) UpdateNote(ctx context.Context, req *pb.UpdateNoteRe
 	}
 	var noteUpdate models.UpdateNoteInput
 	utils.UpdatesNotesMask(&noteUpdate, req)
+	noteUpdate.Editor = callerOr(ctx, req.User)
 	note, err := s.db.UpdateNote(ctx, noteUpdate)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, status.Error(codes.NotFound, "note not found")
-		}
-		return nil, status.Errorf(codes.Internal, "failed to update note: %v", err)
+		return nil, updateErrorToStatus(err)
 	}
 	if note == nil {
 		return nil, status.Error(codes.NotFound, "note not found")
@@ -117,21 +193,41 @@ func (s *noteServiceServer) ListNotes(c context.Context, req *pb.ListNotesReques
 	return &pb.ListNotesResponse{
 		Notes:         protoNotes,
 		NextPageToken: token,
+		Results:       utils.SearchResultsToProto(notes),
 	}, nil
 
 }
 
+func (s *noteServiceServer) StreamNotes(req *pb.ListNotesRequest, stream pb.NoteService_StreamNotesServer) error {
+	if req == nil {
+		return status.Error(codes.InvalidArgument, "the input request was empty")
+	}
+	// Send blocks while the client's flow control window is full, which in
+	// turn stops the database cursor from being read any further.
+	return s.db.StreamNotes(stream.Context(), utils.ProtoToListNotesFilter(req), func(n models.Note) error {
+		return stream.Send(utils.NoteToProto(n))
+	})
+}
+
 func (s *noteServiceServer) GetNote(c context.Context, noteRequest *pb.GetNoteRequest) (*pb.NoteResponse, error) {
 	id := noteRequest.GetId()
 
 	opts := models.GetNoteOptions{
 		IncludeRevisions:   noteRequest.GetIncludeRevisions(),
 		IncludeAttachments: noteRequest.GetIncludeAttachments(),
+		IncludeDeleted:     noteRequest.GetIncludeDeleted(),
+		RevisionsLimit:     int(noteRequest.GetRevisionsLimit()),
 	}
 	note, err := s.db.ViewNote(c, id, opts)
 
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "unable to fetch from db: %s", err.Error())
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "note not found")
+		}
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "unable to fetch from db: %v", err)
 	}
 
 	return &pb.NoteResponse{
@@ -140,25 +236,216 @@ func (s *noteServiceServer) GetNote(c context.Context, noteRequest *pb.GetNoteRe
 }
 
 func (s *noteServiceServer) DeleteNote(c context.Context, noteRequest *pb.DeleteNoteRequest) (*pb.DeleteNoteResponse, error) {
-	id := noteRequest.GetNoteId()
-	val, err := s.db.DeleteNote(c, id, true)
+	in := utils.ProtoToDeleteNoteInput(noteRequest)
+	in.DeletedBy = callerOr(c, noteRequest.GetUser())
+	val, err := s.db.DeleteNote(c, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteNoteResponse{
+		Success: val,
+	}, nil
+}
+
+// maxMoveNotes caps MoveNotes, which holds the write lock for the whole move.
+const maxMoveNotes = 500
+
+func (s *noteServiceServer) MoveNotes(c context.Context, req *pb.MoveNotesRequest) (*pb.MoveNotesResponse, error) {
+	if len(req.GetNoteIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "note_ids is required")
+	}
+	if len(req.GetNoteIds()) > maxMoveNotes {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d notes can be moved at once", maxMoveNotes)
+	}
+	notes, err := s.db.MoveNotes(c, models.MoveNotesInput{
+		NoteIDs:   req.GetNoteIds(),
+		ProjectID: utils.NilIfEmpty(req.GetTargetProjectId()),
+		MovedBy:   callerOr(c, req.GetUser()),
+	})
 	if err != nil {
 		return nil, err
 	}
+	protoNotes := make([]*pb.Note, 0, len(notes))
+	for _, note := range notes {
+		protoNotes = append(protoNotes, utils.NoteToProto(note))
+	}
+	return &pb.MoveNotesResponse{Notes: protoNotes}, nil
+}
 
+func (s *noteServiceServer) ListTrash(c context.Context, req *pb.ListTrashRequest) (*pb.ListNotesResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "the input request was empty")
+	}
+	notes, token, err := s.db.ListNotes(c, utils.ProtoToTrashFilter(req))
+	if err != nil {
+		return nil, err
+	}
+
+	protoNotes := make([]*pb.Note, 0, len(notes))
+	for _, note := range notes {
+		protoNotes = append(protoNotes, utils.NoteToProto(note))
+	}
+	return &pb.ListNotesResponse{
+		Notes:         protoNotes,
+		NextPageToken: token,
+	}, nil
+}
+
+func (s *noteServiceServer) RestoreNote(c context.Context, req *pb.RestoreNoteRequest) (*pb.NoteResponse, error) {
+	if req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+	note, err := s.db.RestoreNote(c, req.GetNoteId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.NoteResponse{
+		Note: utils.NoteToProto(*note),
+	}, nil
+}
+
+func (s *noteServiceServer) PurgeNote(c context.Context, req *pb.PurgeNoteRequest) (*pb.DeleteNoteResponse, error) {
+	if req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+	val, err := s.db.PurgeNote(c, req.GetNoteId())
+	if err != nil {
+		return nil, err
+	}
 	return &pb.DeleteNoteResponse{
 		Success: val,
 	}, nil
 }
 
-func NewGrpcServer(addr int) *GrpcServer {
+func (s *noteServiceServer) ListNoteRevisions(c context.Context, req *pb.ListNoteRevisionsRequest) (*pb.ListNoteRevisionsResponse, error) {
+	if req == nil || req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+
+	revisions, token, err := s.db.ListNoteRevisions(c, utils.ProtoToListNoteRevisionsFilter(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ListNoteRevisionsResponse{
+		Revisions:     utils.RevisionsToProto(revisions),
+		NextPageToken: token,
+	}, nil
+}
+
+func (s *noteServiceServer) RestoreNoteRevision(c context.Context, req *pb.RestoreNoteRevisionRequest) (*pb.NoteResponse, error) {
+	if req == nil || req.GetNoteId() == "" || req.GetRevisionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id and revision_id are required")
+	}
+
+	in := utils.ProtoToRestoreNoteRevisionInput(req)
+	in.Editor = callerOr(c, req.GetUser())
+	note, err := s.db.RestoreNoteRevision(c, in)
+	if err != nil {
+		return nil, updateErrorToStatus(err)
+	}
+	if note == nil {
+		return nil, status.Error(codes.NotFound, "note not found")
+	}
+	return &pb.NoteResponse{
+		Note: utils.NoteToProto(*note),
+	}, nil
+}
+
+func (s *noteServiceServer) DiffNoteRevisions(c context.Context, req *pb.DiffNoteRevisionsRequest) (*pb.DiffNoteRevisionsResponse, error) {
+	if req == nil || req.GetNoteId() == "" || req.GetFromRevisionId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id and from_revision_id are required")
+	}
+	contextSize := 3
+	if req.Context != nil {
+		if req.GetContext() < 0 {
+			return nil, status.Error(codes.InvalidArgument, "context must not be negative")
+		}
+		contextSize = int(req.GetContext())
+	}
+
+	from, err := s.db.GetNoteRevision(c, req.GetNoteId(), req.GetFromRevisionId())
+	if err != nil {
+		return nil, err
+	}
+
+	var toTitle, toContent, toName string
+	if req.GetToRevisionId() != "" {
+		to, err := s.db.GetNoteRevision(c, req.GetNoteId(), req.GetToRevisionId())
+		if err != nil {
+			return nil, err
+		}
+		toTitle, toContent, toName = to.Title, to.Content, to.ID
+	} else {
+		note, err := s.db.ViewNote(c, req.GetNoteId(), models.GetNoteOptions{})
+		if err != nil {
+			return nil, err
+		}
+		toTitle, toName = note.Title, "current"
+		if note.Content != nil {
+			toContent = *note.Content
+		}
+	}
+
+	granularity := utils.ProtoToDiffGranularity(req.GetGranularity())
+	resp := &pb.DiffNoteRevisionsResponse{
+		TitleHunks:   utils.DiffHunksToProto(diff.Compute(from.Title, toTitle, diff.Word, contextSize)),
+		ContentHunks: utils.DiffHunksToProto(diff.Compute(from.Content, toContent, granularity, contextSize)),
+	}
+	if req.GetIncludeUnified() {
+		resp.UnifiedDiff = diff.Unified("a/"+from.ID, "b/"+toName, from.Content, toContent, contextSize)
+	}
+	return resp, nil
+}
+
+// updateErrorToStatus keeps status errors raised by the store (e.g. a failed
+// if_match_updated_at check) and maps everything else.
+func updateErrorToStatus(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return status.Error(codes.NotFound, "note not found")
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Errorf(codes.Internal, "failed to update note: %v", err)
+}
+
+// NewGrpcServer sets up the gRPC server. It fails when authentication is
+// misconfigured rather than starting a server that trusts every caller.
+func NewGrpcServer(addr int) (*GrpcServer, error) {
+	opts, err := authServerOptions()
+	if err != nil {
+		return nil, err
+	}
 
 	newAddr := flag.Int("port", addr, "The server port")
 	return &GrpcServer{
 		Addr:         fmt.Sprintf(":%d", *newAddr),
-		grpcServer:   grpc.NewServer(),
+		grpcServer:   grpc.NewServer(opts...),
 		healthServer: health.NewServer(),
+	}, nil
+}
+
+// authServerOptions installs the JWT interceptors. Running without them has
+// to be asked for explicitly with NOTES_AUTH_DISABLED=true, in which case the
+// actors sent in requests are trusted as before.
+func authServerOptions() ([]grpc.ServerOption, error) {
+	verifier, err := auth.VerifierFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure authentication: %w", err)
+	}
+	if verifier == nil {
+		if os.Getenv("NOTES_AUTH_DISABLED") != "true" {
+			return nil, errors.New("no JWT key configured: set NOTES_JWT_JWKS_FILE or NOTES_JWT_HMAC_SECRET, or NOTES_AUTH_DISABLED=true for local development")
+		}
+		log.Println("authentication is disabled, request actors are trusted")
+		return nil, nil
 	}
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(verifier)),
+		grpc.ChainStreamInterceptor(auth.StreamServerInterceptor(verifier)),
+	}, nil
 }
 
 func (g *GrpcServer) Run(in chan<- bool) {
@@ -167,7 +454,22 @@ func (g *GrpcServer) Run(in chan<- bool) {
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	pb.RegisterNoteServiceServer(g.grpcServer, NewNoteServiceServer())
+	svc := NewNoteServiceServer()
+	pb.RegisterNoteServiceServer(g.grpcServer, svc)
+
+	db := database.GetDb()
+	db.IdempotencyTTL = envDuration("NOTES_IDEMPOTENCY_TTL", 24*time.Hour)
+
+	jobs, stop := context.WithCancel(context.Background())
+	g.stopJobs = stop
+	go db.RunTrashPurger(jobs,
+		envDuration("NOTES_TRASH_RETENTION", 30*24*time.Hour),
+		envDuration("NOTES_TRASH_PURGE_INTERVAL", time.Hour))
+	go db.RunBlobCollector(jobs,
+		envDuration("NOTES_BLOB_GC_GRACE", 24*time.Hour),
+		envDuration("NOTES_BLOB_GC_INTERVAL", time.Hour),
+		svc.blobs.Delete)
+	go db.RunNoteEventPruner(jobs, svc.eventRetention, time.Hour)
 
 	grpc_health_v1.RegisterHealthServer(g.grpcServer, g.healthServer)
 	g.healthServer.SetServingStatus("notes-grpc-service", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -182,6 +484,47 @@ func (g *GrpcServer) Run(in chan<- bool) {
 
 func (g *GrpcServer) End(out <-chan bool) {
 	log.Println("🛑 Stopping gRPC server")
+	if g.stopJobs != nil {
+		g.stopJobs()
+	}
 	g.grpcServer.GracefulStop()
 	<-out
 }
+
+// callerOr returns the authenticated caller, or the actor sent in the request
+// when authentication is disabled. Profile fields missing from the token are
+// taken from the request if it names the same actor.
+func callerOr(ctx context.Context, sent *pb.ActorRef) *models.Actor {
+	identity, ok := auth.FromContext(ctx)
+	if !ok {
+		if sent == nil {
+			return nil
+		}
+		actor := utils.ProtoToActorModel(sent)
+		return &actor
+	}
+	caller := identity.Actor
+	if sent != nil && sent.GetId() == caller.ID {
+		if caller.DisplayName == nil {
+			caller.DisplayName = sent.DisplayName
+		}
+		if caller.AvatarURL == nil {
+			caller.AvatarURL = sent.AvatarUrl
+		}
+	}
+	return &caller
+}
+
+// envDuration reads a time.Duration such as "720h" from the environment.
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		log.Printf("invalid %s=%q, using %s", key, v, fallback)
+		return fallback
+	}
+	return d
+}
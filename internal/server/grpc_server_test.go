@@ -2,17 +2,28 @@ package server_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"sync"
 	"testing"
 	"time"
 
+	"dovakin0007.com/notes-grpc/internal/auth"
 	"dovakin0007.com/notes-grpc/internal/models"
 	"dovakin0007.com/notes-grpc/internal/server"
 	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -22,11 +33,34 @@ const bufSize = 1024 * 1024
 
 type mockStore struct {
 	createdNote *models.Note
+	createIn    models.CreateNoteInput
 	createErr   error
+	roleErr     error // returned by CheckNoteRole
+	duplicates  []models.DuplicateNote
 	viewErr     error
+	revisions   []models.NoteRevision
+	attachments map[string]models.Attachment
+	streamNotes []models.Note
+	grants      map[string][]models.Grant
+	projects    map[string]models.Project
+	moved       *models.MoveNotesInput
+	tagCounts   []models.TagCount
+	listNotes   []models.Note
+	merged      *models.MergeTagsInput
+	titles      []models.TitleMatch
+	suggestions []models.Suggestion
+	listFilter  *models.ListNotesFilter
+	saved       map[string]models.SavedSearch
+	related     []models.RelatedNote
+	relatedArgs []interface{}
+
+	eventsMu sync.Mutex
+	events   []models.NoteEvent
+	wake     chan struct{}
 }
 
 func (m *mockStore) CreateNote(ctx context.Context, in models.CreateNoteInput) (*models.Note, error) {
+	m.createIn = in
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
@@ -41,6 +75,8 @@ func (m *mockStore) CreateNote(ctx context.Context, in models.CreateNoteInput) (
 		Tags:      in.Tags,
 		CreatedAt: now,
 		UpdatedAt: now,
+
+		Duplicates: m.duplicates,
 	}
 	m.createdNote = n
 	return n, nil
@@ -52,7 +88,22 @@ func (m *mockStore) UpdateNote(ctx context.Context, in models.UpdateNoteInput) (
 }
 
 func (m *mockStore) ListNotes(ctx context.Context, in models.ListNotesFilter) ([]models.Note, string, error) {
-	return nil, "", nil
+	m.listFilter = &in
+	return m.listNotes, "", nil
+}
+
+func (m *mockStore) CountNotes(ctx context.Context, in models.ListNotesFilter) (int64, error) {
+	m.listFilter = &in
+	return int64(len(m.listNotes)), nil
+}
+
+func (m *mockStore) StreamNotes(ctx context.Context, in models.ListNotesFilter, fn func(models.Note) error) error {
+	for _, n := range m.streamNotes {
+		if err := fn(n); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (m *mockStore) ViewNote(ctx context.Context, id string, opts models.GetNoteOptions) (*models.Note, error) {
@@ -76,10 +127,314 @@ func (m *mockStore) ViewNote(ctx context.Context, id string, opts models.GetNote
 	}, nil
 }
 
-func (m *mockStore) DeleteNote(ctx context.Context, id string, hard bool) (bool, error) {
+func (m *mockStore) DeleteNote(ctx context.Context, in models.DeleteNoteInput) (bool, error) {
 	return true, nil
 }
 
+func (m *mockStore) RestoreNote(ctx context.Context, id string) (*models.Note, error) {
+	return m.ViewNote(ctx, id, models.GetNoteOptions{})
+}
+
+func (m *mockStore) PurgeNote(ctx context.Context, id string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStore) RestoreNoteRevision(ctx context.Context, in models.RestoreNoteRevisionInput) (*models.Note, error) {
+	return nil, status.Error(codes.FailedPrecondition, "note was modified since if_match_updated_at")
+}
+
+func (m *mockStore) GetNoteRevision(ctx context.Context, noteID, revisionID string) (*models.NoteRevision, error) {
+	for _, r := range m.revisions {
+		if r.NoteID == noteID && r.ID == revisionID {
+			return &r, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "revision not found")
+}
+
+func (m *mockStore) CheckNoteRole(ctx context.Context, noteID string, min models.Role) error {
+	return m.roleErr
+}
+
+func (m *mockStore) CreateAttachment(ctx context.Context, a models.Attachment) (*models.Attachment, error) {
+	if m.attachments == nil {
+		m.attachments = map[string]models.Attachment{}
+	}
+	a.UploadedAt = time.Now()
+	m.attachments[a.ID] = a
+	return &a, nil
+}
+
+func (m *mockStore) ClaimBlob(ctx context.Context, key string, size int64) error {
+	return nil
+}
+
+func (m *mockStore) GetAttachment(ctx context.Context, id string) (*models.Attachment, error) {
+	a, ok := m.attachments[id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "attachment not found")
+	}
+	return &a, nil
+}
+
+// emit appends an event to the fake outbox and wakes watchers.
+func (m *mockStore) emit(ev models.NoteEvent) {
+	m.eventsMu.Lock()
+	ev.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, ev)
+	m.eventsMu.Unlock()
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *mockStore) ListNoteEvents(ctx context.Context, f models.NoteEventFilter) ([]models.NoteEvent, error) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+	var out []models.NoteEvent
+	for _, ev := range m.events {
+		if ev.ID <= f.AfterID || (f.ProjectID != nil && (ev.ProjectID == nil || *ev.ProjectID != *f.ProjectID)) {
+			continue
+		}
+		out = append(out, ev)
+	}
+	return out, nil
+}
+
+func (m *mockStore) LatestNoteEventID(ctx context.Context) (int64, error) {
+	m.eventsMu.Lock()
+	defer m.eventsMu.Unlock()
+	return int64(len(m.events)), nil
+}
+
+func (m *mockStore) SubscribeNoteEvents() (<-chan struct{}, func()) {
+	return m.wake, func() {}
+}
+
+func (m *mockStore) MoveNotes(ctx context.Context, in models.MoveNotesInput) ([]models.Note, error) {
+	m.moved = &in
+	notes := make([]models.Note, 0, len(in.NoteIDs))
+	for _, id := range in.NoteIDs {
+		notes = append(notes, models.Note{ID: id, ProjectID: in.ProjectID, Title: "moved"})
+	}
+	return notes, nil
+}
+
+// The batch methods fail items whose note id is "missing".
+func (m *mockStore) BatchGetNotes(ctx context.Context, ids []string, opts models.GetNoteOptions, partial bool) ([]models.BatchResult, error) {
+	return mockBatch(len(ids), partial, func(i int) (*models.Note, error) {
+		return m.batchNote(ids[i])
+	})
+}
+
+func (m *mockStore) BatchCreateNotes(ctx context.Context, ins []models.CreateNoteInput, partial bool) ([]models.BatchResult, error) {
+	return mockBatch(len(ins), partial, func(i int) (*models.Note, error) {
+		return m.CreateNote(ctx, ins[i])
+	})
+}
+
+func (m *mockStore) BatchUpdateNotes(ctx context.Context, ins []models.UpdateNoteInput, partial bool) ([]models.BatchResult, error) {
+	return mockBatch(len(ins), partial, func(i int) (*models.Note, error) {
+		return m.batchNote(ins[i].NoteID)
+	})
+}
+
+func (m *mockStore) BatchDeleteNotes(ctx context.Context, ins []models.DeleteNoteInput, partial bool) ([]models.BatchResult, error) {
+	return mockBatch(len(ins), partial, func(i int) (*models.Note, error) {
+		_, err := m.batchNote(ins[i].NoteID)
+		return nil, err
+	})
+}
+
+func (m *mockStore) batchNote(id string) (*models.Note, error) {
+	if id == "missing" {
+		return nil, status.Error(codes.NotFound, "note not found")
+	}
+	return m.ViewNote(context.Background(), id, models.GetNoteOptions{})
+}
+
+func mockBatch(n int, partial bool, item func(i int) (*models.Note, error)) ([]models.BatchResult, error) {
+	results := make([]models.BatchResult, n)
+	for i := range results {
+		note, err := item(i)
+		if err != nil && !partial {
+			return nil, err
+		}
+		results[i] = models.BatchResult{Note: note, Err: err}
+	}
+	return results, nil
+}
+
+func (m *mockStore) ListTags(ctx context.Context, f models.ListTagsFilter) ([]models.TagCount, error) {
+	return m.tagCounts, nil
+}
+
+func (m *mockStore) MergeTags(ctx context.Context, in models.MergeTagsInput) (int, error) {
+	m.merged = &in
+	return 2, nil
+}
+
+func (m *mockStore) DeleteTag(ctx context.Context, projectID *string, tag string) (int, error) {
+	return 1, nil
+}
+
+func (m *mockStore) CreateSavedSearch(ctx context.Context, in models.CreateSavedSearchInput) (*models.SavedSearch, error) {
+	if m.saved == nil {
+		m.saved = map[string]models.SavedSearch{}
+	}
+	now := time.Now()
+	s := models.SavedSearch{ID: in.ID, Name: in.Name, OwnerID: in.Owner.ID, Request: in.Request, Owner: &in.Owner, CreatedAt: now, UpdatedAt: now}
+	m.saved[s.ID] = s
+	return &s, nil
+}
+
+func (m *mockStore) GetSavedSearch(ctx context.Context, id string) (*models.SavedSearch, error) {
+	s, ok := m.saved[id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "saved search not found")
+	}
+	return &s, nil
+}
+
+func (m *mockStore) ListSavedSearches(ctx context.Context, f models.ListSavedSearchesFilter) ([]models.SavedSearch, string, error) {
+	out := []models.SavedSearch{}
+	for _, s := range m.saved {
+		out = append(out, s)
+	}
+	return out, "", nil
+}
+
+func (m *mockStore) DeleteSavedSearch(ctx context.Context, id string) (bool, error) {
+	_, ok := m.saved[id]
+	delete(m.saved, id)
+	return ok, nil
+}
+
+func (m *mockStore) ShareSavedSearch(ctx context.Context, id string, g models.Grant) (*models.Grant, error) {
+	return &g, nil
+}
+
+func (m *mockStore) UnshareSavedSearch(ctx context.Context, id string, p models.Principal) (bool, error) {
+	return true, nil
+}
+
+func (m *mockStore) SearchTitles(ctx context.Context, f models.SearchTitlesFilter) ([]models.TitleMatch, []models.Suggestion, error) {
+	return m.titles, m.suggestions, nil
+}
+
+func (m *mockStore) GetRelatedNotes(ctx context.Context, noteID string, limit int) ([]models.RelatedNote, error) {
+	m.relatedArgs = []interface{}{noteID, limit}
+	return m.related, nil
+}
+
+func (m *mockStore) CreateProject(ctx context.Context, in models.CreateProjectInput) (*models.Project, error) {
+	if m.projects == nil {
+		m.projects = map[string]models.Project{}
+	}
+	if _, ok := m.projects[in.ID]; ok {
+		return nil, status.Error(codes.AlreadyExists, "project already exists")
+	}
+	now := time.Now()
+	p := models.Project{ID: in.ID, Name: in.Name, Description: in.Description, OwnerID: in.Owner.ID, Owner: &in.Owner, CreatedAt: now, UpdatedAt: now}
+	m.projects[p.ID] = p
+	return &p, nil
+}
+
+func (m *mockStore) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	p, ok := m.projects[id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return &p, nil
+}
+
+func (m *mockStore) ListProjects(ctx context.Context, f models.ListProjectsFilter) ([]models.Project, string, error) {
+	var out []models.Project
+	for _, p := range m.projects {
+		if p.ArchivedAt == nil || f.IncludeArchived {
+			out = append(out, p)
+		}
+	}
+	return out, "", nil
+}
+
+func (m *mockStore) UpdateProject(ctx context.Context, in models.UpdateProjectInput) (*models.Project, error) {
+	p, ok := m.projects[in.ProjectID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	if in.Name != nil {
+		p.Name = *in.Name
+	}
+	if in.Description != nil {
+		p.Description = in.Description
+	}
+	m.projects[p.ID] = p
+	return &p, nil
+}
+
+func (m *mockStore) ArchiveProject(ctx context.Context, id string) (*models.Project, error) {
+	p, ok := m.projects[id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	if p.ArchivedAt == nil {
+		now := time.Now()
+		p.ArchivedAt = &now
+	}
+	m.projects[id] = p
+	return &p, nil
+}
+
+func (m *mockStore) ShareNote(ctx context.Context, noteID string, g models.Grant) (*models.Grant, error) {
+	return m.share("note:"+noteID, g), nil
+}
+
+func (m *mockStore) UnshareNote(ctx context.Context, noteID string, p models.Principal) (bool, error) {
+	return m.unshare("note:"+noteID, p), nil
+}
+
+func (m *mockStore) ListNoteGrants(ctx context.Context, noteID string) ([]models.Grant, error) {
+	return m.grants["note:"+noteID], nil
+}
+
+func (m *mockStore) ShareProject(ctx context.Context, projectID string, g models.Grant) (*models.Grant, error) {
+	return m.share("project:"+projectID, g), nil
+}
+
+func (m *mockStore) UnshareProject(ctx context.Context, projectID string, p models.Principal) (bool, error) {
+	return m.unshare("project:"+projectID, p), nil
+}
+
+func (m *mockStore) ListProjectGrants(ctx context.Context, projectID string) ([]models.Grant, error) {
+	return m.grants["project:"+projectID], nil
+}
+
+func (m *mockStore) share(key string, g models.Grant) *models.Grant {
+	if m.grants == nil {
+		m.grants = map[string][]models.Grant{}
+	}
+	g.CreatedAt = time.Now()
+	m.unshare(key, g.Principal)
+	m.grants[key] = append(m.grants[key], g)
+	return &g
+}
+
+func (m *mockStore) unshare(key string, p models.Principal) bool {
+	for i, g := range m.grants[key] {
+		if g.Principal == p {
+			m.grants[key] = append(m.grants[key][:i], m.grants[key][i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (m *mockStore) ListNoteRevisions(ctx context.Context, in models.ListNoteRevisionsFilter) ([]models.NoteRevision, string, error) {
+	return m.revisions, "", nil
+}
+
 func dialerWithServer(t *testing.T, s *grpc.Server) func(context.Context, string) (net.Conn, error) {
 	l := bufconn.Listen(bufSize)
 	go func() {
@@ -91,6 +446,25 @@ func dialerWithServer(t *testing.T, s *grpc.Server) func(context.Context, string
 		return l.Dial()
 	}
 }
+
+// newTestClient serves mock over bufconn and returns a client for it.
+func newTestClient(t *testing.T, mock *mockStore) pb.NoteServiceClient {
+	t.Helper()
+	srv := grpc.NewServer()
+	pb.RegisterNoteServiceServer(srv, server.NewNoteServiceServerWithStore(mock))
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(dialerWithServer(t, srv)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewNoteServiceClient(conn)
+}
+
 func buildCreateNoteRequest() *pb.CreateNoteRequest {
 	return &pb.CreateNoteRequest{
 		ProjectId: ptrString("proj-123"),
@@ -151,6 +525,118 @@ func TestCreateAndGetNote(t *testing.T) {
 	assert.Equal(t, noteID, getResp.GetNote().GetId())
 }
 
+func TestListNoteRevisions(t *testing.T) {
+	now := time.Now()
+	mock := &mockStore{
+		revisions: []models.NoteRevision{
+			{ID: "rev-2", NoteID: "note-1", Title: "v2", Content: "second", EditorID: "user-2", EditedAt: now, Editor: &models.Actor{ID: "user-2", DisplayName: ptrString("Bob")}},
+			{ID: "rev-1", NoteID: "note-1", Title: "v1", Content: "first", EditorID: "user-1", EditedAt: now.Add(-time.Minute)},
+		},
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterNoteServiceServer(srv, server.NewNoteServiceServerWithStore(mock))
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(dialerWithServer(t, srv)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewNoteServiceClient(conn)
+
+	_, err = client.ListNoteRevisions(ctx, &pb.ListNoteRevisionsRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := client.ListNoteRevisions(ctx, &pb.ListNoteRevisionsRequest{NoteId: "note-1"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetRevisions(), 2)
+	assert.Equal(t, "rev-2", resp.GetRevisions()[0].GetId())
+	assert.Equal(t, "Bob", resp.GetRevisions()[0].GetEditor().GetDisplayName())
+}
+
+func TestRestoreNoteRevision_KeepsStoreStatus(t *testing.T) {
+	srv := grpc.NewServer()
+	pb.RegisterNoteServiceServer(srv, server.NewNoteServiceServerWithStore(&mockStore{}))
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(dialerWithServer(t, srv)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewNoteServiceClient(conn)
+
+	_, err = client.RestoreNoteRevision(ctx, &pb.RestoreNoteRevisionRequest{NoteId: "note-1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.RestoreNoteRevision(ctx, &pb.RestoreNoteRevisionRequest{
+		NoteId:           "note-1",
+		RevisionId:       "rev-1",
+		IfMatchUpdatedAt: timestamppb.Now(),
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestDiffNoteRevisions(t *testing.T) {
+	mock := &mockStore{
+		revisions: []models.NoteRevision{
+			{ID: "rev-1", NoteID: "note-1", Title: "Old note", Content: "line one\nline two\n"},
+			{ID: "rev-2", NoteID: "note-1", Title: "Existing note", Content: "line one\nline 2\n"},
+		},
+	}
+
+	srv := grpc.NewServer()
+	pb.RegisterNoteServiceServer(srv, server.NewNoteServiceServerWithStore(mock))
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(
+		ctx,
+		"bufnet",
+		grpc.WithContextDialer(dialerWithServer(t, srv)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewNoteServiceClient(conn)
+
+	resp, err := client.DiffNoteRevisions(ctx, &pb.DiffNoteRevisionsRequest{
+		NoteId:         "note-1",
+		FromRevisionId: "rev-1",
+		ToRevisionId:   ptrString("rev-2"),
+		IncludeUnified: true,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.GetContentHunks(), 1)
+	assert.Equal(t, []pb.DiffSpan_Op{pb.DiffSpan_OP_EQUAL, pb.DiffSpan_OP_DELETE, pb.DiffSpan_OP_INSERT},
+		[]pb.DiffSpan_Op{
+			resp.GetContentHunks()[0].GetSpans()[0].GetOp(),
+			resp.GetContentHunks()[0].GetSpans()[1].GetOp(),
+			resp.GetContentHunks()[0].GetSpans()[2].GetOp(),
+		})
+	assert.Contains(t, resp.GetUnifiedDiff(), "-line two\n+line 2\n")
+	assert.Len(t, resp.GetTitleHunks(), 1)
+
+	// Without to_revision_id the revision is compared with the current note.
+	resp, err = client.DiffNoteRevisions(ctx, &pb.DiffNoteRevisionsRequest{NoteId: "note-1", FromRevisionId: "rev-2"})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetTitleHunks())
+	assert.Len(t, resp.GetContentHunks(), 1)
+	assert.Empty(t, resp.GetUnifiedDiff())
+
+	_, err = client.DiffNoteRevisions(ctx, &pb.DiffNoteRevisionsRequest{NoteId: "note-1", FromRevisionId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
 func ptrString(s string) *string { return &s }
 
 func ptrBool(b bool) *bool { return &b }
@@ -158,3 +644,163 @@ func ptrBool(b bool) *bool { return &b }
 func ptrInt64(i int64) *int64 {
 	return &i
 }
+
+func TestStreamNotes(t *testing.T) {
+	now := time.Now()
+	mock := &mockStore{}
+	for i := 0; i < 250; i++ {
+		mock.streamNotes = append(mock.streamNotes, models.Note{
+			ID: fmt.Sprintf("note-%03d", i), AuthorID: "user-1", Title: "n", CreatedAt: now, UpdatedAt: now,
+		})
+	}
+	client := newTestClient(t, mock)
+
+	stream, err := client.StreamNotes(context.Background(), &pb.ListNotesRequest{PageSize: 10})
+	require.NoError(t, err)
+	var ids []string
+	for {
+		n, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, n.GetId())
+	}
+	// Not bound by the ListNotes page size clamp.
+	require.Len(t, ids, 250)
+	require.Equal(t, "note-249", ids[249])
+}
+
+func TestGetNote_Errors(t *testing.T) {
+	mock := &mockStore{}
+	client := newTestClient(t, mock)
+
+	for _, c := range []struct {
+		err  error
+		code codes.Code
+	}{
+		{status.Error(codes.NotFound, "note not found"), codes.NotFound},
+		{status.Error(codes.PermissionDenied, "no access"), codes.PermissionDenied},
+		{sql.ErrNoRows, codes.NotFound},
+		{errors.New("connection reset"), codes.Internal},
+	} {
+		mock.viewErr = c.err
+		_, err := client.GetNote(context.Background(), &pb.GetNoteRequest{Id: "note-1"})
+		require.Equal(t, c.code, status.Code(err), c.err.Error())
+	}
+}
+
+func TestCreateNote_AuthorComesFromToken(t *testing.T) {
+	mock := &mockStore{}
+	verifier := auth.NewHMACVerifier([]byte("s3cret"))
+	srv := grpc.NewServer(grpc.ChainUnaryInterceptor(auth.UnaryServerInterceptor(verifier)))
+	pb.RegisterNoteServiceServer(srv, server.NewNoteServiceServerWithStore(mock))
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(dialerWithServer(t, srv)),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewNoteServiceClient(conn)
+
+	req := buildCreateNoteRequest()
+	req.Author = &pb.ActorRef{Id: "someone-else", DisplayName: ptrString("Mallory")}
+
+	_, err = client.CreateNote(context.Background(), req)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("s3cret"))
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+
+	_, err = client.CreateNote(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, "user-1", mock.createdNote.AuthorID)
+}
+
+func TestNewGrpcServer_ReportsAuthMisconfiguration(t *testing.T) {
+	t.Setenv("NOTES_AUTH_DISABLED", "")
+	t.Setenv("NOTES_JWT_JWKS_FILE", "")
+	t.Setenv("NOTES_JWT_HMAC_SECRET", "")
+
+	_, err := server.NewGrpcServer(0)
+	require.ErrorContains(t, err, "no JWT key configured")
+
+	t.Setenv("NOTES_JWT_JWKS_FILE", "/nonexistent/jwks.json")
+	t.Setenv("NOTES_JWT_HMAC_SECRET", "s3cret")
+	_, err = server.NewGrpcServer(0)
+	require.ErrorContains(t, err, "failed to configure authentication")
+}
+
+func TestCreateNote_Duplicates(t *testing.T) {
+	mock := &mockStore{
+		duplicates: []models.DuplicateNote{{Note: models.Note{ID: "note-old", Title: "Checkout outage"}, Similarity: 0.9}},
+	}
+	client := newTestClient(t, mock)
+
+	resp, err := client.CreateNote(context.Background(), buildCreateNoteRequest())
+	require.NoError(t, err)
+	require.False(t, mock.createIn.RejectDuplicates)
+	require.Len(t, resp.GetDuplicates(), 1)
+	require.Equal(t, "note-old", resp.GetDuplicates()[0].GetNote().GetId())
+	require.Equal(t, float32(0.9), resp.GetDuplicates()[0].GetSimilarity())
+
+	mock.createErr = status.Error(codes.AlreadyExists, "note duplicates note-old")
+	req := buildCreateNoteRequest()
+	req.RejectDuplicates = true
+	_, err = client.CreateNote(context.Background(), req)
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	require.True(t, mock.createIn.RejectDuplicates)
+}
+
+func TestMoveNotes(t *testing.T) {
+	mock := &mockStore{}
+	client := newTestClient(t, mock)
+
+	resp, err := client.MoveNotes(context.Background(), &pb.MoveNotesRequest{
+		NoteIds:         []string{"n1", "n2"},
+		TargetProjectId: ptrString("p2"),
+		User:            &pb.ActorRef{Id: "user-1"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.GetNotes(), 2)
+	require.Equal(t, "p2", resp.GetNotes()[1].GetProjectId())
+	require.Equal(t, []string{"n1", "n2"}, mock.moved.NoteIDs)
+	require.Equal(t, "user-1", mock.moved.MovedBy.ID)
+
+	_, err = client.MoveNotes(context.Background(), &pb.MoveNotesRequest{TargetProjectId: ptrString("p2")})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListNotes_SearchResults(t *testing.T) {
+	mock := &mockStore{listNotes: []models.Note{
+		{ID: "n1", Title: "Deploy", Match: &models.NoteMatch{
+			Rank:       0.5,
+			Snippet:    "Deploy\nhow to deploy",
+			Highlights: []models.TextRange{{Start: 0, End: 6}, {Start: 14, End: 20}},
+		}},
+		{ID: "n2", Title: "Other", Match: &models.NoteMatch{Rank: 0.1, Snippet: "Other"}},
+	}}
+	client := newTestClient(t, mock)
+
+	resp, err := client.ListNotes(context.Background(), &pb.ListNotesRequest{Query: ptrString("deploy"), SortBy: ptrString("relevance")})
+	require.NoError(t, err)
+	require.Len(t, resp.GetResults(), 2)
+	r := resp.GetResults()[0]
+	require.Equal(t, "n1", r.GetNoteId())
+	require.Equal(t, float32(0.5), r.GetRank())
+	require.Len(t, r.GetHighlights(), 2)
+	require.Equal(t, int32(14), r.GetHighlights()[1].GetStart())
+	require.Empty(t, resp.GetResults()[1].GetHighlights())
+
+	mock.listNotes = []models.Note{{ID: "n1"}}
+	resp, err = client.ListNotes(context.Background(), &pb.ListNotesRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.GetResults())
+}
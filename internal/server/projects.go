@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *noteServiceServer) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.ProjectResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	owner := callerOr(ctx, req.GetOwner())
+	if owner == nil || owner.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner is required")
+	}
+	in := utils.ToCreateProjectInput(req)
+	in.Owner = *owner
+	p, err := s.db.CreateProject(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProjectResponse{Project: utils.ProjectToProto(*p)}, nil
+}
+
+func (s *noteServiceServer) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.ProjectResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	p, err := s.db.GetProject(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProjectResponse{Project: utils.ProjectToProto(*p)}, nil
+}
+
+func (s *noteServiceServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	projects, token, err := s.db.ListProjects(ctx, models.ListProjectsFilter{
+		IncludeArchived: req.GetIncludeArchived(),
+		PageSize:        int(req.GetPageSize()),
+		PageToken:       req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListProjectsResponse{
+		Projects:      utils.ProjectsToProto(projects),
+		NextPageToken: token,
+	}, nil
+}
+
+func (s *noteServiceServer) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.ProjectResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	in, err := utils.ProtoToUpdateProjectInput(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if in.Name != nil && *in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name cannot be empty")
+	}
+	p, err := s.db.UpdateProject(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProjectResponse{Project: utils.ProjectToProto(*p)}, nil
+}
+
+func (s *noteServiceServer) ArchiveProject(ctx context.Context, req *pb.ArchiveProjectRequest) (*pb.ProjectResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	p, err := s.db.ArchiveProject(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ProjectResponse{Project: utils.ProjectToProto(*p)}, nil
+}
@@ -0,0 +1,60 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func TestProjects_CreateRenameArchive(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+	ctx := context.Background()
+
+	created, err := client.CreateProject(ctx, &pb.CreateProjectRequest{
+		Id:    ptrString("security"),
+		Name:  "Security",
+		Owner: &pb.ActorRef{Id: "alice"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "security", created.GetProject().GetId())
+	require.Equal(t, "alice", created.GetProject().GetOwner().GetId())
+
+	_, err = client.CreateProject(ctx, &pb.CreateProjectRequest{Id: ptrString("security"), Name: "Again", Owner: &pb.ActorRef{Id: "alice"}})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	updated, err := client.UpdateProject(ctx, &pb.UpdateProjectRequest{
+		Id:          "security",
+		Name:        "Security team",
+		Description: "incident notes",
+		UpdateMask:  &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Security team", updated.GetProject().GetName())
+	require.Nil(t, updated.GetProject().Description)
+
+	archived, err := client.ArchiveProject(ctx, &pb.ArchiveProjectRequest{Id: "security"})
+	require.NoError(t, err)
+	require.NotNil(t, archived.GetProject().GetArchivedAt())
+
+	list, err := client.ListProjects(ctx, &pb.ListProjectsRequest{})
+	require.NoError(t, err)
+	require.Empty(t, list.GetProjects())
+	list, err = client.ListProjects(ctx, &pb.ListProjectsRequest{IncludeArchived: true})
+	require.NoError(t, err)
+	require.Len(t, list.GetProjects(), 1)
+}
+
+func TestUpdateProject_RejectsBadMask(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	_, err := client.UpdateProject(context.Background(), &pb.UpdateProjectRequest{
+		Id:         "p1",
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"owner"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/search"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *noteServiceServer) CreateSavedSearch(ctx context.Context, req *pb.CreateSavedSearchRequest) (*pb.SavedSearchResponse, error) {
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	owner := callerOr(ctx, req.GetOwner())
+	if owner == nil || owner.ID == "" {
+		return nil, status.Error(codes.InvalidArgument, "owner is required")
+	}
+	// A query that cannot run is rejected now rather than on every run.
+	if _, err := search.Parse(req.GetRequest().GetQuery()); err != nil {
+		var serr *search.Error
+		if errors.As(err, &serr) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid query at position %d: %s", serr.Pos, serr.Msg)
+		}
+		return nil, err
+	}
+	in, err := utils.ToCreateSavedSearchInput(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+	in.Owner = *owner
+	saved, err := s.db.CreateSavedSearch(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	out, err := utils.SavedSearchToProto(*saved)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding saved search: %v", err)
+	}
+	return &pb.SavedSearchResponse{SavedSearch: out}, nil
+}
+
+func (s *noteServiceServer) ListSavedSearches(ctx context.Context, req *pb.ListSavedSearchesRequest) (*pb.ListSavedSearchesResponse, error) {
+	searches, token, err := s.db.ListSavedSearches(ctx, models.ListSavedSearchesFilter{
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, err := utils.SavedSearchesToProto(searches)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding saved search: %v", err)
+	}
+	return &pb.ListSavedSearchesResponse{SavedSearches: out, NextPageToken: token}, nil
+}
+
+// RunSavedSearch lists notes exactly like ListNotes with the saved request,
+// so relative dates and the caller's access are evaluated at run time.
+func (s *noteServiceServer) RunSavedSearch(ctx context.Context, req *pb.RunSavedSearchRequest) (*pb.RunSavedSearchResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	saved, err := s.db.GetSavedSearch(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	listReq, err := utils.SavedListNotesRequest(*saved)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "decoding saved search: %v", err)
+	}
+	listReq.PageSize = req.GetPageSize()
+	listReq.PageToken = req.GetPageToken()
+
+	if req.GetCountOnly() {
+		n, err := s.db.CountNotes(ctx, utils.ProtoToListNotesFilter(listReq))
+		if err != nil {
+			return nil, err
+		}
+		return &pb.RunSavedSearchResponse{Count: n}, nil
+	}
+	resp, err := s.ListNotes(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RunSavedSearchResponse{
+		Notes:         resp.GetNotes(),
+		NextPageToken: resp.GetNextPageToken(),
+		Results:       resp.GetResults(),
+	}, nil
+}
+
+func (s *noteServiceServer) DeleteSavedSearch(ctx context.Context, req *pb.DeleteSavedSearchRequest) (*pb.DeleteSavedSearchResponse, error) {
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+	deleted, err := s.db.DeleteSavedSearch(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.DeleteSavedSearchResponse{Deleted: deleted}, nil
+}
+
+func (s *noteServiceServer) ShareSavedSearch(ctx context.Context, req *pb.ShareSavedSearchRequest) (*pb.Grant, error) {
+	if req.GetSavedSearchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "saved_search_id is required")
+	}
+	g, err := toGrant(req.GetPrincipal(), req.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.db.ShareSavedSearch(ctx, req.GetSavedSearchId(), g)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GrantToProto(*out), nil
+}
+
+func (s *noteServiceServer) UnshareSavedSearch(ctx context.Context, req *pb.UnshareSavedSearchRequest) (*pb.UnshareResponse, error) {
+	if req.GetSavedSearchId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "saved_search_id is required")
+	}
+	p, err := toPrincipal(req.GetPrincipal())
+	if err != nil {
+		return nil, err
+	}
+	removed, err := s.db.UnshareSavedSearch(ctx, req.GetSavedSearchId(), p)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnshareResponse{Removed: removed}, nil
+}
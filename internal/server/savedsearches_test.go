@@ -0,0 +1,75 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSavedSearch_CreateAndRun(t *testing.T) {
+	mock := &mockStore{listNotes: []models.Note{{ID: "n1", Title: "db outage"}, {ID: "n2", Title: "api outage"}}}
+	client := newTestClient(t, mock)
+	ctx := context.Background()
+
+	created, err := client.CreateSavedSearch(ctx, &pb.CreateSavedSearchRequest{
+		Name: "Morning incidents",
+		Request: &pb.ListNotesRequest{
+			Query:     ptrString("pinned:true tag:incident updated:>=today-7d"),
+			SortBy:    ptrString("updated_at"),
+			PageSize:  50,
+			PageToken: "stale",
+		},
+		Owner: &pb.ActorRef{Id: "alice"},
+	})
+	require.NoError(t, err)
+	saved := created.GetSavedSearch()
+	require.NotEmpty(t, saved.GetId())
+	require.Equal(t, "alice", saved.GetOwner().GetId())
+	require.Equal(t, "pinned:true tag:incident updated:>=today-7d", saved.GetRequest().GetQuery())
+	require.Zero(t, saved.GetRequest().GetPageSize())
+	require.Empty(t, saved.GetRequest().GetPageToken())
+
+	run, err := client.RunSavedSearch(ctx, &pb.RunSavedSearchRequest{Id: saved.GetId(), PageSize: 20})
+	require.NoError(t, err)
+	require.Len(t, run.GetNotes(), 2)
+	require.Equal(t, "pinned:true tag:incident updated:>=today-7d", *mock.listFilter.Query)
+	require.Equal(t, 20, mock.listFilter.PageSize)
+	require.Empty(t, mock.listFilter.PageToken)
+
+	count, err := client.RunSavedSearch(ctx, &pb.RunSavedSearchRequest{Id: saved.GetId(), CountOnly: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count.GetCount())
+	require.Empty(t, count.GetNotes())
+
+	list, err := client.ListSavedSearches(ctx, &pb.ListSavedSearchesRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.GetSavedSearches(), 1)
+	require.Equal(t, "Morning incidents", list.GetSavedSearches()[0].GetName())
+
+	deleted, err := client.DeleteSavedSearch(ctx, &pb.DeleteSavedSearchRequest{Id: saved.GetId()})
+	require.NoError(t, err)
+	require.True(t, deleted.GetDeleted())
+	_, err = client.RunSavedSearch(ctx, &pb.RunSavedSearchRequest{Id: saved.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCreateSavedSearch_Validation(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+	ctx := context.Background()
+
+	_, err := client.CreateSavedSearch(ctx, &pb.CreateSavedSearchRequest{Owner: &pb.ActorRef{Id: "alice"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateSavedSearch(ctx, &pb.CreateSavedSearchRequest{
+		Name:    "broken",
+		Request: &pb.ListNotesRequest{Query: ptrString(`deploy "release`)},
+		Owner:   &pb.ActorRef{Id: "alice"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "position 8")
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *noteServiceServer) SearchTitles(ctx context.Context, req *pb.SearchTitlesRequest) (*pb.SearchTitlesResponse, error) {
+	if strings.TrimSpace(req.GetQuery()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	matches, suggestions, err := s.db.SearchTitles(ctx, models.SearchTitlesFilter{
+		Query:     req.GetQuery(),
+		ProjectID: req.ProjectId,
+		PageSize:  int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to search titles: %v", err)
+	}
+	return &pb.SearchTitlesResponse{
+		Matches:     utils.TitleMatchesToProto(matches),
+		Suggestions: utils.SuggestionsToProto(suggestions),
+	}, nil
+}
+
+func (s *noteServiceServer) GetRelatedNotes(ctx context.Context, req *pb.GetRelatedNotesRequest) (*pb.GetRelatedNotesResponse, error) {
+	if req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit must not be negative")
+	}
+	related, err := s.db.GetRelatedNotes(ctx, req.GetNoteId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetRelatedNotesResponse{Notes: utils.RelatedNotesToProto(related)}, nil
+}
@@ -0,0 +1,57 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSearchTitles(t *testing.T) {
+	mock := &mockStore{
+		titles: []models.TitleMatch{{Note: models.Note{ID: "n1", Title: "Deployment guide"}, Similarity: 0.8}},
+		suggestions: []models.Suggestion{
+			{Text: "Deployment guide", Kind: models.SuggestionTitle, Similarity: 0.5},
+			{Text: "deploy", Kind: models.SuggestionTag, Similarity: 0.4},
+		},
+	}
+	client := newTestClient(t, mock)
+
+	resp, err := client.SearchTitles(context.Background(), &pb.SearchTitlesRequest{Query: "deplyment gude"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetMatches(), 1)
+	require.Equal(t, "n1", resp.GetMatches()[0].GetNote().GetId())
+	require.Equal(t, float32(0.8), resp.GetMatches()[0].GetSimilarity())
+	require.Len(t, resp.GetSuggestions(), 2)
+	require.Equal(t, pb.SuggestionKind_SUGGESTION_KIND_TITLE, resp.GetSuggestions()[0].GetKind())
+	require.Equal(t, pb.SuggestionKind_SUGGESTION_KIND_TAG, resp.GetSuggestions()[1].GetKind())
+
+	_, err = client.SearchTitles(context.Background(), &pb.SearchTitlesRequest{Query: "  "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetRelatedNotes(t *testing.T) {
+	mock := &mockStore{
+		related: []models.RelatedNote{
+			{Note: models.Note{ID: "n2", Title: "Checkout outage"}, Score: 4.5},
+			{Note: models.Note{ID: "n3", Title: "Payments latency"}, Score: 1.25},
+		},
+	}
+	client := newTestClient(t, mock)
+
+	resp, err := client.GetRelatedNotes(context.Background(), &pb.GetRelatedNotesRequest{NoteId: "n1", Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"n1", 5}, mock.relatedArgs)
+	require.Len(t, resp.GetNotes(), 2)
+	require.Equal(t, "n2", resp.GetNotes()[0].GetNote().GetId())
+	require.Equal(t, float32(4.5), resp.GetNotes()[0].GetScore())
+
+	_, err = client.GetRelatedNotes(context.Background(), &pb.GetRelatedNotesRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.GetRelatedNotes(context.Background(), &pb.GetRelatedNotesRequest{NoteId: "n1", Limit: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
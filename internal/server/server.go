@@ -10,16 +10,21 @@ type server[T Server] struct {
 	GrpcServer     T
 }
 
-func CreateAndStartServer() {
+// CreateAndStartServer sets up the gRPC server, registers it with consul and
+// serves until both stop. Setup errors are returned before anything starts.
+func CreateAndStartServer() error {
+	server, err := NewGrpcServer(9096)
+	if err != nil {
+		return err
+	}
+
 	service := newRegisterServer("notes-grpc", "notes-grpc-service", "localhost", uint(9096))
 	// TODO: channels are needed to make it non blocking we can remove it when there is no need
 	ch := make(chan bool, 2)
 	go service.Run(ch)
-
-	server := NewGrpcServer(9096)
 	go server.Run(ch)
 
 	<-ch
 	<-ch
-
+	return nil
 }
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *noteServiceServer) ShareNote(ctx context.Context, req *pb.ShareNoteRequest) (*pb.Grant, error) {
+	if req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+	g, err := toGrant(req.GetPrincipal(), req.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.db.ShareNote(ctx, req.GetNoteId(), g)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GrantToProto(*out), nil
+}
+
+func (s *noteServiceServer) UnshareNote(ctx context.Context, req *pb.UnshareNoteRequest) (*pb.UnshareResponse, error) {
+	if req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+	p, err := toPrincipal(req.GetPrincipal())
+	if err != nil {
+		return nil, err
+	}
+	removed, err := s.db.UnshareNote(ctx, req.GetNoteId(), p)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnshareResponse{Removed: removed}, nil
+}
+
+func (s *noteServiceServer) ListNoteGrants(ctx context.Context, req *pb.ListNoteGrantsRequest) (*pb.ListGrantsResponse, error) {
+	if req.GetNoteId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "note_id is required")
+	}
+	grants, err := s.db.ListNoteGrants(ctx, req.GetNoteId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListGrantsResponse{Grants: utils.GrantsToProto(grants)}, nil
+}
+
+func (s *noteServiceServer) ShareProject(ctx context.Context, req *pb.ShareProjectRequest) (*pb.Grant, error) {
+	if req.GetProjectId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id is required")
+	}
+	g, err := toGrant(req.GetPrincipal(), req.GetRole())
+	if err != nil {
+		return nil, err
+	}
+	out, err := s.db.ShareProject(ctx, req.GetProjectId(), g)
+	if err != nil {
+		return nil, err
+	}
+	return utils.GrantToProto(*out), nil
+}
+
+func (s *noteServiceServer) UnshareProject(ctx context.Context, req *pb.UnshareProjectRequest) (*pb.UnshareResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id is required")
+	}
+	p, err := toPrincipal(req.GetPrincipal())
+	if err != nil {
+		return nil, err
+	}
+	removed, err := s.db.UnshareProject(ctx, req.GetProjectId(), p)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UnshareResponse{Removed: removed}, nil
+}
+
+func (s *noteServiceServer) ListProjectGrants(ctx context.Context, req *pb.ListProjectGrantsRequest) (*pb.ListGrantsResponse, error) {
+	if req.GetProjectId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "project_id is required")
+	}
+	grants, err := s.db.ListProjectGrants(ctx, req.GetProjectId())
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListGrantsResponse{Grants: utils.GrantsToProto(grants)}, nil
+}
+
+func toPrincipal(p *pb.Principal) (models.Principal, error) {
+	out := utils.ProtoToPrincipal(p)
+	if out.Type == "" || out.ID == "" {
+		return models.Principal{}, status.Error(codes.InvalidArgument, "principal type and id are required")
+	}
+	return out, nil
+}
+
+func toGrant(p *pb.Principal, r pb.Role) (models.Grant, error) {
+	principal, err := toPrincipal(p)
+	if err != nil {
+		return models.Grant{}, err
+	}
+	role := utils.ProtoToRole(r)
+	if role == "" {
+		return models.Grant{}, status.Error(codes.InvalidArgument, "role is required")
+	}
+	return models.Grant{Principal: principal, Role: role}, nil
+}
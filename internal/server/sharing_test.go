@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShareNote_GrantListAndUnshare(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+	ctx := context.Background()
+	hr := &pb.Principal{Type: pb.PrincipalType_PRINCIPAL_TYPE_GROUP, Id: "hr"}
+
+	g, err := client.ShareNote(ctx, &pb.ShareNoteRequest{NoteId: "n1", Principal: hr, Role: pb.Role_ROLE_EDITOR})
+	require.NoError(t, err)
+	require.Equal(t, pb.Role_ROLE_EDITOR, g.GetRole())
+	require.Equal(t, "hr", g.GetPrincipal().GetId())
+
+	list, err := client.ListNoteGrants(ctx, &pb.ListNoteGrantsRequest{NoteId: "n1"})
+	require.NoError(t, err)
+	require.Len(t, list.GetGrants(), 1)
+	require.Equal(t, pb.PrincipalType_PRINCIPAL_TYPE_GROUP, list.GetGrants()[0].GetPrincipal().GetType())
+
+	res, err := client.UnshareNote(ctx, &pb.UnshareNoteRequest{NoteId: "n1", Principal: hr})
+	require.NoError(t, err)
+	require.True(t, res.GetRemoved())
+
+	res, err = client.UnshareNote(ctx, &pb.UnshareNoteRequest{NoteId: "n1", Principal: hr})
+	require.NoError(t, err)
+	require.False(t, res.GetRemoved())
+}
+
+func TestShareProject_RequiresPrincipalAndRole(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+	ctx := context.Background()
+
+	_, err := client.ShareProject(ctx, &pb.ShareProjectRequest{
+		ProjectId: "p1",
+		Principal: &pb.Principal{Type: pb.PrincipalType_PRINCIPAL_TYPE_USER, Id: "u2"},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ShareProject(ctx, &pb.ShareProjectRequest{
+		ProjectId: "p1",
+		Principal: &pb.Principal{Id: "u2"},
+		Role:      pb.Role_ROLE_VIEWER,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
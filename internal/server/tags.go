@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *noteServiceServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	tags, err := s.db.ListTags(ctx, models.ListTagsFilter{
+		ProjectID: req.ProjectId,
+		Prefix:    req.GetPrefix(),
+		PageSize:  int(req.GetPageSize()),
+		Tree:      req.GetTree(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list tags: %v", err)
+	}
+	if req.GetTree() {
+		return &pb.ListTagsResponse{Tags: utils.TagTreeToProto(tags)}, nil
+	}
+	return &pb.ListTagsResponse{Tags: utils.TagCountsToProto(tags)}, nil
+}
+
+func (s *noteServiceServer) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.TagChangeResponse, error) {
+	if utils.NormalizeTag(req.GetTag()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+	if utils.NormalizeTag(req.GetNewTag()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "new_tag is required")
+	}
+	return s.mergeTags(ctx, models.MergeTagsInput{
+		ProjectID: req.ProjectId,
+		Sources:   []string{req.GetTag()},
+		Target:    req.GetNewTag(),
+	})
+}
+
+func (s *noteServiceServer) MergeTags(ctx context.Context, req *pb.MergeTagsRequest) (*pb.TagChangeResponse, error) {
+	if len(utils.NormalizeTags(req.GetSourceTags())) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "source_tags is required")
+	}
+	if utils.NormalizeTag(req.GetTargetTag()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "target_tag is required")
+	}
+	return s.mergeTags(ctx, models.MergeTagsInput{
+		ProjectID: req.ProjectId,
+		Sources:   req.GetSourceTags(),
+		Target:    req.GetTargetTag(),
+	})
+}
+
+func (s *noteServiceServer) mergeTags(ctx context.Context, in models.MergeTagsInput) (*pb.TagChangeResponse, error) {
+	n, err := s.db.MergeTags(ctx, in)
+	if err != nil {
+		return nil, updateErrorToStatus(err)
+	}
+	return &pb.TagChangeResponse{NotesAffected: int32(n)}, nil
+}
+
+func (s *noteServiceServer) DeleteTag(ctx context.Context, req *pb.DeleteTagRequest) (*pb.TagChangeResponse, error) {
+	if utils.NormalizeTag(req.GetTag()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "tag is required")
+	}
+	n, err := s.db.DeleteTag(ctx, req.ProjectId, req.GetTag())
+	if err != nil {
+		return nil, updateErrorToStatus(err)
+	}
+	return &pb.TagChangeResponse{NotesAffected: int32(n)}, nil
+}
@@ -0,0 +1,72 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestListTags(t *testing.T) {
+	mock := &mockStore{tagCounts: []models.TagCount{{Tag: "bug", NoteCount: 3}, {Tag: "build", NoteCount: 1}}}
+	client := newTestClient(t, mock)
+
+	resp, err := client.ListTags(context.Background(), &pb.ListTagsRequest{Prefix: "bu"})
+	require.NoError(t, err)
+	require.Len(t, resp.GetTags(), 2)
+	require.Equal(t, "bug", resp.GetTags()[0].GetTag())
+	require.Equal(t, int32(3), resp.GetTags()[0].GetNoteCount())
+}
+
+func TestRenameTag(t *testing.T) {
+	mock := &mockStore{}
+	client := newTestClient(t, mock)
+
+	resp, err := client.RenameTag(context.Background(), &pb.RenameTagRequest{
+		ProjectId: ptrString("proj-1"),
+		Tag:       "bugs",
+		NewTag:    "bug",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), resp.GetNotesAffected())
+	require.Equal(t, []string{"bugs"}, mock.merged.Sources)
+	require.Equal(t, "bug", mock.merged.Target)
+	require.Equal(t, "proj-1", *mock.merged.ProjectID)
+
+	_, err = client.RenameTag(context.Background(), &pb.RenameTagRequest{Tag: "bugs", NewTag: "  "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestMergeTags_RequiresSources(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	_, err := client.MergeTags(context.Background(), &pb.MergeTagsRequest{SourceTags: []string{" "}, TargetTag: "bug"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListTags_Tree(t *testing.T) {
+	mock := &mockStore{tagCounts: []models.TagCount{
+		{Tag: "eng/backend/db", NoteCount: 1, TotalCount: 1},
+		{Tag: "eng", TotalCount: 3},
+		{Tag: "eng/backend", NoteCount: 1, TotalCount: 2},
+		{Tag: "eng/frontend", NoteCount: 1, TotalCount: 1},
+		{Tag: "ops", NoteCount: 4, TotalCount: 4},
+	}}
+	client := newTestClient(t, mock)
+
+	resp, err := client.ListTags(context.Background(), &pb.ListTagsRequest{Tree: true})
+	require.NoError(t, err)
+	require.Len(t, resp.GetTags(), 2)
+
+	eng := resp.GetTags()[0]
+	require.Equal(t, "eng", eng.GetTag())
+	require.Equal(t, int32(3), eng.GetTotalCount())
+	require.Len(t, eng.GetChildren(), 2)
+	require.Equal(t, "eng/backend", eng.GetChildren()[0].GetTag())
+	require.Equal(t, "eng/backend/db", eng.GetChildren()[0].GetChildren()[0].GetTag())
+	require.Equal(t, "ops", resp.GetTags()[1].GetTag())
+}
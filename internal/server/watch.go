@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	watchBatchSize = 100
+	// watchPollInterval backs up LISTEN/NOTIFY, which can drop wake-ups while
+	// the listener reconnects.
+	watchPollInterval = 5 * time.Second
+)
+
+func (s *noteServiceServer) WatchNotes(req *pb.WatchNotesRequest, stream pb.NoteService_WatchNotesServer) error {
+	ctx := stream.Context()
+
+	// Subscribe before reading the starting position so nothing committed in
+	// between goes unnoticed.
+	wake, unsubscribe := s.db.SubscribeNoteEvents()
+	defer unsubscribe()
+
+	filter := utils.ProtoToNoteEventFilter(req)
+	filter.Limit = watchBatchSize
+	if req.GetCursor() != "" {
+		c, err := utils.DecodeEventCursor(req.GetCursor())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, "invalid cursor")
+		}
+		if s.eventRetention > 0 && time.Since(c.At) > s.eventRetention {
+			return status.Error(codes.OutOfRange, "cursor is older than the event retention, resync with ListNotes")
+		}
+		filter.AfterID = c.ID
+	} else {
+		latest, err := s.db.LatestNoteEventID(ctx)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to start watch: %v", err)
+		}
+		filter.AfterID = latest
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := s.db.ListNoteEvents(ctx, filter)
+		if err != nil {
+			if ctx.Err() != nil {
+				return status.FromContextError(ctx.Err()).Err()
+			}
+			return status.Errorf(codes.Internal, "failed to read note events: %v", err)
+		}
+		for _, ev := range events {
+			if err := stream.Send(s.noteEventToProto(ctx, ev)); err != nil {
+				return err
+			}
+			filter.AfterID = ev.ID
+		}
+		if len(events) == filter.Limit {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// noteEventToProto attaches the current note to created and updated events.
+// The note may have changed again since the event; later events cover that.
+func (s *noteServiceServer) noteEventToProto(ctx context.Context, ev models.NoteEvent) *pb.NoteEvent {
+	// Marshalling an int and a time cannot fail.
+	cursor, _ := utils.EncodeEventCursor(utils.EventCursor{ID: ev.ID, At: ev.OccurredAt})
+	var note *models.Note
+	if ev.Type != models.NoteEventDeleted {
+		if n, err := s.db.ViewNote(ctx, ev.NoteID, models.GetNoteOptions{IncludeAttachments: true}); err == nil {
+			note = n
+		}
+	}
+	return utils.NoteEventToProto(ev, cursor, note)
+}
@@ -0,0 +1,56 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dovakin0007.com/notes-grpc/internal/models"
+	"dovakin0007.com/notes-grpc/internal/utils"
+	pb "dovakin0007.com/notes-grpc/notes"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWatchNotes_ResumesAndStreamsLiveEvents(t *testing.T) {
+	mock := &mockStore{wake: make(chan struct{}, 1)}
+	now := time.Now()
+	mock.emit(models.NoteEvent{Type: models.NoteEventCreated, NoteID: "n1", ProjectID: ptrString("p1"), AuthorID: "u1", OccurredAt: now})
+	mock.emit(models.NoteEvent{Type: models.NoteEventCreated, NoteID: "n2", ProjectID: ptrString("p2"), AuthorID: "u1", OccurredAt: now})
+	mock.emit(models.NoteEvent{Type: models.NoteEventUpdated, NoteID: "n1", ProjectID: ptrString("p1"), AuthorID: "u1", OccurredAt: now})
+
+	client := newTestClient(t, mock)
+	cursor, err := utils.EncodeEventCursor(utils.EventCursor{ID: 1, At: now})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchNotes(ctx, &pb.WatchNotesRequest{ProjectId: ptrString("p1"), Cursor: cursor})
+	require.NoError(t, err)
+
+	// Event 2 belongs to another project, so resuming after 1 yields 3.
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.NoteEventType_NOTE_EVENT_TYPE_UPDATED, ev.GetType())
+	require.Equal(t, "n1", ev.GetNoteId())
+	require.Equal(t, "n1", ev.GetNote().GetId())
+	resumed, err := utils.DecodeEventCursor(ev.GetCursor())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resumed.ID)
+
+	mock.emit(models.NoteEvent{Type: models.NoteEventDeleted, NoteID: "n1", ProjectID: ptrString("p1"), AuthorID: "u1", OccurredAt: time.Now()})
+	ev, err = stream.Recv()
+	require.NoError(t, err)
+	require.Equal(t, pb.NoteEventType_NOTE_EVENT_TYPE_DELETED, ev.GetType())
+	require.Nil(t, ev.GetNote())
+}
+
+func TestWatchNotes_RejectsBadCursor(t *testing.T) {
+	client := newTestClient(t, &mockStore{})
+
+	stream, err := client.WatchNotes(context.Background(), &pb.WatchNotesRequest{Cursor: "not-a-cursor"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
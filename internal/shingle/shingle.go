@@ -0,0 +1,64 @@
+// Package shingle compares texts by their word shingles, the runs of
+// consecutive words they contain, to spot near-duplicates that differ only in
+// case, punctuation, whitespace or a few edited words.
+package shingle
+
+import (
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+// Size is the number of words in a shingle.
+const Size = 3
+
+// Set is the hashed shingles of a text.
+type Set map[uint64]struct{}
+
+// Of returns the shingles of text after lowercasing it and splitting it into
+// words of letters and digits. A text shorter than Size words is one shingle.
+func Of(text string) Set {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	set := Set{}
+	if len(words) == 0 {
+		return set
+	}
+	if len(words) < Size {
+		set.add(words)
+		return set
+	}
+	for i := 0; i+Size <= len(words); i++ {
+		set.add(words[i : i+Size])
+	}
+	return set
+}
+
+func (s Set) add(words []string) {
+	h := fnv.New64a()
+	for _, w := range words {
+		h.Write([]byte(w))
+		h.Write([]byte{0})
+	}
+	s[h.Sum64()] = struct{}{}
+}
+
+// Similarity is the Jaccard similarity of a and b, from 0 for nothing in
+// common to 1 for the same shingles. Two empty sets have nothing in common.
+func Similarity(a, b Set) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	shared := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			shared++
+		}
+	}
+	union := len(a) + len(b) - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,38 @@
+package shingle_test
+
+import (
+	"testing"
+
+	"dovakin0007.com/notes-grpc/internal/shingle"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimilarity(t *testing.T) {
+	text := "Checkout outage: the payments service timed out while renewing its database credentials, so every order failed for twenty minutes."
+
+	cases := []struct {
+		name     string
+		a, b     string
+		min, max float64
+	}{
+		{"identical", text, text, 1, 1},
+		{"case, punctuation and spacing", text, "checkout OUTAGE -- the payments service timed out while renewing its database credentials so every order failed for twenty minutes", 1, 1},
+		{"one word edited", text, "Checkout outage: the payments service timed out while renewing its database credentials, so every order failed for thirty minutes.", 0.7, 0.9},
+		{"unrelated", text, "Quarterly planning: hire two engineers and ship the mobile app before the summer break.", 0, 0.05},
+		{"short titles", "Standup", "standup", 1, 1},
+		{"empty", "", "", 0, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := shingle.Similarity(shingle.Of(c.a), shingle.Of(c.b))
+			require.GreaterOrEqual(t, got, c.min)
+			require.LessOrEqual(t, got, c.max)
+		})
+	}
+}
+
+func TestOf(t *testing.T) {
+	require.Len(t, shingle.Of("one two three four"), 2)
+	require.Len(t, shingle.Of("one two"), 1)
+	require.Empty(t, shingle.Of(" -- "))
+}
@@ -1,10 +1,12 @@
 package utils
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"strings"
+	"time"
 
 	"dovakin0007.com/notes-grpc/internal/models"
 	pb "dovakin0007.com/notes-grpc/notes"
@@ -17,6 +19,8 @@ var allowedPaths = map[string]struct{}{
 	"tags":        {},
 	"is_pinned":   {},
 	"attachments": {},
+	"project_id":  {},
+	"language":    {},
 }
 
 var sortWhitelist = map[string]string{
@@ -58,21 +62,30 @@ func NormalizeMask(m *fieldmaskpb.FieldMask) error {
 }
 
 func UpdatesNotesMask(update_notes *models.UpdateNoteInput, req *pb.UpdateNoteRequest) {
+	update_notes.NoteID = req.NoteId
 	for _, path := range req.UpdateMask.GetPaths() {
 		switch path {
 		case "title":
 			update_notes.Title = &req.Title
+			update_notes.CreateRevision = true
 		case "content":
 			update_notes.Content = &req.Content
+			update_notes.CreateRevision = true
 		case "tags":
 			update_notes.Tags = &req.Tags
 		case "is_pinned":
 			update_notes.IsPinned = &req.IsPinned
+		case "project_id":
+			update_notes.MoveToProject = &req.ProjectId
+		case "language":
+			update_notes.Language = &req.Language
 		case "attachments":
 			{
-				var attachments []models.Attachment = make([]models.Attachment, 1, 10)
+				var attachments []models.Attachment = make([]models.Attachment, 0, len(req.Attachments))
 				for _, attachment := range req.Attachments {
-					attachments = append(attachments, ProtoToAttachmentModel(attachment))
+					a := ProtoToAttachmentModel(attachment)
+					a.NoteID = req.NoteId
+					attachments = append(attachments, a)
 				}
 				update_notes.Attachments = attachments
 			}
@@ -83,6 +96,15 @@ func UpdatesNotesMask(update_notes *models.UpdateNoteInput, req *pb.UpdateNoteRe
 			}
 		}
 	}
+	if req.IfMatchUpdatedAt != nil {
+		t := req.IfMatchUpdatedAt.AsTime()
+		update_notes.IfMatchUpdatedAt = &t
+	}
+	// The editor is recorded on revisions even when "user" is not in the mask.
+	if update_notes.Editor == nil && req.User != nil {
+		actor := ProtoToActorModel(req.User)
+		update_notes.Editor = &actor
+	}
 }
 
 func NormalizeSort(sortBy string) (col string) {
@@ -129,9 +151,140 @@ func DecodePaginationToken(token string) (*NotesPagination, error) {
 
 }
 
+// EventCursor marks a position in the WatchNotes feed. At lets the server tell
+// when the events after the cursor may already have been pruned.
+type EventCursor struct {
+	ID int64     `json:"id"`
+	At time.Time `json:"at"`
+}
+
+func EncodeEventCursor(c EventCursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func DecodeEventCursor(token string) (*EventCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var c EventCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.ID <= 0 || c.At.IsZero() {
+		return nil, errors.New("invalid event cursor")
+	}
+	return &c, nil
+}
+
+// RelevanceCursor is the ListNotes page token when sorting by relevance.
+// Search ties it to the query and language it was issued for, ranks mean
+// nothing for another search.
+type RelevanceCursor struct {
+	Rank   float32 `json:"rank"`
+	ID     string  `json:"id"`
+	Search string  `json:"search"`
+}
+
+// RelevanceSearchKey hashes a search query and its language for
+// RelevanceCursor.Search. A nil language, meaning each note's own, hashes
+// differently from any named one.
+func RelevanceSearchKey(query string, language *string) string {
+	h := sha256.New()
+	h.Write([]byte(query))
+	if language != nil {
+		h.Write([]byte{0})
+		h.Write([]byte(*language))
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil))
+}
+
+func EncodeRelevanceCursor(c RelevanceCursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func DecodeRelevanceCursor(token string) (*RelevanceCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var c RelevanceCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, err
+	}
+	if c.ID == "" {
+		return nil, errors.New("invalid relevance cursor")
+	}
+	return &c, nil
+}
+
+// NormalizeTag lowercases a tag and collapses runs of whitespace into a single
+// space, so "Bug", " bug" and "BUG " are the same tag. Tags nest with "/";
+// each segment is normalized on its own and empty ones are dropped, so
+// "Eng / Backend/" becomes "eng/backend".
+func NormalizeTag(tag string) string {
+	segments := strings.Split(tag, "/")
+	out := segments[:0]
+	for _, s := range segments {
+		if s = strings.Join(strings.Fields(strings.ToLower(s)), " "); s != "" {
+			out = append(out, s)
+		}
+	}
+	return strings.Join(out, "/")
+}
+
+// NormalizeTags normalizes tags, dropping empty ones and duplicates while
+// keeping the first occurrence's order.
+func NormalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	out := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		t = NormalizeTag(t)
+		if t == "" || seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	return out
+}
+
 func NilIfEmpty(s string) *string {
 	if strings.TrimSpace(s) == "" {
 		return nil
 	}
 	return &s
 }
+
+// ProtoToUpdateProjectInput applies the update mask, which must name at
+// least one of "name", "description" and "language".
+func ProtoToUpdateProjectInput(req *pb.UpdateProjectRequest) (models.UpdateProjectInput, error) {
+	in := models.UpdateProjectInput{ProjectID: req.GetId()}
+	if len(req.GetUpdateMask().GetPaths()) == 0 {
+		return in, errors.New("field mask is required")
+	}
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		switch path {
+		case "name":
+			in.Name = &req.Name
+		case "description":
+			in.Description = &req.Description
+		case "language":
+			in.Language = &req.Language
+		default:
+			return in, errors.New("invalid field mask path: " + path)
+		}
+	}
+	return in, nil
+}
@@ -1,11 +1,16 @@
 package utils
 
 import (
+	"sort"
+	"strings"
 	"time"
 
+	"dovakin0007.com/notes-grpc/internal/diff"
 	"dovakin0007.com/notes-grpc/internal/models"
 	pb "dovakin0007.com/notes-grpc/notes"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -54,6 +59,9 @@ func ToCreateNoteInput(req *pb.CreateNoteRequest) models.CreateNoteInput {
 		Author:         author,
 		Attachment:     attachments,
 		IdempotencyKey: idem,
+		Language:       NilIfEmpty(req.GetLanguage()),
+
+		RejectDuplicates: req.GetRejectDuplicates(),
 	}
 }
 
@@ -73,20 +81,16 @@ func attachmentsProtoToModel(in []*pb.Attachment, noteID string) []models.Attach
 			uploaded = time.Now()
 		}
 
+		// External attachments are only described by the client, so unknown
+		// checksums and sizes stay NULL. UploadAttachment fills in real values.
 		var shaPtr *string
 		if s := a.GetSha256(); s != "" {
 			shaPtr = &s
-		} else {
-			defaultSha := "unknown"
-			shaPtr = &defaultSha
 		}
 
 		var sizePtr *int64
 		if sz := a.GetSizeBytes(); sz != 0 {
 			sizePtr = &sz
-		} else {
-			defaultSize := int64(-1)
-			sizePtr = &defaultSize
 		}
 
 		out = append(out, models.Attachment{
@@ -149,6 +153,14 @@ func NoteToProto(n models.Note) *pb.Note {
 	if !n.UpdatedAt.IsZero() {
 		updatedAt = timestamppb.New(n.UpdatedAt)
 	}
+	var deletedAt *timestamppb.Timestamp
+	if n.DeletedAt != nil {
+		deletedAt = timestamppb.New(*n.DeletedAt)
+	}
+	var deletedBy *pb.ActorRef
+	if n.DeletedByID != nil {
+		deletedBy = &pb.ActorRef{Id: *n.DeletedByID}
+	}
 
 	return &pb.Note{
 		Id:          n.ID,
@@ -157,11 +169,16 @@ func NoteToProto(n models.Note) *pb.Note {
 		Title:       n.Title,
 		Content:     content,
 		IsPinned:    n.IsPinned,
+		Language:    n.Language,
 		Tags:        n.Tags,
 		Revisions:   pbRevs,
 		Attachments: pbAtts,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
+		DeletedAt:   deletedAt,
+		DeletedBy:   deletedBy,
+
+		HasMoreRevisions: n.HasMoreRevisions,
 	}
 }
 
@@ -171,10 +188,18 @@ func ActorModelToProto(a models.Actor) *pb.ActorRef {
 		return nil
 	}
 
+	var displayName, avatarURL *string
+	if a.DisplayName != nil {
+		displayName = strPtrOrNil(*a.DisplayName)
+	}
+	if a.AvatarURL != nil {
+		avatarURL = strPtrOrNil(*a.AvatarURL)
+	}
+
 	return &pb.ActorRef{
 		Id:          a.ID,
-		DisplayName: strPtrOrNil(*a.DisplayName),
-		AvatarUrl:   strPtrOrNil(*a.AvatarURL),
+		DisplayName: displayName,
+		AvatarUrl:   avatarURL,
 	}
 }
 
@@ -184,13 +209,17 @@ func AttachmentModelToProto(a models.Attachment) *pb.Attachment {
 	if !a.UploadedAt.IsZero() {
 		uploaded = timestamppb.New(a.UploadedAt)
 	}
+	var sha256 *string
+	if a.SHA256 != nil {
+		sha256 = strPtrOrNil(*a.SHA256)
+	}
 	return &pb.Attachment{
 		Id:         a.ID,
 		Url:        a.URL,
 		FileName:   a.FileName,
 		FileType:   a.FileType,
 		UploadedAt: uploaded,
-		Sha256:     strPtrOrNil(*a.SHA256),
+		Sha256:     sha256,
 		SizeBytes:  a.SizeBytes,
 	}
 }
@@ -265,13 +294,107 @@ func revisionModelToProto(r models.NoteRevision) *pb.NoteRevision {
 	}
 }
 
+func RevisionsToProto(revisions []models.NoteRevision) []*pb.NoteRevision {
+	out := make([]*pb.NoteRevision, 0, len(revisions))
+	for _, r := range revisions {
+		out = append(out, revisionModelToProto(r))
+	}
+	return out
+}
+
+func ProtoToListNoteRevisionsFilter(req *pb.ListNoteRevisionsRequest) models.ListNoteRevisionsFilter {
+	return models.ListNoteRevisionsFilter{
+		NoteID:    req.GetNoteId(),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+}
+
+func ProtoToRestoreNoteRevisionInput(req *pb.RestoreNoteRevisionRequest) models.RestoreNoteRevisionInput {
+	in := models.RestoreNoteRevisionInput{
+		NoteID:     req.GetNoteId(),
+		RevisionID: req.GetRevisionId(),
+	}
+	if req.User != nil {
+		actor := ProtoToActorModel(req.User)
+		in.Editor = &actor
+	}
+	if req.IfMatchUpdatedAt != nil {
+		t := req.IfMatchUpdatedAt.AsTime()
+		in.IfMatchUpdatedAt = &t
+	}
+	return in
+}
+
+func DiffHunksToProto(hunks []diff.Hunk) []*pb.DiffHunk {
+	out := make([]*pb.DiffHunk, 0, len(hunks))
+	for _, h := range hunks {
+		spans := make([]*pb.DiffSpan, 0, len(h.Spans))
+		for _, sp := range h.Spans {
+			op := pb.DiffSpan_OP_EQUAL
+			switch sp.Op {
+			case diff.Insert:
+				op = pb.DiffSpan_OP_INSERT
+			case diff.Delete:
+				op = pb.DiffSpan_OP_DELETE
+			}
+			spans = append(spans, &pb.DiffSpan{Op: op, Text: sp.Text})
+		}
+		out = append(out, &pb.DiffHunk{
+			FromStart: int32(h.FromStart),
+			FromCount: int32(h.FromCount),
+			ToStart:   int32(h.ToStart),
+			ToCount:   int32(h.ToCount),
+			Spans:     spans,
+		})
+	}
+	return out
+}
+
+func ProtoToDiffGranularity(g pb.DiffGranularity) diff.Granularity {
+	if g == pb.DiffGranularity_DIFF_GRANULARITY_WORD {
+		return diff.Word
+	}
+	return diff.Line
+}
+
+func ProtoToTrashFilter(req *pb.ListTrashRequest) models.ListNotesFilter {
+	return models.ListNotesFilter{
+		ProjectID:   req.ProjectId,
+		UserID:      req.UserId,
+		SortBy:      "deleted_at",
+		SortDesc:    true,
+		PageSize:    int(req.GetPageSize()),
+		PageToken:   req.GetPageToken(),
+		OnlyDeleted: true,
+	}
+}
+
+func ProtoToDeleteNoteInput(req *pb.DeleteNoteRequest) models.DeleteNoteInput {
+	in := models.DeleteNoteInput{
+		NoteID: req.GetNoteId(),
+		Hard:   req.GetHardDelete(),
+	}
+	if req.User != nil {
+		actor := ProtoToActorModel(req.User)
+		in.DeletedBy = &actor
+	}
+	return in
+}
+
 func ProtoToListNotesFilter(req *pb.ListNotesRequest) models.ListNotesFilter {
 	filter := models.ListNotesFilter{
 		ProjectID: req.ProjectId,
 		UserID:    req.UserId,
 		Query:     req.Query,
+		Language:  NilIfEmpty(req.GetLanguage()),
+		TagsAny:   req.TagsAny,
+		TagsAll:   req.TagsAll,
+		TagsNone:  req.TagsNone,
 		PageSize:  int(req.PageSize),
 		PageToken: req.PageToken,
+
+		IncludeDeleted: req.IncludeDeleted,
 	}
 
 	if req.SortBy != nil {
@@ -292,3 +415,279 @@ func ProtoToListNotesFilter(req *pb.ListNotesRequest) models.ListNotesFilter {
 
 	return filter
 }
+
+var noteEventTypes = map[string]pb.NoteEventType{
+	models.NoteEventCreated: pb.NoteEventType_NOTE_EVENT_TYPE_CREATED,
+	models.NoteEventUpdated: pb.NoteEventType_NOTE_EVENT_TYPE_UPDATED,
+	models.NoteEventDeleted: pb.NoteEventType_NOTE_EVENT_TYPE_DELETED,
+}
+
+// NoteEventToProto converts an outbox event. note is the current state of the
+// note and may be nil.
+func NoteEventToProto(ev models.NoteEvent, cursor string, note *models.Note) *pb.NoteEvent {
+	out := &pb.NoteEvent{
+		Cursor:     cursor,
+		Type:       noteEventTypes[ev.Type],
+		NoteId:     ev.NoteID,
+		ProjectId:  ev.ProjectID,
+		AuthorId:   ev.AuthorID,
+		Tags:       ev.Tags,
+		OccurredAt: timestamppb.New(ev.OccurredAt),
+	}
+	if note != nil {
+		out.Note = NoteToProto(*note)
+	}
+	return out
+}
+
+func ProtoToNoteEventFilter(req *pb.WatchNotesRequest) models.NoteEventFilter {
+	return models.NoteEventFilter{
+		ProjectID: req.ProjectId,
+		AuthorID:  req.AuthorId,
+		Tag:       req.Tag,
+	}
+}
+
+var roles = map[models.Role]pb.Role{
+	models.RoleViewer: pb.Role_ROLE_VIEWER,
+	models.RoleEditor: pb.Role_ROLE_EDITOR,
+	models.RoleOwner:  pb.Role_ROLE_OWNER,
+}
+
+var principalTypes = map[string]pb.PrincipalType{
+	models.PrincipalUser:  pb.PrincipalType_PRINCIPAL_TYPE_USER,
+	models.PrincipalGroup: pb.PrincipalType_PRINCIPAL_TYPE_GROUP,
+}
+
+// ProtoToRole returns "" for ROLE_UNSPECIFIED and unknown values.
+func ProtoToRole(r pb.Role) models.Role {
+	for role, v := range roles {
+		if v == r {
+			return role
+		}
+	}
+	return ""
+}
+
+// ProtoToPrincipal returns a principal with an empty Type for unspecified or
+// unknown principal types.
+func ProtoToPrincipal(p *pb.Principal) models.Principal {
+	out := models.Principal{ID: p.GetId()}
+	for t, v := range principalTypes {
+		if v == p.GetType() {
+			out.Type = t
+		}
+	}
+	return out
+}
+
+func GrantToProto(g models.Grant) *pb.Grant {
+	return &pb.Grant{
+		Principal: &pb.Principal{Type: principalTypes[g.Type], Id: g.ID},
+		Role:      roles[g.Role],
+		GrantedBy: g.GrantedBy,
+		CreatedAt: timestamppb.New(g.CreatedAt),
+	}
+}
+
+func GrantsToProto(grants []models.Grant) []*pb.Grant {
+	out := make([]*pb.Grant, 0, len(grants))
+	for _, g := range grants {
+		out = append(out, GrantToProto(g))
+	}
+	return out
+}
+
+func ToCreateProjectInput(req *pb.CreateProjectRequest) models.CreateProjectInput {
+	id := req.GetId()
+	if id == "" {
+		id = uuid.NewString()
+	}
+	return models.CreateProjectInput{
+		ID:          id,
+		Name:        req.GetName(),
+		Description: strPtrOrNil(req.GetDescription()),
+		Language:    NilIfEmpty(req.GetLanguage()),
+	}
+}
+
+func ProjectToProto(p models.Project) *pb.Project {
+	out := &pb.Project{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Language:    p.Language,
+		CreatedAt:   timestamppb.New(p.CreatedAt),
+		UpdatedAt:   timestamppb.New(p.UpdatedAt),
+		NoteCount:   p.NoteCount,
+	}
+	if p.Owner != nil {
+		out.Owner = ActorModelToProto(*p.Owner)
+	} else {
+		out.Owner = &pb.ActorRef{Id: p.OwnerID}
+	}
+	if p.ArchivedAt != nil {
+		out.ArchivedAt = timestamppb.New(*p.ArchivedAt)
+	}
+	return out
+}
+
+func ProjectsToProto(projects []models.Project) []*pb.Project {
+	out := make([]*pb.Project, 0, len(projects))
+	for _, p := range projects {
+		out = append(out, ProjectToProto(p))
+	}
+	return out
+}
+
+func TagCountsToProto(tags []models.TagCount) []*pb.TagCount {
+	out := make([]*pb.TagCount, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, &pb.TagCount{Tag: t.Tag, NoteCount: int32(t.NoteCount)})
+	}
+	return out
+}
+
+// TagTreeToProto nests tags under their parents. Tags whose parent is not in
+// tags become roots. Siblings are ordered by path.
+func TagTreeToProto(tags []models.TagCount) []*pb.TagCount {
+	sorted := append([]models.TagCount(nil), tags...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Tag < sorted[j].Tag })
+
+	nodes := make(map[string]*pb.TagCount, len(sorted))
+	var roots []*pb.TagCount
+	for _, t := range sorted {
+		node := &pb.TagCount{Tag: t.Tag, NoteCount: int32(t.NoteCount), TotalCount: int32(t.TotalCount)}
+		nodes[t.Tag] = node
+		if i := strings.LastIndex(t.Tag, "/"); i >= 0 {
+			if parent, ok := nodes[t.Tag[:i]]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		roots = append(roots, node)
+	}
+	return roots
+}
+
+// SearchResultsToProto returns a result per note when they carry matches, nil
+// otherwise.
+func SearchResultsToProto(notes []models.Note) []*pb.SearchResult {
+	if len(notes) == 0 || notes[0].Match == nil {
+		return nil
+	}
+	out := make([]*pb.SearchResult, 0, len(notes))
+	for _, n := range notes {
+		r := &pb.SearchResult{NoteId: n.ID}
+		if n.Match != nil {
+			r.Rank = n.Match.Rank
+			r.Snippet = n.Match.Snippet
+			for _, h := range n.Match.Highlights {
+				r.Highlights = append(r.Highlights, &pb.TextRange{Start: int32(h.Start), End: int32(h.End)})
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+func TitleMatchesToProto(matches []models.TitleMatch) []*pb.TitleMatch {
+	out := make([]*pb.TitleMatch, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, &pb.TitleMatch{Note: NoteToProto(m.Note), Similarity: m.Similarity})
+	}
+	return out
+}
+
+func DuplicatesToProto(duplicates []models.DuplicateNote) []*pb.DuplicateNote {
+	if len(duplicates) == 0 {
+		return nil
+	}
+	out := make([]*pb.DuplicateNote, 0, len(duplicates))
+	for _, d := range duplicates {
+		out = append(out, &pb.DuplicateNote{Note: NoteToProto(d.Note), Similarity: d.Similarity})
+	}
+	return out
+}
+
+func RelatedNotesToProto(related []models.RelatedNote) []*pb.RelatedNote {
+	out := make([]*pb.RelatedNote, 0, len(related))
+	for _, r := range related {
+		out = append(out, &pb.RelatedNote{Note: NoteToProto(r.Note), Score: r.Score})
+	}
+	return out
+}
+
+var suggestionKinds = map[string]pb.SuggestionKind{
+	models.SuggestionTitle: pb.SuggestionKind_SUGGESTION_KIND_TITLE,
+	models.SuggestionTag:   pb.SuggestionKind_SUGGESTION_KIND_TAG,
+}
+
+func SuggestionsToProto(suggestions []models.Suggestion) []*pb.Suggestion {
+	out := make([]*pb.Suggestion, 0, len(suggestions))
+	for _, s := range suggestions {
+		out = append(out, &pb.Suggestion{Text: s.Text, Kind: suggestionKinds[s.Kind], Similarity: s.Similarity})
+	}
+	return out
+}
+
+// ToCreateSavedSearchInput keeps the request without its page_size and
+// page_token, which are given on every run instead.
+func ToCreateSavedSearchInput(req *pb.CreateSavedSearchRequest) (models.CreateSavedSearchInput, error) {
+	id := req.GetId()
+	if id == "" {
+		id = uuid.NewString()
+	}
+	saved := &pb.ListNotesRequest{}
+	if req.GetRequest() != nil {
+		saved = proto.Clone(req.GetRequest()).(*pb.ListNotesRequest)
+	}
+	saved.PageSize, saved.PageToken = 0, ""
+	b, err := protojson.Marshal(saved)
+	if err != nil {
+		return models.CreateSavedSearchInput{}, err
+	}
+	return models.CreateSavedSearchInput{ID: id, Name: req.GetName(), Request: b}, nil
+}
+
+// SavedListNotesRequest decodes the request of a saved search. Fields that no
+// longer exist are dropped.
+func SavedListNotesRequest(s models.SavedSearch) (*pb.ListNotesRequest, error) {
+	req := &pb.ListNotesRequest{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(s.Request, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func SavedSearchToProto(s models.SavedSearch) (*pb.SavedSearch, error) {
+	req, err := SavedListNotesRequest(s)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.SavedSearch{
+		Id:        s.ID,
+		Name:      s.Name,
+		Request:   req,
+		CreatedAt: timestamppb.New(s.CreatedAt),
+		UpdatedAt: timestamppb.New(s.UpdatedAt),
+	}
+	if s.Owner != nil {
+		out.Owner = ActorModelToProto(*s.Owner)
+	} else {
+		out.Owner = &pb.ActorRef{Id: s.OwnerID}
+	}
+	return out, nil
+}
+
+func SavedSearchesToProto(searches []models.SavedSearch) ([]*pb.SavedSearch, error) {
+	out := make([]*pb.SavedSearch, 0, len(searches))
+	for _, s := range searches {
+		p, err := SavedSearchToProto(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
@@ -7,6 +7,7 @@
 package notes
 
 import (
+	status "google.golang.org/genproto/googleapis/rpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
@@ -23,6 +24,310 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DiffGranularity int32
+
+const (
+	DiffGranularity_DIFF_GRANULARITY_UNSPECIFIED DiffGranularity = 0 // same as LINE
+	DiffGranularity_DIFF_GRANULARITY_LINE        DiffGranularity = 1
+	DiffGranularity_DIFF_GRANULARITY_WORD        DiffGranularity = 2
+)
+
+// Enum value maps for DiffGranularity.
+var (
+	DiffGranularity_name = map[int32]string{
+		0: "DIFF_GRANULARITY_UNSPECIFIED",
+		1: "DIFF_GRANULARITY_LINE",
+		2: "DIFF_GRANULARITY_WORD",
+	}
+	DiffGranularity_value = map[string]int32{
+		"DIFF_GRANULARITY_UNSPECIFIED": 0,
+		"DIFF_GRANULARITY_LINE":        1,
+		"DIFF_GRANULARITY_WORD":        2,
+	}
+)
+
+func (x DiffGranularity) Enum() *DiffGranularity {
+	p := new(DiffGranularity)
+	*p = x
+	return p
+}
+
+func (x DiffGranularity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiffGranularity) Descriptor() protoreflect.EnumDescriptor {
+	return file_notes_proto_enumTypes[0].Descriptor()
+}
+
+func (DiffGranularity) Type() protoreflect.EnumType {
+	return &file_notes_proto_enumTypes[0]
+}
+
+func (x DiffGranularity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiffGranularity.Descriptor instead.
+func (DiffGranularity) EnumDescriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{0}
+}
+
+type NoteEventType int32
+
+const (
+	NoteEventType_NOTE_EVENT_TYPE_UNSPECIFIED NoteEventType = 0
+	NoteEventType_NOTE_EVENT_TYPE_CREATED     NoteEventType = 1
+	// Also sent when a note is restored from the trash
+	NoteEventType_NOTE_EVENT_TYPE_UPDATED NoteEventType = 2
+	NoteEventType_NOTE_EVENT_TYPE_DELETED NoteEventType = 3
+)
+
+// Enum value maps for NoteEventType.
+var (
+	NoteEventType_name = map[int32]string{
+		0: "NOTE_EVENT_TYPE_UNSPECIFIED",
+		1: "NOTE_EVENT_TYPE_CREATED",
+		2: "NOTE_EVENT_TYPE_UPDATED",
+		3: "NOTE_EVENT_TYPE_DELETED",
+	}
+	NoteEventType_value = map[string]int32{
+		"NOTE_EVENT_TYPE_UNSPECIFIED": 0,
+		"NOTE_EVENT_TYPE_CREATED":     1,
+		"NOTE_EVENT_TYPE_UPDATED":     2,
+		"NOTE_EVENT_TYPE_DELETED":     3,
+	}
+)
+
+func (x NoteEventType) Enum() *NoteEventType {
+	p := new(NoteEventType)
+	*p = x
+	return p
+}
+
+func (x NoteEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NoteEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_notes_proto_enumTypes[1].Descriptor()
+}
+
+func (NoteEventType) Type() protoreflect.EnumType {
+	return &file_notes_proto_enumTypes[1]
+}
+
+func (x NoteEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NoteEventType.Descriptor instead.
+func (NoteEventType) EnumDescriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{1}
+}
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_VIEWER      Role = 1
+	// Can edit, trash and restore notes
+	Role_ROLE_EDITOR Role = 2
+	// Can also share, purge and hard delete
+	Role_ROLE_OWNER Role = 3
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_VIEWER",
+		2: "ROLE_EDITOR",
+		3: "ROLE_OWNER",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_VIEWER":      1,
+		"ROLE_EDITOR":      2,
+		"ROLE_OWNER":       3,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_notes_proto_enumTypes[2].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_notes_proto_enumTypes[2]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{2}
+}
+
+type PrincipalType int32
+
+const (
+	PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED PrincipalType = 0
+	PrincipalType_PRINCIPAL_TYPE_USER        PrincipalType = 1
+	// A group listed in the caller's token
+	PrincipalType_PRINCIPAL_TYPE_GROUP PrincipalType = 2
+)
+
+// Enum value maps for PrincipalType.
+var (
+	PrincipalType_name = map[int32]string{
+		0: "PRINCIPAL_TYPE_UNSPECIFIED",
+		1: "PRINCIPAL_TYPE_USER",
+		2: "PRINCIPAL_TYPE_GROUP",
+	}
+	PrincipalType_value = map[string]int32{
+		"PRINCIPAL_TYPE_UNSPECIFIED": 0,
+		"PRINCIPAL_TYPE_USER":        1,
+		"PRINCIPAL_TYPE_GROUP":       2,
+	}
+)
+
+func (x PrincipalType) Enum() *PrincipalType {
+	p := new(PrincipalType)
+	*p = x
+	return p
+}
+
+func (x PrincipalType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PrincipalType) Descriptor() protoreflect.EnumDescriptor {
+	return file_notes_proto_enumTypes[3].Descriptor()
+}
+
+func (PrincipalType) Type() protoreflect.EnumType {
+	return &file_notes_proto_enumTypes[3]
+}
+
+func (x PrincipalType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PrincipalType.Descriptor instead.
+func (PrincipalType) EnumDescriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{3}
+}
+
+type SuggestionKind int32
+
+const (
+	SuggestionKind_SUGGESTION_KIND_UNSPECIFIED SuggestionKind = 0
+	SuggestionKind_SUGGESTION_KIND_TITLE       SuggestionKind = 1
+	SuggestionKind_SUGGESTION_KIND_TAG         SuggestionKind = 2
+)
+
+// Enum value maps for SuggestionKind.
+var (
+	SuggestionKind_name = map[int32]string{
+		0: "SUGGESTION_KIND_UNSPECIFIED",
+		1: "SUGGESTION_KIND_TITLE",
+		2: "SUGGESTION_KIND_TAG",
+	}
+	SuggestionKind_value = map[string]int32{
+		"SUGGESTION_KIND_UNSPECIFIED": 0,
+		"SUGGESTION_KIND_TITLE":       1,
+		"SUGGESTION_KIND_TAG":         2,
+	}
+)
+
+func (x SuggestionKind) Enum() *SuggestionKind {
+	p := new(SuggestionKind)
+	*p = x
+	return p
+}
+
+func (x SuggestionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SuggestionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_notes_proto_enumTypes[4].Descriptor()
+}
+
+func (SuggestionKind) Type() protoreflect.EnumType {
+	return &file_notes_proto_enumTypes[4]
+}
+
+func (x SuggestionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SuggestionKind.Descriptor instead.
+func (SuggestionKind) EnumDescriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{4}
+}
+
+type DiffSpan_Op int32
+
+const (
+	DiffSpan_OP_EQUAL  DiffSpan_Op = 0
+	DiffSpan_OP_INSERT DiffSpan_Op = 1
+	DiffSpan_OP_DELETE DiffSpan_Op = 2
+)
+
+// Enum value maps for DiffSpan_Op.
+var (
+	DiffSpan_Op_name = map[int32]string{
+		0: "OP_EQUAL",
+		1: "OP_INSERT",
+		2: "OP_DELETE",
+	}
+	DiffSpan_Op_value = map[string]int32{
+		"OP_EQUAL":  0,
+		"OP_INSERT": 1,
+		"OP_DELETE": 2,
+	}
+)
+
+func (x DiffSpan_Op) Enum() *DiffSpan_Op {
+	p := new(DiffSpan_Op)
+	*p = x
+	return p
+}
+
+func (x DiffSpan_Op) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DiffSpan_Op) Descriptor() protoreflect.EnumDescriptor {
+	return file_notes_proto_enumTypes[5].Descriptor()
+}
+
+func (DiffSpan_Op) Type() protoreflect.EnumType {
+	return &file_notes_proto_enumTypes[5]
+}
+
+func (x DiffSpan_Op) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DiffSpan_Op.Descriptor instead.
+func (DiffSpan_Op) EnumDescriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{26, 0}
+}
+
 type ActorRef struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // required
@@ -84,18 +389,25 @@ func (x *ActorRef) GetAvatarUrl() string {
 }
 
 type Note struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProjectId     *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
-	Author        *ActorRef              `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
-	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
-	Content       *string                `protobuf:"bytes,5,opt,name=content,proto3,oneof" json:"content,omitempty"`
-	IsPinned      bool                   `protobuf:"varint,6,opt,name=is_pinned,json=isPinned,proto3" json:"is_pinned,omitempty"`
-	Tags          []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
-	Revisions     []*NoteRevision        `protobuf:"bytes,8,rep,name=revisions,proto3" json:"revisions,omitempty"`
-	Attachments   []*Attachment          `protobuf:"bytes,9,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId   *string                `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Author      *ActorRef              `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Title       string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Content     *string                `protobuf:"bytes,5,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	IsPinned    bool                   `protobuf:"varint,6,opt,name=is_pinned,json=isPinned,proto3" json:"is_pinned,omitempty"`
+	Tags        []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Revisions   []*NoteRevision        `protobuf:"bytes,8,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	Attachments []*Attachment          `protobuf:"bytes,9,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set while the note is in the trash
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=deleted_at,json=deletedAt,proto3,oneof" json:"deleted_at,omitempty"`
+	DeletedBy *ActorRef              `protobuf:"bytes,13,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	// True when revisions holds only the newest revisions_limit entries
+	HasMoreRevisions bool `protobuf:"varint,14,opt,name=has_more_revisions,json=hasMoreRevisions,proto3" json:"has_more_revisions,omitempty"`
+	// Text search configuration its title and content are indexed with
+	Language      string `protobuf:"bytes,15,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -207,6 +519,34 @@ func (x *Note) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Note) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Note) GetDeletedBy() *ActorRef {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return nil
+}
+
+func (x *Note) GetHasMoreRevisions() bool {
+	if x != nil {
+		return x.HasMoreRevisions
+	}
+	return false
+}
+
+func (x *Note) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 type NoteRevision struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -375,30 +715,32 @@ func (x *Attachment) GetSizeBytes() int64 {
 	return 0
 }
 
-type GetNoteRequest struct {
+// First message carries the metadata, every following one a chunk of bytes
+type UploadAttachmentRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Include heavy fields?
-	IncludeRevisions   bool `protobuf:"varint,2,opt,name=include_revisions,json=includeRevisions,proto3" json:"include_revisions,omitempty"`
-	IncludeAttachments bool `protobuf:"varint,3,opt,name=include_attachments,json=includeAttachments,proto3" json:"include_attachments,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*UploadAttachmentRequest_Metadata
+	//	*UploadAttachmentRequest_Chunk
+	Payload       isUploadAttachmentRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetNoteRequest) Reset() {
-	*x = GetNoteRequest{}
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
 	mi := &file_notes_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetNoteRequest) String() string {
+func (x *UploadAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetNoteRequest) ProtoMessage() {}
+func (*UploadAttachmentRequest) ProtoMessage() {}
 
-func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_notes_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -410,59 +752,78 @@ func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetNoteRequest.ProtoReflect.Descriptor instead.
-func (*GetNoteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
 	return file_notes_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetNoteRequest) GetId() string {
+func (x *UploadAttachmentRequest) GetPayload() isUploadAttachmentRequest_Payload {
 	if x != nil {
-		return x.Id
+		return x.Payload
 	}
-	return ""
+	return nil
 }
 
-func (x *GetNoteRequest) GetIncludeRevisions() bool {
+func (x *UploadAttachmentRequest) GetMetadata() *UploadAttachmentMetadata {
 	if x != nil {
-		return x.IncludeRevisions
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Metadata); ok {
+			return x.Metadata
+		}
 	}
-	return false
+	return nil
 }
 
-func (x *GetNoteRequest) GetIncludeAttachments() bool {
+func (x *UploadAttachmentRequest) GetChunk() []byte {
 	if x != nil {
-		return x.IncludeAttachments
+		if x, ok := x.Payload.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
 	}
-	return false
+	return nil
 }
 
-type ListNotesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
-	UserId        *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
-	Query         *string                `protobuf:"bytes,3,opt,name=query,proto3,oneof" json:"query,omitempty"`
-	SortBy        *string                `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
-	SortDesc      *bool                  `protobuf:"varint,5,opt,name=sort_desc,json=sortDesc,proto3,oneof" json:"sort_desc,omitempty"`
-	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+type isUploadAttachmentRequest_Payload interface {
+	isUploadAttachmentRequest_Payload()
+}
+
+type UploadAttachmentRequest_Metadata struct {
+	Metadata *UploadAttachmentMetadata `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Metadata) isUploadAttachmentRequest_Payload() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Payload() {}
+
+type UploadAttachmentMetadata struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	NoteId   string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	FileName string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileType string                 `protobuf:"bytes,3,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	User     *ActorRef              `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	// Optional client side checksum, the upload is rejected when it differs
+	Sha256        *string `protobuf:"bytes,5,opt,name=sha256,proto3,oneof" json:"sha256,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotesRequest) Reset() {
-	*x = ListNotesRequest{}
+func (x *UploadAttachmentMetadata) Reset() {
+	*x = UploadAttachmentMetadata{}
 	mi := &file_notes_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotesRequest) String() string {
+func (x *UploadAttachmentMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotesRequest) ProtoMessage() {}
+func (*UploadAttachmentMetadata) ProtoMessage() {}
 
-func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadAttachmentMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_notes_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -474,88 +835,67 @@ func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotesRequest.ProtoReflect.Descriptor instead.
-func (*ListNotesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadAttachmentMetadata.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentMetadata) Descriptor() ([]byte, []int) {
 	return file_notes_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *ListNotesRequest) GetProjectId() string {
-	if x != nil && x.ProjectId != nil {
-		return *x.ProjectId
-	}
-	return ""
-}
-
-func (x *ListNotesRequest) GetUserId() string {
-	if x != nil && x.UserId != nil {
-		return *x.UserId
+func (x *UploadAttachmentMetadata) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
 	}
 	return ""
 }
 
-func (x *ListNotesRequest) GetQuery() string {
-	if x != nil && x.Query != nil {
-		return *x.Query
+func (x *UploadAttachmentMetadata) GetFileName() string {
+	if x != nil {
+		return x.FileName
 	}
 	return ""
 }
 
-func (x *ListNotesRequest) GetSortBy() string {
-	if x != nil && x.SortBy != nil {
-		return *x.SortBy
+func (x *UploadAttachmentMetadata) GetFileType() string {
+	if x != nil {
+		return x.FileType
 	}
 	return ""
 }
 
-func (x *ListNotesRequest) GetSortDesc() bool {
-	if x != nil && x.SortDesc != nil {
-		return *x.SortDesc
-	}
-	return false
-}
-
-func (x *ListNotesRequest) GetPageSize() int32 {
+func (x *UploadAttachmentMetadata) GetUser() *ActorRef {
 	if x != nil {
-		return x.PageSize
+		return x.User
 	}
-	return 0
+	return nil
 }
 
-func (x *ListNotesRequest) GetPageToken() string {
-	if x != nil {
-		return x.PageToken
+func (x *UploadAttachmentMetadata) GetSha256() string {
+	if x != nil && x.Sha256 != nil {
+		return *x.Sha256
 	}
 	return ""
 }
 
-type CreateNoteRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId      *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
-	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content        *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
-	IsPinned       bool                   `protobuf:"varint,4,opt,name=is_pinned,json=isPinned,proto3" json:"is_pinned,omitempty"`
-	Tags           []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
-	Attachments    []*Attachment          `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	Author         *ActorRef              `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
-	IdempotencyKey *string                `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type DownloadAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateNoteRequest) Reset() {
-	*x = CreateNoteRequest{}
+func (x *DownloadAttachmentRequest) Reset() {
+	*x = DownloadAttachmentRequest{}
 	mi := &file_notes_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateNoteRequest) String() string {
+func (x *DownloadAttachmentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateNoteRequest) ProtoMessage() {}
+func (*DownloadAttachmentRequest) ProtoMessage() {}
 
-func (x *CreateNoteRequest) ProtoReflect() protoreflect.Message {
+func (x *DownloadAttachmentRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_notes_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -567,98 +907,4257 @@ func (x *CreateNoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateNoteRequest.ProtoReflect.Descriptor instead.
-func (*CreateNoteRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DownloadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentRequest) Descriptor() ([]byte, []int) {
 	return file_notes_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *CreateNoteRequest) GetProjectId() string {
+func (x *DownloadAttachmentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+// First message carries the metadata, every following one a chunk of bytes
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*DownloadAttachmentResponse_Metadata
+	//	*DownloadAttachmentResponse_Chunk
+	Payload       isDownloadAttachmentResponse_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_notes_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DownloadAttachmentResponse) GetPayload() isDownloadAttachmentResponse_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetMetadata() *Attachment {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Metadata); ok {
+			return x.Metadata
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Payload.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Payload interface {
+	isDownloadAttachmentResponse_Payload()
+}
+
+type DownloadAttachmentResponse_Metadata struct {
+	Metadata *Attachment `protobuf:"bytes,1,opt,name=metadata,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadAttachmentResponse_Metadata) isDownloadAttachmentResponse_Payload() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Payload() {}
+
+type GetNoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Include heavy fields?
+	IncludeRevisions   bool `protobuf:"varint,2,opt,name=include_revisions,json=includeRevisions,proto3" json:"include_revisions,omitempty"`
+	IncludeAttachments bool `protobuf:"varint,3,opt,name=include_attachments,json=includeAttachments,proto3" json:"include_attachments,omitempty"`
+	// Also return the note when it is in the trash
+	IncludeDeleted bool `protobuf:"varint,4,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Newest revisions to return with include_revisions, default 50, max 500
+	RevisionsLimit *int32 `protobuf:"varint,5,opt,name=revisions_limit,json=revisionsLimit,proto3,oneof" json:"revisions_limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetNoteRequest) Reset() {
+	*x = GetNoteRequest{}
+	mi := &file_notes_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNoteRequest) ProtoMessage() {}
+
+func (x *GetNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNoteRequest.ProtoReflect.Descriptor instead.
+func (*GetNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetNoteRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetNoteRequest) GetIncludeRevisions() bool {
+	if x != nil {
+		return x.IncludeRevisions
+	}
+	return false
+}
+
+func (x *GetNoteRequest) GetIncludeAttachments() bool {
+	if x != nil {
+		return x.IncludeAttachments
+	}
+	return false
+}
+
+func (x *GetNoteRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *GetNoteRequest) GetRevisionsLimit() int32 {
+	if x != nil && x.RevisionsLimit != nil {
+		return *x.RevisionsLimit
+	}
+	return 0
+}
+
+type ListNotesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	UserId    *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	// Search query: words and "quoted phrases" (all must match), OR, -exclude,
+	// (grouping), and the qualifiers title:, tag:, author:, project:,
+	// pinned:true, updated: and created: with >, >=, <, <= a YYYY-MM-DD day,
+	// the day itself or day..day. Days can also be today, today-Nd or today-Nw
+	// (UTC). Malformed queries fail with InvalidArgument naming the position
+	// of the problem.
+	Query *string `protobuf:"bytes,3,opt,name=query,proto3,oneof" json:"query,omitempty"`
+	// updated_at (default), created_at, title, is_pinned, or relevance, which
+	// needs a query with words or phrases and always puts the best match first
+	SortBy         *string `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	SortDesc       *bool   `protobuf:"varint,5,opt,name=sort_desc,json=sortDesc,proto3,oneof" json:"sort_desc,omitempty"`
+	PageSize       int32   `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken      string  `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	IncludeDeleted bool    `protobuf:"varint,8,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	// Tag filters; any combination of them can be used together.
+	TagsAny  []string `protobuf:"bytes,9,rep,name=tags_any,json=tagsAny,proto3" json:"tags_any,omitempty"`     // notes with at least one of these tags
+	TagsAll  []string `protobuf:"bytes,10,rep,name=tags_all,json=tagsAll,proto3" json:"tags_all,omitempty"`    // notes with every one of these tags
+	TagsNone []string `protobuf:"bytes,11,rep,name=tags_none,json=tagsNone,proto3" json:"tags_none,omitempty"` // notes with none of these tags
+	// Text search configuration the query is read in, such as "german". When
+	// unset each note is matched in its own language.
+	Language      *string `protobuf:"bytes,12,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotesRequest) Reset() {
+	*x = ListNotesRequest{}
+	mi := &file_notes_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotesRequest) ProtoMessage() {}
+
+func (x *ListNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotesRequest.ProtoReflect.Descriptor instead.
+func (*ListNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListNotesRequest) GetProjectId() string {
 	if x != nil && x.ProjectId != nil {
 		return *x.ProjectId
 	}
 	return ""
 }
 
-func (x *CreateNoteRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
+func (x *ListNotesRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
 	}
 	return ""
 }
 
-func (x *CreateNoteRequest) GetContent() string {
-	if x != nil && x.Content != nil {
-		return *x.Content
+func (x *ListNotesRequest) GetQuery() string {
+	if x != nil && x.Query != nil {
+		return *x.Query
 	}
 	return ""
 }
 
-func (x *CreateNoteRequest) GetIsPinned() bool {
+func (x *ListNotesRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *ListNotesRequest) GetSortDesc() bool {
+	if x != nil && x.SortDesc != nil {
+		return *x.SortDesc
+	}
+	return false
+}
+
+func (x *ListNotesRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.IsPinned
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNotesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListNotesRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
 	}
 	return false
 }
 
-func (x *CreateNoteRequest) GetTags() []string {
+func (x *ListNotesRequest) GetTagsAny() []string {
 	if x != nil {
-		return x.Tags
+		return x.TagsAny
 	}
 	return nil
 }
 
-func (x *CreateNoteRequest) GetAttachments() []*Attachment {
+func (x *ListNotesRequest) GetTagsAll() []string {
 	if x != nil {
-		return x.Attachments
+		return x.TagsAll
 	}
 	return nil
 }
 
-func (x *CreateNoteRequest) GetAuthor() *ActorRef {
+func (x *ListNotesRequest) GetTagsNone() []string {
 	if x != nil {
-		return x.Author
+		return x.TagsNone
 	}
 	return nil
 }
 
-func (x *CreateNoteRequest) GetIdempotencyKey() string {
-	if x != nil && x.IdempotencyKey != nil {
-		return *x.IdempotencyKey
+func (x *ListNotesRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
 	}
 	return ""
 }
 
-type UpdateNoteRequest struct {
-	state  protoimpl.MessageState `protogen:"open.v1"`
-	NoteId string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
-	// New values (only those listed in update_mask are applied)
-	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content          string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	Tags             []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
-	IsPinned         bool                   `protobuf:"varint,5,opt,name=is_pinned,json=isPinned,proto3" json:"is_pinned,omitempty"`
-	Attachments      []*Attachment          `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
-	User             *ActorRef              `protobuf:"bytes,7,opt,name=user,proto3" json:"user,omitempty"`
-	UpdateMask       *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
-	IfMatchUpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=if_match_updated_at,json=ifMatchUpdatedAt,proto3,oneof" json:"if_match_updated_at,omitempty"`
+type CreateNoteRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId      *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content        *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	IsPinned       bool                   `protobuf:"varint,4,opt,name=is_pinned,json=isPinned,proto3" json:"is_pinned,omitempty"`
+	Tags           []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	Attachments    []*Attachment          `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Author         *ActorRef              `protobuf:"bytes,7,opt,name=author,proto3" json:"author,omitempty"`
+	IdempotencyKey *string                `protobuf:"bytes,8,opt,name=idempotency_key,json=idempotencyKey,proto3,oneof" json:"idempotency_key,omitempty"`
+	// Text search configuration, such as "german" or "simple"; defaults to the
+	// project's language, then "english"
+	Language *string `protobuf:"bytes,9,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	// Fail with ALREADY_EXISTS, naming the notes, instead of creating a note
+	// that nearly duplicates one of them
+	RejectDuplicates bool `protobuf:"varint,10,opt,name=reject_duplicates,json=rejectDuplicates,proto3" json:"reject_duplicates,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *UpdateNoteRequest) Reset() {
-	*x = UpdateNoteRequest{}
-	mi := &file_notes_proto_msgTypes[7]
+func (x *CreateNoteRequest) Reset() {
+	*x = CreateNoteRequest{}
+	mi := &file_notes_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNoteRequest) ProtoMessage() {}
+
+func (x *CreateNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNoteRequest.ProtoReflect.Descriptor instead.
+func (*CreateNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateNoteRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetIsPinned() bool {
+	if x != nil {
+		return x.IsPinned
+	}
+	return false
+}
+
+func (x *CreateNoteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateNoteRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *CreateNoteRequest) GetAuthor() *ActorRef {
+	if x != nil {
+		return x.Author
+	}
+	return nil
+}
+
+func (x *CreateNoteRequest) GetIdempotencyKey() string {
+	if x != nil && x.IdempotencyKey != nil {
+		return *x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+func (x *CreateNoteRequest) GetRejectDuplicates() bool {
+	if x != nil {
+		return x.RejectDuplicates
+	}
+	return false
+}
+
+type UpdateNoteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// New values (only those listed in update_mask are applied)
+	Title            string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content          string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Tags             []string               `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	IsPinned         bool                   `protobuf:"varint,5,opt,name=is_pinned,json=isPinned,proto3" json:"is_pinned,omitempty"`
+	Attachments      []*Attachment          `protobuf:"bytes,6,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	User             *ActorRef              `protobuf:"bytes,7,opt,name=user,proto3" json:"user,omitempty"`
+	UpdateMask       *fieldmaskpb.FieldMask `protobuf:"bytes,8,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	IfMatchUpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=if_match_updated_at,json=ifMatchUpdatedAt,proto3,oneof" json:"if_match_updated_at,omitempty"`
+	// Moves the note like MoveNotes; empty takes it out of its project
+	ProjectId string `protobuf:"bytes,10,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Empty goes back to the project's language
+	Language      string `protobuf:"bytes,11,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateNoteRequest) Reset() {
+	*x = UpdateNoteRequest{}
+	mi := &file_notes_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNoteRequest) ProtoMessage() {}
+
+func (x *UpdateNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNoteRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *UpdateNoteRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateNoteRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateNoteRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateNoteRequest) GetIsPinned() bool {
+	if x != nil {
+		return x.IsPinned
+	}
+	return false
+}
+
+func (x *UpdateNoteRequest) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *UpdateNoteRequest) GetUser() *ActorRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *UpdateNoteRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateNoteRequest) GetIfMatchUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IfMatchUpdatedAt
+	}
+	return nil
+}
+
+func (x *UpdateNoteRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *UpdateNoteRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type MoveNotesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most 500 notes, moved together or not at all
+	NoteIds []string `protobuf:"bytes,1,rep,name=note_ids,json=noteIds,proto3" json:"note_ids,omitempty"`
+	// Unset takes the notes out of their projects
+	TargetProjectId *string   `protobuf:"bytes,2,opt,name=target_project_id,json=targetProjectId,proto3,oneof" json:"target_project_id,omitempty"`
+	User            *ActorRef `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *MoveNotesRequest) Reset() {
+	*x = MoveNotesRequest{}
+	mi := &file_notes_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveNotesRequest) ProtoMessage() {}
+
+func (x *MoveNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveNotesRequest.ProtoReflect.Descriptor instead.
+func (*MoveNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MoveNotesRequest) GetNoteIds() []string {
+	if x != nil {
+		return x.NoteIds
+	}
+	return nil
+}
+
+func (x *MoveNotesRequest) GetTargetProjectId() string {
+	if x != nil && x.TargetProjectId != nil {
+		return *x.TargetProjectId
+	}
+	return ""
+}
+
+func (x *MoveNotesRequest) GetUser() *ActorRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type MoveNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveNotesResponse) Reset() {
+	*x = MoveNotesResponse{}
+	mi := &file_notes_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveNotesResponse) ProtoMessage() {}
+
+func (x *MoveNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveNotesResponse.ProtoReflect.Descriptor instead.
+func (*MoveNotesResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MoveNotesResponse) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+type DeleteNoteRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// Skip the trash and remove the note right away
+	HardDelete    *bool     `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3,oneof" json:"hard_delete,omitempty"`
+	User          *ActorRef `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteNoteRequest) Reset() {
+	*x = DeleteNoteRequest{}
+	mi := &file_notes_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNoteRequest) ProtoMessage() {}
+
+func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNoteRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeleteNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *DeleteNoteRequest) GetHardDelete() bool {
+	if x != nil && x.HardDelete != nil {
+		return *x.HardDelete
+	}
+	return false
+}
+
+func (x *DeleteNoteRequest) GetUser() *ActorRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	UserId        *string                `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_notes_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTrashRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetUserId() string {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type RestoreNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreNoteRequest) Reset() {
+	*x = RestoreNoteRequest{}
+	mi := &file_notes_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreNoteRequest) ProtoMessage() {}
+
+func (x *RestoreNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreNoteRequest.ProtoReflect.Descriptor instead.
+func (*RestoreNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RestoreNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+type PurgeNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeNoteRequest) Reset() {
+	*x = PurgeNoteRequest{}
+	mi := &file_notes_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeNoteRequest) ProtoMessage() {}
+
+func (x *PurgeNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeNoteRequest.ProtoReflect.Descriptor instead.
+func (*PurgeNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PurgeNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+// An existing note a new one nearly repeats
+type DuplicateNote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Note  *Note                  `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// Jaccard similarity of the normalized title and content word shingles, 0
+	// to 1
+	Similarity    float32 `protobuf:"fixed32,2,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateNote) Reset() {
+	*x = DuplicateNote{}
+	mi := &file_notes_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateNote) ProtoMessage() {}
+
+func (x *DuplicateNote) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateNote.ProtoReflect.Descriptor instead.
+func (*DuplicateNote) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DuplicateNote) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *DuplicateNote) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type NoteResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Note  *Note                  `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// Set by CreateNote: live notes by the same author or in the same project
+	// that the new note nearly duplicates, most similar first
+	Duplicates    []*DuplicateNote `protobuf:"bytes,2,rep,name=duplicates,proto3" json:"duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteResponse) Reset() {
+	*x = NoteResponse{}
+	mi := &file_notes_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteResponse) ProtoMessage() {}
+
+func (x *NoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteResponse.ProtoReflect.Descriptor instead.
+func (*NoteResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *NoteResponse) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *NoteResponse) GetDuplicates() []*DuplicateNote {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+type ListNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// Set when the query has words or phrases: one per note, in the same order
+	Results       []*SearchResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotesResponse) Reset() {
+	*x = ListNotesResponse{}
+	mi := &file_notes_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotesResponse) ProtoMessage() {}
+
+func (x *ListNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotesResponse.ProtoReflect.Descriptor instead.
+func (*ListNotesResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListNotesResponse) GetNotes() []*Note {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *ListNotesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListNotesResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// TextRange is [start, end) in characters (Unicode code points).
+type TextRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextRange) Reset() {
+	*x = TextRange{}
+	mi := &file_notes_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextRange) ProtoMessage() {}
+
+func (x *TextRange) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextRange.ProtoReflect.Descriptor instead.
+func (*TextRange) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TextRange) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TextRange) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type SearchResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// ts_rank_cd with title matches weighted above content matches
+	Rank float32 `protobuf:"fixed32,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Excerpts of title and content around the matches, joined by " … "
+	Snippet string `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	// Where the matched words are in snippet
+	Highlights    []*TextRange `protobuf:"bytes,4,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
+	mi := &file_notes_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResult) ProtoMessage() {}
+
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchResult) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *SearchResult) GetRank() float32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchResult) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+func (x *SearchResult) GetHighlights() []*TextRange {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+type ListNoteRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNoteRevisionsRequest) Reset() {
+	*x = ListNoteRevisionsRequest{}
+	mi := &file_notes_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNoteRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNoteRevisionsRequest) ProtoMessage() {}
+
+func (x *ListNoteRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNoteRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListNoteRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListNoteRevisionsRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *ListNoteRevisionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListNoteRevisionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListNoteRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*NoteRevision        `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNoteRevisionsResponse) Reset() {
+	*x = ListNoteRevisionsResponse{}
+	mi := &file_notes_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNoteRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNoteRevisionsResponse) ProtoMessage() {}
+
+func (x *ListNoteRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNoteRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListNoteRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListNoteRevisionsResponse) GetRevisions() []*NoteRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *ListNoteRevisionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type DiffNoteRevisionsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	NoteId         string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	FromRevisionId string                 `protobuf:"bytes,2,opt,name=from_revision_id,json=fromRevisionId,proto3" json:"from_revision_id,omitempty"`
+	// Empty compares against the current note
+	ToRevisionId *string         `protobuf:"bytes,3,opt,name=to_revision_id,json=toRevisionId,proto3,oneof" json:"to_revision_id,omitempty"`
+	Granularity  DiffGranularity `protobuf:"varint,4,opt,name=granularity,proto3,enum=notes.v1.DiffGranularity" json:"granularity,omitempty"`
+	// Unchanged lines/words kept around each change, defaults to 3
+	Context        *int32 `protobuf:"varint,5,opt,name=context,proto3,oneof" json:"context,omitempty"`
+	IncludeUnified bool   `protobuf:"varint,6,opt,name=include_unified,json=includeUnified,proto3" json:"include_unified,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiffNoteRevisionsRequest) Reset() {
+	*x = DiffNoteRevisionsRequest{}
+	mi := &file_notes_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffNoteRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffNoteRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffNoteRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffNoteRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffNoteRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DiffNoteRevisionsRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *DiffNoteRevisionsRequest) GetFromRevisionId() string {
+	if x != nil {
+		return x.FromRevisionId
+	}
+	return ""
+}
+
+func (x *DiffNoteRevisionsRequest) GetToRevisionId() string {
+	if x != nil && x.ToRevisionId != nil {
+		return *x.ToRevisionId
+	}
+	return ""
+}
+
+func (x *DiffNoteRevisionsRequest) GetGranularity() DiffGranularity {
+	if x != nil {
+		return x.Granularity
+	}
+	return DiffGranularity_DIFF_GRANULARITY_UNSPECIFIED
+}
+
+func (x *DiffNoteRevisionsRequest) GetContext() int32 {
+	if x != nil && x.Context != nil {
+		return *x.Context
+	}
+	return 0
+}
+
+func (x *DiffNoteRevisionsRequest) GetIncludeUnified() bool {
+	if x != nil {
+		return x.IncludeUnified
+	}
+	return false
+}
+
+type DiffSpan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            DiffSpan_Op            `protobuf:"varint,1,opt,name=op,proto3,enum=notes.v1.DiffSpan_Op" json:"op,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSpan) Reset() {
+	*x = DiffSpan{}
+	mi := &file_notes_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSpan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSpan) ProtoMessage() {}
+
+func (x *DiffSpan) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSpan.ProtoReflect.Descriptor instead.
+func (*DiffSpan) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DiffSpan) GetOp() DiffSpan_Op {
+	if x != nil {
+		return x.Op
+	}
+	return DiffSpan_OP_EQUAL
+}
+
+func (x *DiffSpan) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// Starts are 1-based, counts are in lines or words depending on granularity
+type DiffHunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromStart     int32                  `protobuf:"varint,1,opt,name=from_start,json=fromStart,proto3" json:"from_start,omitempty"`
+	FromCount     int32                  `protobuf:"varint,2,opt,name=from_count,json=fromCount,proto3" json:"from_count,omitempty"`
+	ToStart       int32                  `protobuf:"varint,3,opt,name=to_start,json=toStart,proto3" json:"to_start,omitempty"`
+	ToCount       int32                  `protobuf:"varint,4,opt,name=to_count,json=toCount,proto3" json:"to_count,omitempty"`
+	Spans         []*DiffSpan            `protobuf:"bytes,5,rep,name=spans,proto3" json:"spans,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffHunk) Reset() {
+	*x = DiffHunk{}
+	mi := &file_notes_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffHunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffHunk) ProtoMessage() {}
+
+func (x *DiffHunk) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffHunk.ProtoReflect.Descriptor instead.
+func (*DiffHunk) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DiffHunk) GetFromStart() int32 {
+	if x != nil {
+		return x.FromStart
+	}
+	return 0
+}
+
+func (x *DiffHunk) GetFromCount() int32 {
+	if x != nil {
+		return x.FromCount
+	}
+	return 0
+}
+
+func (x *DiffHunk) GetToStart() int32 {
+	if x != nil {
+		return x.ToStart
+	}
+	return 0
+}
+
+func (x *DiffHunk) GetToCount() int32 {
+	if x != nil {
+		return x.ToCount
+	}
+	return 0
+}
+
+func (x *DiffHunk) GetSpans() []*DiffSpan {
+	if x != nil {
+		return x.Spans
+	}
+	return nil
+}
+
+type DiffNoteRevisionsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Titles are always diffed word by word
+	TitleHunks   []*DiffHunk `protobuf:"bytes,1,rep,name=title_hunks,json=titleHunks,proto3" json:"title_hunks,omitempty"`
+	ContentHunks []*DiffHunk `protobuf:"bytes,2,rep,name=content_hunks,json=contentHunks,proto3" json:"content_hunks,omitempty"`
+	// Line based unified diff of the content, set when include_unified is true
+	UnifiedDiff   string `protobuf:"bytes,3,opt,name=unified_diff,json=unifiedDiff,proto3" json:"unified_diff,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffNoteRevisionsResponse) Reset() {
+	*x = DiffNoteRevisionsResponse{}
+	mi := &file_notes_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffNoteRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffNoteRevisionsResponse) ProtoMessage() {}
+
+func (x *DiffNoteRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffNoteRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*DiffNoteRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DiffNoteRevisionsResponse) GetTitleHunks() []*DiffHunk {
+	if x != nil {
+		return x.TitleHunks
+	}
+	return nil
+}
+
+func (x *DiffNoteRevisionsResponse) GetContentHunks() []*DiffHunk {
+	if x != nil {
+		return x.ContentHunks
+	}
+	return nil
+}
+
+func (x *DiffNoteRevisionsResponse) GetUnifiedDiff() string {
+	if x != nil {
+		return x.UnifiedDiff
+	}
+	return ""
+}
+
+type RestoreNoteRevisionRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	NoteId           string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	RevisionId       string                 `protobuf:"bytes,2,opt,name=revision_id,json=revisionId,proto3" json:"revision_id,omitempty"`
+	User             *ActorRef              `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	IfMatchUpdatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=if_match_updated_at,json=ifMatchUpdatedAt,proto3,oneof" json:"if_match_updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RestoreNoteRevisionRequest) Reset() {
+	*x = RestoreNoteRevisionRequest{}
+	mi := &file_notes_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreNoteRevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreNoteRevisionRequest) ProtoMessage() {}
+
+func (x *RestoreNoteRevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreNoteRevisionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreNoteRevisionRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RestoreNoteRevisionRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *RestoreNoteRevisionRequest) GetRevisionId() string {
+	if x != nil {
+		return x.RevisionId
+	}
+	return ""
+}
+
+func (x *RestoreNoteRevisionRequest) GetUser() *ActorRef {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *RestoreNoteRevisionRequest) GetIfMatchUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IfMatchUpdatedAt
+	}
+	return nil
+}
+
+type WatchNotesRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	AuthorId  *string                `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	// Also matches the tags nested below it, like the ListNotes tag filters
+	Tag *string `protobuf:"bytes,3,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	// cursor of the last event seen; empty starts from the next change
+	Cursor        string `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchNotesRequest) Reset() {
+	*x = WatchNotesRequest{}
+	mi := &file_notes_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchNotesRequest) ProtoMessage() {}
+
+func (x *WatchNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchNotesRequest.ProtoReflect.Descriptor instead.
+func (*WatchNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *WatchNotesRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *WatchNotesRequest) GetAuthorId() string {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return ""
+}
+
+func (x *WatchNotesRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *WatchNotesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type NoteEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Pass back as WatchNotesRequest.cursor to resume after this event
+	Cursor    string        `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Type      NoteEventType `protobuf:"varint,2,opt,name=type,proto3,enum=notes.v1.NoteEventType" json:"type,omitempty"`
+	NoteId    string        `protobuf:"bytes,3,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	ProjectId *string       `protobuf:"bytes,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	AuthorId  string        `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// Tags at the time of the event
+	Tags       []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	OccurredAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	// Current state of the note, unset for deletes or notes that are gone
+	Note          *Note `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NoteEvent) Reset() {
+	*x = NoteEvent{}
+	mi := &file_notes_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NoteEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoteEvent) ProtoMessage() {}
+
+func (x *NoteEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoteEvent.ProtoReflect.Descriptor instead.
+func (*NoteEvent) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NoteEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *NoteEvent) GetType() NoteEventType {
+	if x != nil {
+		return x.Type
+	}
+	return NoteEventType_NOTE_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *NoteEvent) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *NoteEvent) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *NoteEvent) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *NoteEvent) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *NoteEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *NoteEvent) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+type Principal struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          PrincipalType          `protobuf:"varint,1,opt,name=type,proto3,enum=notes.v1.PrincipalType" json:"type,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Principal) Reset() {
+	*x = Principal{}
+	mi := &file_notes_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Principal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Principal) ProtoMessage() {}
+
+func (x *Principal) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Principal.ProtoReflect.Descriptor instead.
+func (*Principal) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Principal) GetType() PrincipalType {
+	if x != nil {
+		return x.Type
+	}
+	return PrincipalType_PRINCIPAL_TYPE_UNSPECIFIED
+}
+
+func (x *Principal) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Grant struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Principal     *Principal             `protobuf:"bytes,1,opt,name=principal,proto3" json:"principal,omitempty"`
+	Role          Role                   `protobuf:"varint,2,opt,name=role,proto3,enum=notes.v1.Role" json:"role,omitempty"`
+	GrantedBy     *string                `protobuf:"bytes,3,opt,name=granted_by,json=grantedBy,proto3,oneof" json:"granted_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Grant) Reset() {
+	*x = Grant{}
+	mi := &file_notes_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Grant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Grant) ProtoMessage() {}
+
+func (x *Grant) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Grant.ProtoReflect.Descriptor instead.
+func (*Grant) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Grant) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+func (x *Grant) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *Grant) GetGrantedBy() string {
+	if x != nil && x.GrantedBy != nil {
+		return *x.GrantedBy
+	}
+	return ""
+}
+
+func (x *Grant) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type ShareNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Principal     *Principal             `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	Role          Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=notes.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareNoteRequest) Reset() {
+	*x = ShareNoteRequest{}
+	mi := &file_notes_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareNoteRequest) ProtoMessage() {}
+
+func (x *ShareNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareNoteRequest.ProtoReflect.Descriptor instead.
+func (*ShareNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ShareNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *ShareNoteRequest) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+func (x *ShareNoteRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type UnshareNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	Principal     *Principal             `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareNoteRequest) Reset() {
+	*x = UnshareNoteRequest{}
+	mi := &file_notes_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareNoteRequest) ProtoMessage() {}
+
+func (x *UnshareNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareNoteRequest.ProtoReflect.Descriptor instead.
+func (*UnshareNoteRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UnshareNoteRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *UnshareNoteRequest) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+type ListNoteGrantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNoteGrantsRequest) Reset() {
+	*x = ListNoteGrantsRequest{}
+	mi := &file_notes_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNoteGrantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNoteGrantsRequest) ProtoMessage() {}
+
+func (x *ListNoteGrantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNoteGrantsRequest.ProtoReflect.Descriptor instead.
+func (*ListNoteGrantsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListNoteGrantsRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+type ShareProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Principal     *Principal             `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	Role          Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=notes.v1.Role" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareProjectRequest) Reset() {
+	*x = ShareProjectRequest{}
+	mi := &file_notes_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareProjectRequest) ProtoMessage() {}
+
+func (x *ShareProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareProjectRequest.ProtoReflect.Descriptor instead.
+func (*ShareProjectRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ShareProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ShareProjectRequest) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+func (x *ShareProjectRequest) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+type UnshareProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Principal     *Principal             `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareProjectRequest) Reset() {
+	*x = UnshareProjectRequest{}
+	mi := &file_notes_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareProjectRequest) ProtoMessage() {}
+
+func (x *UnshareProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareProjectRequest.ProtoReflect.Descriptor instead.
+func (*UnshareProjectRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UnshareProjectRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *UnshareProjectRequest) GetPrincipal() *Principal {
+	if x != nil {
+		return x.Principal
+	}
+	return nil
+}
+
+type ListProjectGrantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectGrantsRequest) Reset() {
+	*x = ListProjectGrantsRequest{}
+	mi := &file_notes_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectGrantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectGrantsRequest) ProtoMessage() {}
+
+func (x *ListProjectGrantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectGrantsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectGrantsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListProjectGrantsRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+type ListGrantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Grants        []*Grant               `protobuf:"bytes,1,rep,name=grants,proto3" json:"grants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGrantsResponse) Reset() {
+	*x = ListGrantsResponse{}
+	mi := &file_notes_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGrantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGrantsResponse) ProtoMessage() {}
+
+func (x *ListGrantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGrantsResponse.ProtoReflect.Descriptor instead.
+func (*ListGrantsResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListGrantsResponse) GetGrants() []*Grant {
+	if x != nil {
+		return x.Grants
+	}
+	return nil
+}
+
+type UnshareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       bool                   `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareResponse) Reset() {
+	*x = UnshareResponse{}
+	mi := &file_notes_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareResponse) ProtoMessage() {}
+
+func (x *UnshareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareResponse.ProtoReflect.Descriptor instead.
+func (*UnshareResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *UnshareResponse) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+type Project struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Owner       *ActorRef              `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Set once the project is archived; archived projects take no new notes
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=archived_at,json=archivedAt,proto3,oneof" json:"archived_at,omitempty"`
+	// Notes outside the trash that the caller can read
+	NoteCount int64 `protobuf:"varint,8,opt,name=note_count,json=noteCount,proto3" json:"note_count,omitempty"`
+	// Default text search configuration for notes created in the project;
+	// unset means "english"
+	Language      *string `protobuf:"bytes,9,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_notes_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetOwner() *ActorRef {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *Project) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Project) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Project) GetNoteCount() int64 {
+	if x != nil {
+		return x.NoteCount
+	}
+	return 0
+}
+
+func (x *Project) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+type CreateProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Generated when empty
+	Id            *string   `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	Name          string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string   `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Owner         *ActorRef `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Language      *string   `protobuf:"bytes,5,opt,name=language,proto3,oneof" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_notes_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateProjectRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetOwner() *ActorRef {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *CreateProjectRequest) GetLanguage() string {
+	if x != nil && x.Language != nil {
+		return *x.Language
+	}
+	return ""
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_notes_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListProjectsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PageSize        int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken       string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,3,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_notes_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListProjectsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProjectsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListProjectsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_notes_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *ListProjectsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type UpdateProjectRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// New values (only those listed in update_mask are applied)
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// An empty description clears it
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	UpdateMask  *fieldmaskpb.FieldMask `protobuf:"bytes,4,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// An empty language clears it; notes already in the project keep theirs
+	Language      string `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_notes_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdateProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+func (x *UpdateProjectRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type ArchiveProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveProjectRequest) Reset() {
+	*x = ArchiveProjectRequest{}
+	mi := &file_notes_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveProjectRequest) ProtoMessage() {}
+
+func (x *ArchiveProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveProjectRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveProjectRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ArchiveProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectResponse) Reset() {
+	*x = ProjectResponse{}
+	mi := &file_notes_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectResponse) ProtoMessage() {}
+
+func (x *ProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectResponse.ProtoReflect.Descriptor instead.
+func (*ProjectResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+// Batch calls run in one transaction. By default the first failing item
+// fails the call and nothing is applied; with allow_partial_failure every
+// item gets its own status and the successful ones are kept.
+type BatchGetNotesRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Ids                 []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	IncludeAttachments  bool                   `protobuf:"varint,2,opt,name=include_attachments,json=includeAttachments,proto3" json:"include_attachments,omitempty"`
+	IncludeDeleted      bool                   `protobuf:"varint,3,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	AllowPartialFailure bool                   `protobuf:"varint,4,opt,name=allow_partial_failure,json=allowPartialFailure,proto3" json:"allow_partial_failure,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchGetNotesRequest) Reset() {
+	*x = BatchGetNotesRequest{}
+	mi := &file_notes_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetNotesRequest) ProtoMessage() {}
+
+func (x *BatchGetNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetNotesRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *BatchGetNotesRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BatchGetNotesRequest) GetIncludeAttachments() bool {
+	if x != nil {
+		return x.IncludeAttachments
+	}
+	return false
+}
+
+func (x *BatchGetNotesRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *BatchGetNotesRequest) GetAllowPartialFailure() bool {
+	if x != nil {
+		return x.AllowPartialFailure
+	}
+	return false
+}
+
+type BatchCreateNotesRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Requests            []*CreateNoteRequest   `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	AllowPartialFailure bool                   `protobuf:"varint,2,opt,name=allow_partial_failure,json=allowPartialFailure,proto3" json:"allow_partial_failure,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchCreateNotesRequest) Reset() {
+	*x = BatchCreateNotesRequest{}
+	mi := &file_notes_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCreateNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCreateNotesRequest) ProtoMessage() {}
+
+func (x *BatchCreateNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCreateNotesRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *BatchCreateNotesRequest) GetRequests() []*CreateNoteRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchCreateNotesRequest) GetAllowPartialFailure() bool {
+	if x != nil {
+		return x.AllowPartialFailure
+	}
+	return false
+}
+
+type BatchUpdateNotesRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Requests            []*UpdateNoteRequest   `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	AllowPartialFailure bool                   `protobuf:"varint,2,opt,name=allow_partial_failure,json=allowPartialFailure,proto3" json:"allow_partial_failure,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchUpdateNotesRequest) Reset() {
+	*x = BatchUpdateNotesRequest{}
+	mi := &file_notes_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateNotesRequest) ProtoMessage() {}
+
+func (x *BatchUpdateNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateNotesRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *BatchUpdateNotesRequest) GetRequests() []*UpdateNoteRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchUpdateNotesRequest) GetAllowPartialFailure() bool {
+	if x != nil {
+		return x.AllowPartialFailure
+	}
+	return false
+}
+
+type BatchDeleteNotesRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Requests            []*DeleteNoteRequest   `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	AllowPartialFailure bool                   `protobuf:"varint,2,opt,name=allow_partial_failure,json=allowPartialFailure,proto3" json:"allow_partial_failure,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *BatchDeleteNotesRequest) Reset() {
+	*x = BatchDeleteNotesRequest{}
+	mi := &file_notes_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeleteNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeleteNotesRequest) ProtoMessage() {}
+
+func (x *BatchDeleteNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeleteNotesRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeleteNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *BatchDeleteNotesRequest) GetRequests() []*DeleteNoteRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+func (x *BatchDeleteNotesRequest) GetAllowPartialFailure() bool {
+	if x != nil {
+		return x.AllowPartialFailure
+	}
+	return false
+}
+
+type BatchNoteResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Code 0 when the item succeeded
+	Status *status.Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// Unset for deletes and failed items
+	Note *Note `protobuf:"bytes,2,opt,name=note,proto3" json:"note,omitempty"`
+	// Set for created notes, like NoteResponse.duplicates
+	Duplicates    []*DuplicateNote `protobuf:"bytes,3,rep,name=duplicates,proto3" json:"duplicates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchNoteResult) Reset() {
+	*x = BatchNoteResult{}
+	mi := &file_notes_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchNoteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchNoteResult) ProtoMessage() {}
+
+func (x *BatchNoteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchNoteResult.ProtoReflect.Descriptor instead.
+func (*BatchNoteResult) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *BatchNoteResult) GetStatus() *status.Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *BatchNoteResult) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *BatchNoteResult) GetDuplicates() []*DuplicateNote {
+	if x != nil {
+		return x.Duplicates
+	}
+	return nil
+}
+
+// One result per request item, in request order
+type BatchNotesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BatchNoteResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchNotesResponse) Reset() {
+	*x = BatchNotesResponse{}
+	mi := &file_notes_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchNotesResponse) ProtoMessage() {}
+
+func (x *BatchNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchNotesResponse.ProtoReflect.Descriptor instead.
+func (*BatchNotesResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *BatchNotesResponse) GetResults() []*BatchNoteResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Tags are stored lowercased with runs of whitespace collapsed to one space.
+// They nest with "/": "eng/backend/db" sits below "eng/backend". Tag filters,
+// renames, merges and deletes cover the nested tags too.
+// Tag calls work on every note when project_id is unset. With
+// authentication they only see or change notes the caller can read or edit.
+type TagCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The full path, e.g. "eng/backend/db"
+	Tag string `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	// Live notes carrying the tag
+	NoteCount int32 `protobuf:"varint,2,opt,name=note_count,json=noteCount,proto3" json:"note_count,omitempty"`
+	// Tree listings only: live notes carrying the tag or one nested below it
+	TotalCount int32 `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	// Tree listings only
+	Children      []*TagCount `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagCount) Reset() {
+	*x = TagCount{}
+	mi := &file_notes_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagCount) ProtoMessage() {}
+
+func (x *TagCount) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagCount.ProtoReflect.Descriptor instead.
+func (*TagCount) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *TagCount) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *TagCount) GetNoteCount() int32 {
+	if x != nil {
+		return x.NoteCount
+	}
+	return 0
+}
+
+func (x *TagCount) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *TagCount) GetChildren() []*TagCount {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type ListTagsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	// Only tags starting with prefix, for autocomplete
+	Prefix   string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	PageSize int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Nest tags under their parents with rolled-up counts. Parents that are
+	// never used on their own show up with a note_count of 0. page_size is
+	// ignored.
+	Tree          bool `protobuf:"varint,4,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsRequest) Reset() {
+	*x = ListTagsRequest{}
+	mi := &file_notes_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsRequest) ProtoMessage() {}
+
+func (x *ListTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsRequest.ProtoReflect.Descriptor instead.
+func (*ListTagsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListTagsRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListTagsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTagsRequest) GetTree() bool {
+	if x != nil {
+		return x.Tree
+	}
+	return false
+}
+
+// Most used tags first, or the top of the tree ordered by path
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*TagCount            `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_notes_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListTagsResponse) GetTags() []*TagCount {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// Renaming onto a tag that already exists merges the two. Nested tags move
+// along: renaming "eng" to "dev" turns "eng/db" into "dev/db".
+type RenameTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	NewTag        string                 `protobuf:"bytes,3,opt,name=new_tag,json=newTag,proto3" json:"new_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_notes_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagRequest) ProtoMessage() {}
+
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *RenameTagRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *RenameTagRequest) GetNewTag() string {
+	if x != nil {
+		return x.NewTag
+	}
+	return ""
+}
+
+type MergeTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	SourceTags    []string               `protobuf:"bytes,2,rep,name=source_tags,json=sourceTags,proto3" json:"source_tags,omitempty"`
+	TargetTag     string                 `protobuf:"bytes,3,opt,name=target_tag,json=targetTag,proto3" json:"target_tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_notes_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MergeTagsRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *MergeTagsRequest) GetSourceTags() []string {
+	if x != nil {
+		return x.SourceTags
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTargetTag() string {
+	if x != nil {
+		return x.TargetTag
+	}
+	return ""
+}
+
+type DeleteTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     *string                `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTagRequest) Reset() {
+	*x = DeleteTagRequest{}
+	mi := &file_notes_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTagRequest) ProtoMessage() {}
+
+func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DeleteTagRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *DeleteTagRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type TagChangeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NotesAffected int32                  `protobuf:"varint,1,opt,name=notes_affected,json=notesAffected,proto3" json:"notes_affected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagChangeResponse) Reset() {
+	*x = TagChangeResponse{}
+	mi := &file_notes_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagChangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagChangeResponse) ProtoMessage() {}
+
+func (x *TagChangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagChangeResponse.ProtoReflect.Descriptor instead.
+func (*TagChangeResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TagChangeResponse) GetNotesAffected() int32 {
+	if x != nil {
+		return x.NotesAffected
+	}
+	return 0
+}
+
+type SearchTitlesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Compared with titles by trigram similarity, so "deplyment gude" still
+	// finds "Deployment guide"
+	Query         string  `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	ProjectId     *string `protobuf:"bytes,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	PageSize      int32   `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTitlesRequest) Reset() {
+	*x = SearchTitlesRequest{}
+	mi := &file_notes_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTitlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTitlesRequest) ProtoMessage() {}
+
+func (x *SearchTitlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTitlesRequest.ProtoReflect.Descriptor instead.
+func (*SearchTitlesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SearchTitlesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchTitlesRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+func (x *SearchTitlesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type TitleMatch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Note  *Note                  `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// pg_trgm word similarity of the query to the title, 0 to 1
+	Similarity    float32 `protobuf:"fixed32,2,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TitleMatch) Reset() {
+	*x = TitleMatch{}
+	mi := &file_notes_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TitleMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TitleMatch) ProtoMessage() {}
+
+func (x *TitleMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TitleMatch.ProtoReflect.Descriptor instead.
+func (*TitleMatch) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *TitleMatch) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *TitleMatch) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+// A "did you mean" alternative to the whole query
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Kind          SuggestionKind         `protobuf:"varint,2,opt,name=kind,proto3,enum=notes.v1.SuggestionKind" json:"kind,omitempty"`
+	Similarity    float32                `protobuf:"fixed32,3,opt,name=similarity,proto3" json:"similarity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_notes_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetKind() SuggestionKind {
+	if x != nil {
+		return x.Kind
+	}
+	return SuggestionKind_SUGGESTION_KIND_UNSPECIFIED
+}
+
+func (x *Suggestion) GetSimilarity() float32 {
+	if x != nil {
+		return x.Similarity
+	}
+	return 0
+}
+
+type SearchTitlesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Best match first
+	Matches []*TitleMatch `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	// Existing titles and tags close to the query, best first
+	Suggestions   []*Suggestion `protobuf:"bytes,2,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTitlesResponse) Reset() {
+	*x = SearchTitlesResponse{}
+	mi := &file_notes_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTitlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTitlesResponse) ProtoMessage() {}
+
+func (x *SearchTitlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTitlesResponse.ProtoReflect.Descriptor instead.
+func (*SearchTitlesResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SearchTitlesResponse) GetMatches() []*TitleMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SearchTitlesResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetRelatedNotesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	NoteId string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
+	// 10 when unset, at most 50
+	Limit         int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedNotesRequest) Reset() {
+	*x = GetRelatedNotesRequest{}
+	mi := &file_notes_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedNotesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedNotesRequest) ProtoMessage() {}
+
+func (x *GetRelatedNotesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedNotesRequest.ProtoReflect.Descriptor instead.
+func (*GetRelatedNotesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetRelatedNotesRequest) GetNoteId() string {
+	if x != nil {
+		return x.NoteId
+	}
+	return ""
+}
+
+func (x *GetRelatedNotesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RelatedNote struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Note  *Note                  `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	// BM25 score against the source note's most distinctive terms, only
+	// comparable within one response
+	Score         float32 `protobuf:"fixed32,2,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelatedNote) Reset() {
+	*x = RelatedNote{}
+	mi := &file_notes_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelatedNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelatedNote) ProtoMessage() {}
+
+func (x *RelatedNote) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelatedNote.ProtoReflect.Descriptor instead.
+func (*RelatedNote) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *RelatedNote) GetNote() *Note {
+	if x != nil {
+		return x.Note
+	}
+	return nil
+}
+
+func (x *RelatedNote) GetScore() float32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type GetRelatedNotesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Most similar first
+	Notes         []*RelatedNote `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRelatedNotesResponse) Reset() {
+	*x = GetRelatedNotesResponse{}
+	mi := &file_notes_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRelatedNotesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRelatedNotesResponse) ProtoMessage() {}
+
+func (x *GetRelatedNotesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRelatedNotesResponse.ProtoReflect.Descriptor instead.
+func (*GetRelatedNotesResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *GetRelatedNotesResponse) GetNotes() []*RelatedNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+// A saved search is a named ListNotes request. Running it lists notes with
+// the runner's own access, so sharing a search never shares the notes it
+// finds. Viewers can run a shared search; only its owner can share or
+// delete it.
+type SavedSearch struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Owner *ActorRef              `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	// Filters, query and sort; page_size and page_token are not kept
+	Request       *ListNotesRequest      `protobuf:"bytes,4,opt,name=request,proto3" json:"request,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedSearch) Reset() {
+	*x = SavedSearch{}
+	mi := &file_notes_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedSearch) ProtoMessage() {}
+
+func (x *SavedSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedSearch.ProtoReflect.Descriptor instead.
+func (*SavedSearch) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *SavedSearch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SavedSearch) GetOwner() *ActorRef {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetRequest() *ListNotesRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SavedSearch) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateSavedSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Generated when empty
+	Id   *string `protobuf:"bytes,1,opt,name=id,proto3,oneof" json:"id,omitempty"`
+	Name string  `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Relative days such as updated:>=today-7d are resolved on every run
+	Request       *ListNotesRequest `protobuf:"bytes,3,opt,name=request,proto3" json:"request,omitempty"`
+	Owner         *ActorRef         `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSavedSearchRequest) Reset() {
+	*x = CreateSavedSearchRequest{}
+	mi := &file_notes_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSavedSearchRequest) ProtoMessage() {}
+
+func (x *CreateSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*CreateSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateSavedSearchRequest) GetId() string {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return ""
+}
+
+func (x *CreateSavedSearchRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSavedSearchRequest) GetRequest() *ListNotesRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *CreateSavedSearchRequest) GetOwner() *ActorRef {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+type SavedSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SavedSearch   *SavedSearch           `protobuf:"bytes,1,opt,name=saved_search,json=savedSearch,proto3" json:"saved_search,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SavedSearchResponse) Reset() {
+	*x = SavedSearchResponse{}
+	mi := &file_notes_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SavedSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SavedSearchResponse) ProtoMessage() {}
+
+func (x *SavedSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SavedSearchResponse.ProtoReflect.Descriptor instead.
+func (*SavedSearchResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *SavedSearchResponse) GetSavedSearch() *SavedSearch {
+	if x != nil {
+		return x.SavedSearch
+	}
+	return nil
+}
+
+// Saved searches the caller owns or has been granted, ordered by name
+type ListSavedSearchesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchesRequest) Reset() {
+	*x = ListSavedSearchesRequest{}
+	mi := &file_notes_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavedSearchesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavedSearchesRequest) ProtoMessage() {}
+
+func (x *ListSavedSearchesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavedSearchesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListSavedSearchesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListSavedSearchesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListSavedSearchesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SavedSearches []*SavedSearch         `protobuf:"bytes,1,rep,name=saved_searches,json=savedSearches,proto3" json:"saved_searches,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavedSearchesResponse) Reset() {
+	*x = ListSavedSearchesResponse{}
+	mi := &file_notes_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateNoteRequest) String() string {
+func (x *ListSavedSearchesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateNoteRequest) ProtoMessage() {}
+func (*ListSavedSearchesResponse) ProtoMessage() {}
 
-func (x *UpdateNoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[7]
+func (x *ListSavedSearchesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -669,97 +5168,121 @@ func (x *UpdateNoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateNoteRequest.ProtoReflect.Descriptor instead.
-func (*UpdateNoteRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ListSavedSearchesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavedSearchesResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *UpdateNoteRequest) GetNoteId() string {
+func (x *ListSavedSearchesResponse) GetSavedSearches() []*SavedSearch {
 	if x != nil {
-		return x.NoteId
+		return x.SavedSearches
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateNoteRequest) GetTitle() string {
+func (x *ListSavedSearchesResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.Title
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *UpdateNoteRequest) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
+type RunSavedSearchRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PageSize  int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Only count the matching notes, e.g. for sidebar badges
+	CountOnly     bool `protobuf:"varint,4,opt,name=count_only,json=countOnly,proto3" json:"count_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateNoteRequest) GetTags() []string {
-	if x != nil {
-		return x.Tags
-	}
-	return nil
+func (x *RunSavedSearchRequest) Reset() {
+	*x = RunSavedSearchRequest{}
+	mi := &file_notes_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateNoteRequest) GetIsPinned() bool {
+func (x *RunSavedSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunSavedSearchRequest) ProtoMessage() {}
+
+func (x *RunSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[75]
 	if x != nil {
-		return x.IsPinned
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateNoteRequest) GetAttachments() []*Attachment {
+// Deprecated: Use RunSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*RunSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RunSavedSearchRequest) GetId() string {
 	if x != nil {
-		return x.Attachments
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateNoteRequest) GetUser() *ActorRef {
+func (x *RunSavedSearchRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.User
+		return x.PageSize
 	}
-	return nil
+	return 0
 }
 
-func (x *UpdateNoteRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+func (x *RunSavedSearchRequest) GetPageToken() string {
 	if x != nil {
-		return x.UpdateMask
+		return x.PageToken
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateNoteRequest) GetIfMatchUpdatedAt() *timestamppb.Timestamp {
+func (x *RunSavedSearchRequest) GetCountOnly() bool {
 	if x != nil {
-		return x.IfMatchUpdatedAt
+		return x.CountOnly
 	}
-	return nil
+	return false
 }
 
-type DeleteNoteRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
-	HardDelete    *bool                  `protobuf:"varint,2,opt,name=hard_delete,json=hardDelete,proto3,oneof" json:"hard_delete,omitempty"`
+type RunSavedSearchResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// As in ListNotesResponse; empty with count_only
+	Notes         []*Note         `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
+	NextPageToken string          `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Results       []*SearchResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	// Set with count_only
+	Count         int64 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteNoteRequest) Reset() {
-	*x = DeleteNoteRequest{}
-	mi := &file_notes_proto_msgTypes[8]
+func (x *RunSavedSearchResponse) Reset() {
+	*x = RunSavedSearchResponse{}
+	mi := &file_notes_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteNoteRequest) String() string {
+func (x *RunSavedSearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteNoteRequest) ProtoMessage() {}
+func (*RunSavedSearchResponse) ProtoMessage() {}
 
-func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[8]
+func (x *RunSavedSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -770,47 +5293,61 @@ func (x *DeleteNoteRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteNoteRequest.ProtoReflect.Descriptor instead.
-func (*DeleteNoteRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use RunSavedSearchResponse.ProtoReflect.Descriptor instead.
+func (*RunSavedSearchResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *DeleteNoteRequest) GetNoteId() string {
+func (x *RunSavedSearchResponse) GetNotes() []*Note {
 	if x != nil {
-		return x.NoteId
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *RunSavedSearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *DeleteNoteRequest) GetHardDelete() bool {
-	if x != nil && x.HardDelete != nil {
-		return *x.HardDelete
+func (x *RunSavedSearchResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
 	}
-	return false
+	return nil
 }
 
-type NoteResponse struct {
+func (x *RunSavedSearchResponse) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type DeleteSavedSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Note          *Note                  `protobuf:"bytes,1,opt,name=note,proto3" json:"note,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NoteResponse) Reset() {
-	*x = NoteResponse{}
-	mi := &file_notes_proto_msgTypes[9]
+func (x *DeleteSavedSearchRequest) Reset() {
+	*x = DeleteSavedSearchRequest{}
+	mi := &file_notes_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NoteResponse) String() string {
+func (x *DeleteSavedSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NoteResponse) ProtoMessage() {}
+func (*DeleteSavedSearchRequest) ProtoMessage() {}
 
-func (x *NoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[9]
+func (x *DeleteSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -821,41 +5358,40 @@ func (x *NoteResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NoteResponse.ProtoReflect.Descriptor instead.
-func (*NoteResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use DeleteSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *NoteResponse) GetNote() *Note {
+func (x *DeleteSavedSearchRequest) GetId() string {
 	if x != nil {
-		return x.Note
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type ListNotesResponse struct {
+type DeleteSavedSearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Notes         []*Note                `protobuf:"bytes,1,rep,name=notes,proto3" json:"notes,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	Deleted       bool                   `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNotesResponse) Reset() {
-	*x = ListNotesResponse{}
-	mi := &file_notes_proto_msgTypes[10]
+func (x *DeleteSavedSearchResponse) Reset() {
+	*x = DeleteSavedSearchResponse{}
+	mi := &file_notes_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNotesResponse) String() string {
+func (x *DeleteSavedSearchResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNotesResponse) ProtoMessage() {}
+func (*DeleteSavedSearchResponse) ProtoMessage() {}
 
-func (x *ListNotesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[10]
+func (x *DeleteSavedSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -866,49 +5402,42 @@ func (x *ListNotesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNotesResponse.ProtoReflect.Descriptor instead.
-func (*ListNotesResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{10}
-}
-
-func (x *ListNotesResponse) GetNotes() []*Note {
-	if x != nil {
-		return x.Notes
-	}
-	return nil
+// Deprecated: Use DeleteSavedSearchResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSavedSearchResponse) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *ListNotesResponse) GetNextPageToken() string {
+func (x *DeleteSavedSearchResponse) GetDeleted() bool {
 	if x != nil {
-		return x.NextPageToken
+		return x.Deleted
 	}
-	return ""
+	return false
 }
 
-type ListNoteRevisionsRequest struct {
+type ShareSavedSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NoteId        string                 `protobuf:"bytes,1,opt,name=note_id,json=noteId,proto3" json:"note_id,omitempty"`
-	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	SavedSearchId string                 `protobuf:"bytes,1,opt,name=saved_search_id,json=savedSearchId,proto3" json:"saved_search_id,omitempty"`
+	Principal     *Principal             `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
+	Role          Role                   `protobuf:"varint,3,opt,name=role,proto3,enum=notes.v1.Role" json:"role,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNoteRevisionsRequest) Reset() {
-	*x = ListNoteRevisionsRequest{}
-	mi := &file_notes_proto_msgTypes[11]
+func (x *ShareSavedSearchRequest) Reset() {
+	*x = ShareSavedSearchRequest{}
+	mi := &file_notes_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNoteRevisionsRequest) String() string {
+func (x *ShareSavedSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNoteRevisionsRequest) ProtoMessage() {}
+func (*ShareSavedSearchRequest) ProtoMessage() {}
 
-func (x *ListNoteRevisionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[11]
+func (x *ShareSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -919,55 +5448,55 @@ func (x *ListNoteRevisionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNoteRevisionsRequest.ProtoReflect.Descriptor instead.
-func (*ListNoteRevisionsRequest) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ShareSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*ShareSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *ListNoteRevisionsRequest) GetNoteId() string {
+func (x *ShareSavedSearchRequest) GetSavedSearchId() string {
 	if x != nil {
-		return x.NoteId
+		return x.SavedSearchId
 	}
 	return ""
 }
 
-func (x *ListNoteRevisionsRequest) GetPageSize() int32 {
+func (x *ShareSavedSearchRequest) GetPrincipal() *Principal {
 	if x != nil {
-		return x.PageSize
+		return x.Principal
 	}
-	return 0
+	return nil
 }
 
-func (x *ListNoteRevisionsRequest) GetPageToken() string {
+func (x *ShareSavedSearchRequest) GetRole() Role {
 	if x != nil {
-		return x.PageToken
+		return x.Role
 	}
-	return ""
+	return Role_ROLE_UNSPECIFIED
 }
 
-type ListNoteRevisionsResponse struct {
+type UnshareSavedSearchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Revisions     []*NoteRevision        `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	SavedSearchId string                 `protobuf:"bytes,1,opt,name=saved_search_id,json=savedSearchId,proto3" json:"saved_search_id,omitempty"`
+	Principal     *Principal             `protobuf:"bytes,2,opt,name=principal,proto3" json:"principal,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNoteRevisionsResponse) Reset() {
-	*x = ListNoteRevisionsResponse{}
-	mi := &file_notes_proto_msgTypes[12]
+func (x *UnshareSavedSearchRequest) Reset() {
+	*x = UnshareSavedSearchRequest{}
+	mi := &file_notes_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNoteRevisionsResponse) String() string {
+func (x *UnshareSavedSearchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNoteRevisionsResponse) ProtoMessage() {}
+func (*UnshareSavedSearchRequest) ProtoMessage() {}
 
-func (x *ListNoteRevisionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[12]
+func (x *UnshareSavedSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_notes_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -978,23 +5507,23 @@ func (x *ListNoteRevisionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNoteRevisionsResponse.ProtoReflect.Descriptor instead.
-func (*ListNoteRevisionsResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use UnshareSavedSearchRequest.ProtoReflect.Descriptor instead.
+func (*UnshareSavedSearchRequest) Descriptor() ([]byte, []int) {
+	return file_notes_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *ListNoteRevisionsResponse) GetRevisions() []*NoteRevision {
+func (x *UnshareSavedSearchRequest) GetSavedSearchId() string {
 	if x != nil {
-		return x.Revisions
+		return x.SavedSearchId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListNoteRevisionsResponse) GetNextPageToken() string {
+func (x *UnshareSavedSearchRequest) GetPrincipal() *Principal {
 	if x != nil {
-		return x.NextPageToken
+		return x.Principal
 	}
-	return ""
+	return nil
 }
 
 type DeleteNoteResponse struct {
@@ -1006,7 +5535,7 @@ type DeleteNoteResponse struct {
 
 func (x *DeleteNoteResponse) Reset() {
 	*x = DeleteNoteResponse{}
-	mi := &file_notes_proto_msgTypes[13]
+	mi := &file_notes_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1018,7 +5547,7 @@ func (x *DeleteNoteResponse) String() string {
 func (*DeleteNoteResponse) ProtoMessage() {}
 
 func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_notes_proto_msgTypes[13]
+	mi := &file_notes_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,7 +5560,7 @@ func (x *DeleteNoteResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteNoteResponse.ProtoReflect.Descriptor instead.
 func (*DeleteNoteResponse) Descriptor() ([]byte, []int) {
-	return file_notes_proto_rawDescGZIP(), []int{13}
+	return file_notes_proto_rawDescGZIP(), []int{81}
 }
 
 func (x *DeleteNoteResponse) GetSuccess() bool {
@@ -1045,14 +5574,14 @@ var File_notes_proto protoreflect.FileDescriptor
 
 const file_notes_proto_rawDesc = "" +
 	"\n" +
-	"\vnotes.proto\x12\bnotes.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\"\x86\x01\n" +
+	"\vnotes.proto\x12\bnotes.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x17google/rpc/status.proto\"\x86\x01\n" +
 	"\bActorRef\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
 	"\fdisplay_name\x18\x02 \x01(\tH\x00R\vdisplayName\x88\x01\x01\x12\"\n" +
 	"\n" +
 	"avatar_url\x18\x03 \x01(\tH\x01R\tavatarUrl\x88\x01\x01B\x0f\n" +
 	"\r_display_nameB\r\n" +
-	"\v_avatar_url\"\xd1\x03\n" +
+	"\v_avatar_url\"\x9d\x05\n" +
 	"\x04Note\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
 	"\n" +
@@ -1068,10 +5597,17 @@ const file_notes_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\r\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\n" +
+	"deleted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampH\x02R\tdeletedAt\x88\x01\x01\x121\n" +
+	"\n" +
+	"deleted_by\x18\r \x01(\v2\x12.notes.v1.ActorRefR\tdeletedBy\x12,\n" +
+	"\x12has_more_revisions\x18\x0e \x01(\bR\x10hasMoreRevisions\x12\x1a\n" +
+	"\blanguage\x18\x0f \x01(\tR\blanguageB\r\n" +
 	"\v_project_idB\n" +
 	"\n" +
-	"\b_contentJ\x04\bd\x10x\"\xb3\x01\n" +
+	"\b_contentB\r\n" +
+	"\v_deleted_atJ\x04\bd\x10x\"\xb3\x01\n" +
 	"\fNoteRevision\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -1091,10 +5627,30 @@ const file_notes_proto_rawDesc = "" +
 	"size_bytes\x18\a \x01(\x03H\x01R\tsizeBytes\x88\x01\x01B\t\n" +
 	"\a_sha256B\r\n" +
 	"\v_size_bytes\"~\n" +
+	"\x17UploadAttachmentRequest\x12@\n" +
+	"\bmetadata\x18\x01 \x01(\v2\".notes.v1.UploadAttachmentMetadataH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xbd\x01\n" +
+	"\x18UploadAttachmentMetadata\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_type\x18\x03 \x01(\tR\bfileType\x12&\n" +
+	"\x04user\x18\x04 \x01(\v2\x12.notes.v1.ActorRefR\x04user\x12\x1b\n" +
+	"\x06sha256\x18\x05 \x01(\tH\x00R\x06sha256\x88\x01\x01B\t\n" +
+	"\a_sha256\"@\n" +
+	"\x19DownloadAttachmentRequest\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\"s\n" +
+	"\x1aDownloadAttachmentResponse\x122\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x14.notes.v1.AttachmentH\x00R\bmetadata\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\t\n" +
+	"\apayload\"\xe9\x01\n" +
 	"\x0eGetNoteRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
 	"\x11include_revisions\x18\x02 \x01(\bR\x10includeRevisions\x12/\n" +
-	"\x13include_attachments\x18\x03 \x01(\bR\x12includeAttachments\"\xaa\x02\n" +
+	"\x13include_attachments\x18\x03 \x01(\bR\x12includeAttachments\x12'\n" +
+	"\x0finclude_deleted\x18\x04 \x01(\bR\x0eincludeDeleted\x12,\n" +
+	"\x0frevisions_limit\x18\x05 \x01(\x05H\x00R\x0erevisionsLimit\x88\x01\x01B\x12\n" +
+	"\x10_revisions_limit\"\xd4\x03\n" +
 	"\x10ListNotesRequest\x12\"\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1c\n" +
@@ -1104,7 +5660,13 @@ const file_notes_proto_rawDesc = "" +
 	"\tsort_desc\x18\x05 \x01(\bH\x04R\bsortDesc\x88\x01\x01\x12\x1b\n" +
 	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\a \x01(\tR\tpageTokenB\r\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\x12'\n" +
+	"\x0finclude_deleted\x18\b \x01(\bR\x0eincludeDeleted\x12\x19\n" +
+	"\btags_any\x18\t \x03(\tR\atagsAny\x12\x19\n" +
+	"\btags_all\x18\n" +
+	" \x03(\tR\atagsAll\x12\x1b\n" +
+	"\ttags_none\x18\v \x03(\tR\btagsNone\x12\x1f\n" +
+	"\blanguage\x18\f \x01(\tH\x05R\blanguage\x88\x01\x01B\r\n" +
 	"\v_project_idB\n" +
 	"\n" +
 	"\b_user_idB\b\n" +
@@ -1112,7 +5674,8 @@ const file_notes_proto_rawDesc = "" +
 	"\n" +
 	"\b_sort_byB\f\n" +
 	"\n" +
-	"_sort_desc\"\xde\x02\n" +
+	"_sort_descB\v\n" +
+	"\t_language\"\xb9\x03\n" +
 	"\x11CreateNoteRequest\x12\"\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x14\n" +
@@ -1122,11 +5685,15 @@ const file_notes_proto_rawDesc = "" +
 	"\x04tags\x18\x05 \x03(\tR\x04tags\x126\n" +
 	"\vattachments\x18\x06 \x03(\v2\x14.notes.v1.AttachmentR\vattachments\x12*\n" +
 	"\x06author\x18\a \x01(\v2\x12.notes.v1.ActorRefR\x06author\x12,\n" +
-	"\x0fidempotency_key\x18\b \x01(\tH\x02R\x0eidempotencyKey\x88\x01\x01B\r\n" +
+	"\x0fidempotency_key\x18\b \x01(\tH\x02R\x0eidempotencyKey\x88\x01\x01\x12\x1f\n" +
+	"\blanguage\x18\t \x01(\tH\x03R\blanguage\x88\x01\x01\x12+\n" +
+	"\x11reject_duplicates\x18\n" +
+	" \x01(\bR\x10rejectDuplicatesB\r\n" +
 	"\v_project_idB\n" +
 	"\n" +
 	"\b_contentB\x12\n" +
-	"\x10_idempotency_key\"\x92\x03\n" +
+	"\x10_idempotency_keyB\v\n" +
+	"\t_language\"\xcd\x03\n" +
 	"\x11UpdateNoteRequest\x12\x17\n" +
 	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
@@ -1137,18 +5704,63 @@ const file_notes_proto_rawDesc = "" +
 	"\x04user\x18\a \x01(\v2\x12.notes.v1.ActorRefR\x04user\x12;\n" +
 	"\vupdate_mask\x18\b \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
 	"updateMask\x12N\n" +
-	"\x13if_match_updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x10ifMatchUpdatedAt\x88\x01\x01B\x16\n" +
-	"\x14_if_match_updated_at\"b\n" +
+	"\x13if_match_updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x10ifMatchUpdatedAt\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\n" +
+	" \x01(\tR\tprojectId\x12\x1a\n" +
+	"\blanguage\x18\v \x01(\tR\blanguageB\x16\n" +
+	"\x14_if_match_updated_at\"\x9c\x01\n" +
+	"\x10MoveNotesRequest\x12\x19\n" +
+	"\bnote_ids\x18\x01 \x03(\tR\anoteIds\x12/\n" +
+	"\x11target_project_id\x18\x02 \x01(\tH\x00R\x0ftargetProjectId\x88\x01\x01\x12&\n" +
+	"\x04user\x18\x03 \x01(\v2\x12.notes.v1.ActorRefR\x04userB\x14\n" +
+	"\x12_target_project_id\"9\n" +
+	"\x11MoveNotesResponse\x12$\n" +
+	"\x05notes\x18\x01 \x03(\v2\x0e.notes.v1.NoteR\x05notes\"\x8a\x01\n" +
 	"\x11DeleteNoteRequest\x12\x17\n" +
 	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12$\n" +
 	"\vhard_delete\x18\x02 \x01(\bH\x00R\n" +
-	"hardDelete\x88\x01\x01B\x0e\n" +
-	"\f_hard_delete\"2\n" +
+	"hardDelete\x88\x01\x01\x12&\n" +
+	"\x04user\x18\x03 \x01(\v2\x12.notes.v1.ActorRefR\x04userB\x0e\n" +
+	"\f_hard_delete\"\xab\x01\n" +
+	"\x10ListTrashRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\tH\x01R\x06userId\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageTokenB\r\n" +
+	"\v_project_idB\n" +
+	"\n" +
+	"\b_user_id\"-\n" +
+	"\x12RestoreNoteRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\"+\n" +
+	"\x10PurgeNoteRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\"S\n" +
+	"\rDuplicateNote\x12\"\n" +
+	"\x04note\x18\x01 \x01(\v2\x0e.notes.v1.NoteR\x04note\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x02R\n" +
+	"similarity\"k\n" +
 	"\fNoteResponse\x12\"\n" +
-	"\x04note\x18\x01 \x01(\v2\x0e.notes.v1.NoteR\x04note\"a\n" +
+	"\x04note\x18\x01 \x01(\v2\x0e.notes.v1.NoteR\x04note\x127\n" +
+	"\n" +
+	"duplicates\x18\x02 \x03(\v2\x17.notes.v1.DuplicateNoteR\n" +
+	"duplicates\"\x93\x01\n" +
 	"\x11ListNotesResponse\x12$\n" +
 	"\x05notes\x18\x01 \x03(\v2\x0e.notes.v1.NoteR\x05notes\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"o\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x120\n" +
+	"\aresults\x18\x03 \x03(\v2\x16.notes.v1.SearchResultR\aresults\"3\n" +
+	"\tTextRange\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\"\x8a\x01\n" +
+	"\fSearchResult\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x02R\x04rank\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\x123\n" +
+	"\n" +
+	"highlights\x18\x04 \x03(\v2\x13.notes.v1.TextRangeR\n" +
+	"highlights\"o\n" +
 	"\x18ListNoteRevisionsRequest\x12\x17\n" +
 	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x1b\n" +
 	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
@@ -1156,19 +5768,359 @@ const file_notes_proto_rawDesc = "" +
 	"page_token\x18\x03 \x01(\tR\tpageToken\"y\n" +
 	"\x19ListNoteRevisionsResponse\x124\n" +
 	"\trevisions\x18\x01 \x03(\v2\x16.notes.v1.NoteRevisionR\trevisions\x12&\n" +
-	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\".\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xac\x02\n" +
+	"\x18DiffNoteRevisionsRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12(\n" +
+	"\x10from_revision_id\x18\x02 \x01(\tR\x0efromRevisionId\x12)\n" +
+	"\x0eto_revision_id\x18\x03 \x01(\tH\x00R\ftoRevisionId\x88\x01\x01\x12;\n" +
+	"\vgranularity\x18\x04 \x01(\x0e2\x19.notes.v1.DiffGranularityR\vgranularity\x12\x1d\n" +
+	"\acontext\x18\x05 \x01(\x05H\x01R\acontext\x88\x01\x01\x12'\n" +
+	"\x0finclude_unified\x18\x06 \x01(\bR\x0eincludeUnifiedB\x11\n" +
+	"\x0f_to_revision_idB\n" +
+	"\n" +
+	"\b_context\"w\n" +
+	"\bDiffSpan\x12%\n" +
+	"\x02op\x18\x01 \x01(\x0e2\x15.notes.v1.DiffSpan.OpR\x02op\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"0\n" +
+	"\x02Op\x12\f\n" +
+	"\bOP_EQUAL\x10\x00\x12\r\n" +
+	"\tOP_INSERT\x10\x01\x12\r\n" +
+	"\tOP_DELETE\x10\x02\"\xa8\x01\n" +
+	"\bDiffHunk\x12\x1d\n" +
+	"\n" +
+	"from_start\x18\x01 \x01(\x05R\tfromStart\x12\x1d\n" +
+	"\n" +
+	"from_count\x18\x02 \x01(\x05R\tfromCount\x12\x19\n" +
+	"\bto_start\x18\x03 \x01(\x05R\atoStart\x12\x19\n" +
+	"\bto_count\x18\x04 \x01(\x05R\atoCount\x12(\n" +
+	"\x05spans\x18\x05 \x03(\v2\x12.notes.v1.DiffSpanR\x05spans\"\xac\x01\n" +
+	"\x19DiffNoteRevisionsResponse\x123\n" +
+	"\vtitle_hunks\x18\x01 \x03(\v2\x12.notes.v1.DiffHunkR\n" +
+	"titleHunks\x127\n" +
+	"\rcontent_hunks\x18\x02 \x03(\v2\x12.notes.v1.DiffHunkR\fcontentHunks\x12!\n" +
+	"\funified_diff\x18\x03 \x01(\tR\vunifiedDiff\"\xe6\x01\n" +
+	"\x1aRestoreNoteRevisionRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x1f\n" +
+	"\vrevision_id\x18\x02 \x01(\tR\n" +
+	"revisionId\x12&\n" +
+	"\x04user\x18\x03 \x01(\v2\x12.notes.v1.ActorRefR\x04user\x12N\n" +
+	"\x13if_match_updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x10ifMatchUpdatedAt\x88\x01\x01B\x16\n" +
+	"\x14_if_match_updated_at\"\xad\x01\n" +
+	"\x11WatchNotesRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12 \n" +
+	"\tauthor_id\x18\x02 \x01(\tH\x01R\bauthorId\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\x03 \x01(\tH\x02R\x03tag\x88\x01\x01\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursorB\r\n" +
+	"\v_project_idB\f\n" +
+	"\n" +
+	"_author_idB\x06\n" +
+	"\x04_tag\"\xae\x02\n" +
+	"\tNoteEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.notes.v1.NoteEventTypeR\x04type\x12\x17\n" +
+	"\anote_id\x18\x03 \x01(\tR\x06noteId\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1b\n" +
+	"\tauthor_id\x18\x05 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\"\n" +
+	"\x04note\x18\b \x01(\v2\x0e.notes.v1.NoteR\x04noteB\r\n" +
+	"\v_project_id\"H\n" +
+	"\tPrincipal\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.notes.v1.PrincipalTypeR\x04type\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xcc\x01\n" +
+	"\x05Grant\x121\n" +
+	"\tprincipal\x18\x01 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\x12\"\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x0e.notes.v1.RoleR\x04role\x12\"\n" +
+	"\n" +
+	"granted_by\x18\x03 \x01(\tH\x00R\tgrantedBy\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_granted_by\"\x82\x01\n" +
+	"\x10ShareNoteRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x121\n" +
+	"\tprincipal\x18\x02 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\x12\"\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x0e.notes.v1.RoleR\x04role\"`\n" +
+	"\x12UnshareNoteRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x121\n" +
+	"\tprincipal\x18\x02 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\"0\n" +
+	"\x15ListNoteGrantsRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\"\x8b\x01\n" +
+	"\x13ShareProjectRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x121\n" +
+	"\tprincipal\x18\x02 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\x12\"\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x0e.notes.v1.RoleR\x04role\"i\n" +
+	"\x15UnshareProjectRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x121\n" +
+	"\tprincipal\x18\x02 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\"9\n" +
+	"\x18ListProjectGrantsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\"=\n" +
+	"\x12ListGrantsResponse\x12'\n" +
+	"\x06grants\x18\x01 \x03(\v2\x0f.notes.v1.GrantR\x06grants\"+\n" +
+	"\x0fUnshareResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\bR\aremoved\"\xa3\x03\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12(\n" +
+	"\x05owner\x18\x04 \x01(\v2\x12.notes.v1.ActorRefR\x05owner\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12@\n" +
+	"\varchived_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x01R\n" +
+	"archivedAt\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"note_count\x18\b \x01(\x03R\tnoteCount\x12\x1f\n" +
+	"\blanguage\x18\t \x01(\tH\x02R\blanguage\x88\x01\x01B\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_archived_atB\v\n" +
+	"\t_language\"\xd5\x01\n" +
+	"\x14CreateProjectRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12(\n" +
+	"\x05owner\x18\x04 \x01(\v2\x12.notes.v1.ActorRefR\x05owner\x12\x1f\n" +
+	"\blanguage\x18\x05 \x01(\tH\x02R\blanguage\x88\x01\x01B\x05\n" +
+	"\x03_idB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_language\"#\n" +
+	"\x11GetProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"|\n" +
+	"\x13ListProjectsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12)\n" +
+	"\x10include_archived\x18\x03 \x01(\bR\x0fincludeArchived\"m\n" +
+	"\x14ListProjectsResponse\x12-\n" +
+	"\bprojects\x18\x01 \x03(\v2\x11.notes.v1.ProjectR\bprojects\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb5\x01\n" +
+	"\x14UpdateProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12;\n" +
+	"\vupdate_mask\x18\x04 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\"'\n" +
+	"\x15ArchiveProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x0fProjectResponse\x12+\n" +
+	"\aproject\x18\x01 \x01(\v2\x11.notes.v1.ProjectR\aproject\"\xb6\x01\n" +
+	"\x14BatchGetNotesRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\x12/\n" +
+	"\x13include_attachments\x18\x02 \x01(\bR\x12includeAttachments\x12'\n" +
+	"\x0finclude_deleted\x18\x03 \x01(\bR\x0eincludeDeleted\x122\n" +
+	"\x15allow_partial_failure\x18\x04 \x01(\bR\x13allowPartialFailure\"\x86\x01\n" +
+	"\x17BatchCreateNotesRequest\x127\n" +
+	"\brequests\x18\x01 \x03(\v2\x1b.notes.v1.CreateNoteRequestR\brequests\x122\n" +
+	"\x15allow_partial_failure\x18\x02 \x01(\bR\x13allowPartialFailure\"\x86\x01\n" +
+	"\x17BatchUpdateNotesRequest\x127\n" +
+	"\brequests\x18\x01 \x03(\v2\x1b.notes.v1.UpdateNoteRequestR\brequests\x122\n" +
+	"\x15allow_partial_failure\x18\x02 \x01(\bR\x13allowPartialFailure\"\x86\x01\n" +
+	"\x17BatchDeleteNotesRequest\x127\n" +
+	"\brequests\x18\x01 \x03(\v2\x1b.notes.v1.DeleteNoteRequestR\brequests\x122\n" +
+	"\x15allow_partial_failure\x18\x02 \x01(\bR\x13allowPartialFailure\"\x9a\x01\n" +
+	"\x0fBatchNoteResult\x12*\n" +
+	"\x06status\x18\x01 \x01(\v2\x12.google.rpc.StatusR\x06status\x12\"\n" +
+	"\x04note\x18\x02 \x01(\v2\x0e.notes.v1.NoteR\x04note\x127\n" +
+	"\n" +
+	"duplicates\x18\x03 \x03(\v2\x17.notes.v1.DuplicateNoteR\n" +
+	"duplicates\"I\n" +
+	"\x12BatchNotesResponse\x123\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.notes.v1.BatchNoteResultR\aresults\"\x8c\x01\n" +
+	"\bTagCount\x12\x10\n" +
+	"\x03tag\x18\x01 \x01(\tR\x03tag\x12\x1d\n" +
+	"\n" +
+	"note_count\x18\x02 \x01(\x05R\tnoteCount\x12\x1f\n" +
+	"\vtotal_count\x18\x03 \x01(\x05R\n" +
+	"totalCount\x12.\n" +
+	"\bchildren\x18\x04 \x03(\v2\x12.notes.v1.TagCountR\bchildren\"\x8d\x01\n" +
+	"\x0fListTagsRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x12\n" +
+	"\x04tree\x18\x04 \x01(\bR\x04treeB\r\n" +
+	"\v_project_id\":\n" +
+	"\x10ListTagsResponse\x12&\n" +
+	"\x04tags\x18\x01 \x03(\v2\x12.notes.v1.TagCountR\x04tags\"p\n" +
+	"\x10RenameTagRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tag\x12\x17\n" +
+	"\anew_tag\x18\x03 \x01(\tR\x06newTagB\r\n" +
+	"\v_project_id\"\x85\x01\n" +
+	"\x10MergeTagsRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1f\n" +
+	"\vsource_tags\x18\x02 \x03(\tR\n" +
+	"sourceTags\x12\x1d\n" +
+	"\n" +
+	"target_tag\x18\x03 \x01(\tR\ttargetTagB\r\n" +
+	"\v_project_id\"W\n" +
+	"\x10DeleteTagRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x10\n" +
+	"\x03tag\x18\x02 \x01(\tR\x03tagB\r\n" +
+	"\v_project_id\":\n" +
+	"\x11TagChangeResponse\x12%\n" +
+	"\x0enotes_affected\x18\x01 \x01(\x05R\rnotesAffected\"{\n" +
+	"\x13SearchTitlesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\tH\x00R\tprojectId\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSizeB\r\n" +
+	"\v_project_id\"P\n" +
+	"\n" +
+	"TitleMatch\x12\"\n" +
+	"\x04note\x18\x01 \x01(\v2\x0e.notes.v1.NoteR\x04note\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x02 \x01(\x02R\n" +
+	"similarity\"n\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12,\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x18.notes.v1.SuggestionKindR\x04kind\x12\x1e\n" +
+	"\n" +
+	"similarity\x18\x03 \x01(\x02R\n" +
+	"similarity\"~\n" +
+	"\x14SearchTitlesResponse\x12.\n" +
+	"\amatches\x18\x01 \x03(\v2\x14.notes.v1.TitleMatchR\amatches\x126\n" +
+	"\vsuggestions\x18\x02 \x03(\v2\x14.notes.v1.SuggestionR\vsuggestions\"G\n" +
+	"\x16GetRelatedNotesRequest\x12\x17\n" +
+	"\anote_id\x18\x01 \x01(\tR\x06noteId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"G\n" +
+	"\vRelatedNote\x12\"\n" +
+	"\x04note\x18\x01 \x01(\v2\x0e.notes.v1.NoteR\x04note\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x02R\x05score\"F\n" +
+	"\x17GetRelatedNotesResponse\x12+\n" +
+	"\x05notes\x18\x01 \x03(\v2\x15.notes.v1.RelatedNoteR\x05notes\"\x87\x02\n" +
+	"\vSavedSearch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12(\n" +
+	"\x05owner\x18\x03 \x01(\v2\x12.notes.v1.ActorRefR\x05owner\x124\n" +
+	"\arequest\x18\x04 \x01(\v2\x1a.notes.v1.ListNotesRequestR\arequest\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xaa\x01\n" +
+	"\x18CreateSavedSearchRequest\x12\x13\n" +
+	"\x02id\x18\x01 \x01(\tH\x00R\x02id\x88\x01\x01\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x124\n" +
+	"\arequest\x18\x03 \x01(\v2\x1a.notes.v1.ListNotesRequestR\arequest\x12(\n" +
+	"\x05owner\x18\x04 \x01(\v2\x12.notes.v1.ActorRefR\x05ownerB\x05\n" +
+	"\x03_id\"O\n" +
+	"\x13SavedSearchResponse\x128\n" +
+	"\fsaved_search\x18\x01 \x01(\v2\x15.notes.v1.SavedSearchR\vsavedSearch\"V\n" +
+	"\x18ListSavedSearchesRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"\x81\x01\n" +
+	"\x19ListSavedSearchesResponse\x12<\n" +
+	"\x0esaved_searches\x18\x01 \x03(\v2\x15.notes.v1.SavedSearchR\rsavedSearches\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x82\x01\n" +
+	"\x15RunSavedSearchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12\x1d\n" +
+	"\n" +
+	"count_only\x18\x04 \x01(\bR\tcountOnly\"\xae\x01\n" +
+	"\x16RunSavedSearchResponse\x12$\n" +
+	"\x05notes\x18\x01 \x03(\v2\x0e.notes.v1.NoteR\x05notes\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x120\n" +
+	"\aresults\x18\x03 \x03(\v2\x16.notes.v1.SearchResultR\aresults\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x03R\x05count\"*\n" +
+	"\x18DeleteSavedSearchRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x19DeleteSavedSearchResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\bR\adeleted\"\x98\x01\n" +
+	"\x17ShareSavedSearchRequest\x12&\n" +
+	"\x0fsaved_search_id\x18\x01 \x01(\tR\rsavedSearchId\x121\n" +
+	"\tprincipal\x18\x02 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\x12\"\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x0e.notes.v1.RoleR\x04role\"v\n" +
+	"\x19UnshareSavedSearchRequest\x12&\n" +
+	"\x0fsaved_search_id\x18\x01 \x01(\tR\rsavedSearchId\x121\n" +
+	"\tprincipal\x18\x02 \x01(\v2\x13.notes.v1.PrincipalR\tprincipal\".\n" +
 	"\x12DeleteNoteResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xbd\x03\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess*i\n" +
+	"\x0fDiffGranularity\x12 \n" +
+	"\x1cDIFF_GRANULARITY_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15DIFF_GRANULARITY_LINE\x10\x01\x12\x19\n" +
+	"\x15DIFF_GRANULARITY_WORD\x10\x02*\x87\x01\n" +
+	"\rNoteEventType\x12\x1f\n" +
+	"\x1bNOTE_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17NOTE_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17NOTE_EVENT_TYPE_UPDATED\x10\x02\x12\x1b\n" +
+	"\x17NOTE_EVENT_TYPE_DELETED\x10\x03*N\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vROLE_VIEWER\x10\x01\x12\x0f\n" +
+	"\vROLE_EDITOR\x10\x02\x12\x0e\n" +
+	"\n" +
+	"ROLE_OWNER\x10\x03*b\n" +
+	"\rPrincipalType\x12\x1e\n" +
+	"\x1aPRINCIPAL_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13PRINCIPAL_TYPE_USER\x10\x01\x12\x18\n" +
+	"\x14PRINCIPAL_TYPE_GROUP\x10\x02*e\n" +
+	"\x0eSuggestionKind\x12\x1f\n" +
+	"\x1bSUGGESTION_KIND_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15SUGGESTION_KIND_TITLE\x10\x01\x12\x17\n" +
+	"\x13SUGGESTION_KIND_TAG\x10\x022\xfd\x19\n" +
 	"\vNoteService\x12;\n" +
 	"\aGetNote\x12\x18.notes.v1.GetNoteRequest\x1a\x16.notes.v1.NoteResponse\x12D\n" +
-	"\tListNotes\x12\x1a.notes.v1.ListNotesRequest\x1a\x1b.notes.v1.ListNotesResponse\x12A\n" +
+	"\tListNotes\x12\x1a.notes.v1.ListNotesRequest\x1a\x1b.notes.v1.ListNotesResponse\x12;\n" +
+	"\vStreamNotes\x12\x1a.notes.v1.ListNotesRequest\x1a\x0e.notes.v1.Note0\x01\x12M\n" +
+	"\fSearchTitles\x12\x1d.notes.v1.SearchTitlesRequest\x1a\x1e.notes.v1.SearchTitlesResponse\x12V\n" +
+	"\x0fGetRelatedNotes\x12 .notes.v1.GetRelatedNotesRequest\x1a!.notes.v1.GetRelatedNotesResponse\x12A\n" +
 	"\n" +
 	"CreateNote\x12\x1b.notes.v1.CreateNoteRequest\x1a\x16.notes.v1.NoteResponse\x12A\n" +
 	"\n" +
 	"UpdateNote\x12\x1b.notes.v1.UpdateNoteRequest\x1a\x16.notes.v1.NoteResponse\x12G\n" +
 	"\n" +
-	"DeleteNote\x12\x1b.notes.v1.DeleteNoteRequest\x1a\x1c.notes.v1.DeleteNoteResponse\x12\\\n" +
-	"\x11ListNoteRevisions\x12\".notes.v1.ListNoteRevisionsRequest\x1a#.notes.v1.ListNoteRevisionsResponseB\x13Z\x11dovakin0007/notesb\x06proto3"
+	"DeleteNote\x12\x1b.notes.v1.DeleteNoteRequest\x1a\x1c.notes.v1.DeleteNoteResponse\x12D\n" +
+	"\tMoveNotes\x12\x1a.notes.v1.MoveNotesRequest\x1a\x1b.notes.v1.MoveNotesResponse\x12M\n" +
+	"\rBatchGetNotes\x12\x1e.notes.v1.BatchGetNotesRequest\x1a\x1c.notes.v1.BatchNotesResponse\x12S\n" +
+	"\x10BatchCreateNotes\x12!.notes.v1.BatchCreateNotesRequest\x1a\x1c.notes.v1.BatchNotesResponse\x12S\n" +
+	"\x10BatchUpdateNotes\x12!.notes.v1.BatchUpdateNotesRequest\x1a\x1c.notes.v1.BatchNotesResponse\x12S\n" +
+	"\x10BatchDeleteNotes\x12!.notes.v1.BatchDeleteNotesRequest\x1a\x1c.notes.v1.BatchNotesResponse\x12D\n" +
+	"\tListTrash\x12\x1a.notes.v1.ListTrashRequest\x1a\x1b.notes.v1.ListNotesResponse\x12C\n" +
+	"\vRestoreNote\x12\x1c.notes.v1.RestoreNoteRequest\x1a\x16.notes.v1.NoteResponse\x12E\n" +
+	"\tPurgeNote\x12\x1a.notes.v1.PurgeNoteRequest\x1a\x1c.notes.v1.DeleteNoteResponse\x12\\\n" +
+	"\x11ListNoteRevisions\x12\".notes.v1.ListNoteRevisionsRequest\x1a#.notes.v1.ListNoteRevisionsResponse\x12S\n" +
+	"\x13RestoreNoteRevision\x12$.notes.v1.RestoreNoteRevisionRequest\x1a\x16.notes.v1.NoteResponse\x12\\\n" +
+	"\x11DiffNoteRevisions\x12\".notes.v1.DiffNoteRevisionsRequest\x1a#.notes.v1.DiffNoteRevisionsResponse\x12M\n" +
+	"\x10UploadAttachment\x12!.notes.v1.UploadAttachmentRequest\x1a\x14.notes.v1.Attachment(\x01\x12a\n" +
+	"\x12DownloadAttachment\x12#.notes.v1.DownloadAttachmentRequest\x1a$.notes.v1.DownloadAttachmentResponse0\x01\x12@\n" +
+	"\n" +
+	"WatchNotes\x12\x1b.notes.v1.WatchNotesRequest\x1a\x13.notes.v1.NoteEvent0\x01\x128\n" +
+	"\tShareNote\x12\x1a.notes.v1.ShareNoteRequest\x1a\x0f.notes.v1.Grant\x12F\n" +
+	"\vUnshareNote\x12\x1c.notes.v1.UnshareNoteRequest\x1a\x19.notes.v1.UnshareResponse\x12O\n" +
+	"\x0eListNoteGrants\x12\x1f.notes.v1.ListNoteGrantsRequest\x1a\x1c.notes.v1.ListGrantsResponse\x12>\n" +
+	"\fShareProject\x12\x1d.notes.v1.ShareProjectRequest\x1a\x0f.notes.v1.Grant\x12L\n" +
+	"\x0eUnshareProject\x12\x1f.notes.v1.UnshareProjectRequest\x1a\x19.notes.v1.UnshareResponse\x12U\n" +
+	"\x11ListProjectGrants\x12\".notes.v1.ListProjectGrantsRequest\x1a\x1c.notes.v1.ListGrantsResponse\x12J\n" +
+	"\rCreateProject\x12\x1e.notes.v1.CreateProjectRequest\x1a\x19.notes.v1.ProjectResponse\x12D\n" +
+	"\n" +
+	"GetProject\x12\x1b.notes.v1.GetProjectRequest\x1a\x19.notes.v1.ProjectResponse\x12M\n" +
+	"\fListProjects\x12\x1d.notes.v1.ListProjectsRequest\x1a\x1e.notes.v1.ListProjectsResponse\x12J\n" +
+	"\rUpdateProject\x12\x1e.notes.v1.UpdateProjectRequest\x1a\x19.notes.v1.ProjectResponse\x12L\n" +
+	"\x0eArchiveProject\x12\x1f.notes.v1.ArchiveProjectRequest\x1a\x19.notes.v1.ProjectResponse\x12A\n" +
+	"\bListTags\x12\x19.notes.v1.ListTagsRequest\x1a\x1a.notes.v1.ListTagsResponse\x12D\n" +
+	"\tRenameTag\x12\x1a.notes.v1.RenameTagRequest\x1a\x1b.notes.v1.TagChangeResponse\x12D\n" +
+	"\tMergeTags\x12\x1a.notes.v1.MergeTagsRequest\x1a\x1b.notes.v1.TagChangeResponse\x12D\n" +
+	"\tDeleteTag\x12\x1a.notes.v1.DeleteTagRequest\x1a\x1b.notes.v1.TagChangeResponse\x12V\n" +
+	"\x11CreateSavedSearch\x12\".notes.v1.CreateSavedSearchRequest\x1a\x1d.notes.v1.SavedSearchResponse\x12\\\n" +
+	"\x11ListSavedSearches\x12\".notes.v1.ListSavedSearchesRequest\x1a#.notes.v1.ListSavedSearchesResponse\x12S\n" +
+	"\x0eRunSavedSearch\x12\x1f.notes.v1.RunSavedSearchRequest\x1a .notes.v1.RunSavedSearchResponse\x12\\\n" +
+	"\x11DeleteSavedSearch\x12\".notes.v1.DeleteSavedSearchRequest\x1a#.notes.v1.DeleteSavedSearchResponse\x12F\n" +
+	"\x10ShareSavedSearch\x12!.notes.v1.ShareSavedSearchRequest\x1a\x0f.notes.v1.Grant\x12T\n" +
+	"\x12UnshareSavedSearch\x12#.notes.v1.UnshareSavedSearchRequest\x1a\x19.notes.v1.UnshareResponseB\x13Z\x11dovakin0007/notesb\x06proto3"
 
 var (
 	file_notes_proto_rawDescOnce sync.Once
@@ -1182,60 +6134,279 @@ func file_notes_proto_rawDescGZIP() []byte {
 	return file_notes_proto_rawDescData
 }
 
-var file_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_notes_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 82)
 var file_notes_proto_goTypes = []any{
-	(*ActorRef)(nil),                  // 0: notes.v1.ActorRef
-	(*Note)(nil),                      // 1: notes.v1.Note
-	(*NoteRevision)(nil),              // 2: notes.v1.NoteRevision
-	(*Attachment)(nil),                // 3: notes.v1.Attachment
-	(*GetNoteRequest)(nil),            // 4: notes.v1.GetNoteRequest
-	(*ListNotesRequest)(nil),          // 5: notes.v1.ListNotesRequest
-	(*CreateNoteRequest)(nil),         // 6: notes.v1.CreateNoteRequest
-	(*UpdateNoteRequest)(nil),         // 7: notes.v1.UpdateNoteRequest
-	(*DeleteNoteRequest)(nil),         // 8: notes.v1.DeleteNoteRequest
-	(*NoteResponse)(nil),              // 9: notes.v1.NoteResponse
-	(*ListNotesResponse)(nil),         // 10: notes.v1.ListNotesResponse
-	(*ListNoteRevisionsRequest)(nil),  // 11: notes.v1.ListNoteRevisionsRequest
-	(*ListNoteRevisionsResponse)(nil), // 12: notes.v1.ListNoteRevisionsResponse
-	(*DeleteNoteResponse)(nil),        // 13: notes.v1.DeleteNoteResponse
-	(*timestamppb.Timestamp)(nil),     // 14: google.protobuf.Timestamp
-	(*fieldmaskpb.FieldMask)(nil),     // 15: google.protobuf.FieldMask
+	(DiffGranularity)(0),               // 0: notes.v1.DiffGranularity
+	(NoteEventType)(0),                 // 1: notes.v1.NoteEventType
+	(Role)(0),                          // 2: notes.v1.Role
+	(PrincipalType)(0),                 // 3: notes.v1.PrincipalType
+	(SuggestionKind)(0),                // 4: notes.v1.SuggestionKind
+	(DiffSpan_Op)(0),                   // 5: notes.v1.DiffSpan.Op
+	(*ActorRef)(nil),                   // 6: notes.v1.ActorRef
+	(*Note)(nil),                       // 7: notes.v1.Note
+	(*NoteRevision)(nil),               // 8: notes.v1.NoteRevision
+	(*Attachment)(nil),                 // 9: notes.v1.Attachment
+	(*UploadAttachmentRequest)(nil),    // 10: notes.v1.UploadAttachmentRequest
+	(*UploadAttachmentMetadata)(nil),   // 11: notes.v1.UploadAttachmentMetadata
+	(*DownloadAttachmentRequest)(nil),  // 12: notes.v1.DownloadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil), // 13: notes.v1.DownloadAttachmentResponse
+	(*GetNoteRequest)(nil),             // 14: notes.v1.GetNoteRequest
+	(*ListNotesRequest)(nil),           // 15: notes.v1.ListNotesRequest
+	(*CreateNoteRequest)(nil),          // 16: notes.v1.CreateNoteRequest
+	(*UpdateNoteRequest)(nil),          // 17: notes.v1.UpdateNoteRequest
+	(*MoveNotesRequest)(nil),           // 18: notes.v1.MoveNotesRequest
+	(*MoveNotesResponse)(nil),          // 19: notes.v1.MoveNotesResponse
+	(*DeleteNoteRequest)(nil),          // 20: notes.v1.DeleteNoteRequest
+	(*ListTrashRequest)(nil),           // 21: notes.v1.ListTrashRequest
+	(*RestoreNoteRequest)(nil),         // 22: notes.v1.RestoreNoteRequest
+	(*PurgeNoteRequest)(nil),           // 23: notes.v1.PurgeNoteRequest
+	(*DuplicateNote)(nil),              // 24: notes.v1.DuplicateNote
+	(*NoteResponse)(nil),               // 25: notes.v1.NoteResponse
+	(*ListNotesResponse)(nil),          // 26: notes.v1.ListNotesResponse
+	(*TextRange)(nil),                  // 27: notes.v1.TextRange
+	(*SearchResult)(nil),               // 28: notes.v1.SearchResult
+	(*ListNoteRevisionsRequest)(nil),   // 29: notes.v1.ListNoteRevisionsRequest
+	(*ListNoteRevisionsResponse)(nil),  // 30: notes.v1.ListNoteRevisionsResponse
+	(*DiffNoteRevisionsRequest)(nil),   // 31: notes.v1.DiffNoteRevisionsRequest
+	(*DiffSpan)(nil),                   // 32: notes.v1.DiffSpan
+	(*DiffHunk)(nil),                   // 33: notes.v1.DiffHunk
+	(*DiffNoteRevisionsResponse)(nil),  // 34: notes.v1.DiffNoteRevisionsResponse
+	(*RestoreNoteRevisionRequest)(nil), // 35: notes.v1.RestoreNoteRevisionRequest
+	(*WatchNotesRequest)(nil),          // 36: notes.v1.WatchNotesRequest
+	(*NoteEvent)(nil),                  // 37: notes.v1.NoteEvent
+	(*Principal)(nil),                  // 38: notes.v1.Principal
+	(*Grant)(nil),                      // 39: notes.v1.Grant
+	(*ShareNoteRequest)(nil),           // 40: notes.v1.ShareNoteRequest
+	(*UnshareNoteRequest)(nil),         // 41: notes.v1.UnshareNoteRequest
+	(*ListNoteGrantsRequest)(nil),      // 42: notes.v1.ListNoteGrantsRequest
+	(*ShareProjectRequest)(nil),        // 43: notes.v1.ShareProjectRequest
+	(*UnshareProjectRequest)(nil),      // 44: notes.v1.UnshareProjectRequest
+	(*ListProjectGrantsRequest)(nil),   // 45: notes.v1.ListProjectGrantsRequest
+	(*ListGrantsResponse)(nil),         // 46: notes.v1.ListGrantsResponse
+	(*UnshareResponse)(nil),            // 47: notes.v1.UnshareResponse
+	(*Project)(nil),                    // 48: notes.v1.Project
+	(*CreateProjectRequest)(nil),       // 49: notes.v1.CreateProjectRequest
+	(*GetProjectRequest)(nil),          // 50: notes.v1.GetProjectRequest
+	(*ListProjectsRequest)(nil),        // 51: notes.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),       // 52: notes.v1.ListProjectsResponse
+	(*UpdateProjectRequest)(nil),       // 53: notes.v1.UpdateProjectRequest
+	(*ArchiveProjectRequest)(nil),      // 54: notes.v1.ArchiveProjectRequest
+	(*ProjectResponse)(nil),            // 55: notes.v1.ProjectResponse
+	(*BatchGetNotesRequest)(nil),       // 56: notes.v1.BatchGetNotesRequest
+	(*BatchCreateNotesRequest)(nil),    // 57: notes.v1.BatchCreateNotesRequest
+	(*BatchUpdateNotesRequest)(nil),    // 58: notes.v1.BatchUpdateNotesRequest
+	(*BatchDeleteNotesRequest)(nil),    // 59: notes.v1.BatchDeleteNotesRequest
+	(*BatchNoteResult)(nil),            // 60: notes.v1.BatchNoteResult
+	(*BatchNotesResponse)(nil),         // 61: notes.v1.BatchNotesResponse
+	(*TagCount)(nil),                   // 62: notes.v1.TagCount
+	(*ListTagsRequest)(nil),            // 63: notes.v1.ListTagsRequest
+	(*ListTagsResponse)(nil),           // 64: notes.v1.ListTagsResponse
+	(*RenameTagRequest)(nil),           // 65: notes.v1.RenameTagRequest
+	(*MergeTagsRequest)(nil),           // 66: notes.v1.MergeTagsRequest
+	(*DeleteTagRequest)(nil),           // 67: notes.v1.DeleteTagRequest
+	(*TagChangeResponse)(nil),          // 68: notes.v1.TagChangeResponse
+	(*SearchTitlesRequest)(nil),        // 69: notes.v1.SearchTitlesRequest
+	(*TitleMatch)(nil),                 // 70: notes.v1.TitleMatch
+	(*Suggestion)(nil),                 // 71: notes.v1.Suggestion
+	(*SearchTitlesResponse)(nil),       // 72: notes.v1.SearchTitlesResponse
+	(*GetRelatedNotesRequest)(nil),     // 73: notes.v1.GetRelatedNotesRequest
+	(*RelatedNote)(nil),                // 74: notes.v1.RelatedNote
+	(*GetRelatedNotesResponse)(nil),    // 75: notes.v1.GetRelatedNotesResponse
+	(*SavedSearch)(nil),                // 76: notes.v1.SavedSearch
+	(*CreateSavedSearchRequest)(nil),   // 77: notes.v1.CreateSavedSearchRequest
+	(*SavedSearchResponse)(nil),        // 78: notes.v1.SavedSearchResponse
+	(*ListSavedSearchesRequest)(nil),   // 79: notes.v1.ListSavedSearchesRequest
+	(*ListSavedSearchesResponse)(nil),  // 80: notes.v1.ListSavedSearchesResponse
+	(*RunSavedSearchRequest)(nil),      // 81: notes.v1.RunSavedSearchRequest
+	(*RunSavedSearchResponse)(nil),     // 82: notes.v1.RunSavedSearchResponse
+	(*DeleteSavedSearchRequest)(nil),   // 83: notes.v1.DeleteSavedSearchRequest
+	(*DeleteSavedSearchResponse)(nil),  // 84: notes.v1.DeleteSavedSearchResponse
+	(*ShareSavedSearchRequest)(nil),    // 85: notes.v1.ShareSavedSearchRequest
+	(*UnshareSavedSearchRequest)(nil),  // 86: notes.v1.UnshareSavedSearchRequest
+	(*DeleteNoteResponse)(nil),         // 87: notes.v1.DeleteNoteResponse
+	(*timestamppb.Timestamp)(nil),      // 88: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),      // 89: google.protobuf.FieldMask
+	(*status.Status)(nil),              // 90: google.rpc.Status
 }
 var file_notes_proto_depIdxs = []int32{
-	0,  // 0: notes.v1.Note.author:type_name -> notes.v1.ActorRef
-	2,  // 1: notes.v1.Note.revisions:type_name -> notes.v1.NoteRevision
-	3,  // 2: notes.v1.Note.attachments:type_name -> notes.v1.Attachment
-	14, // 3: notes.v1.Note.created_at:type_name -> google.protobuf.Timestamp
-	14, // 4: notes.v1.Note.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 5: notes.v1.NoteRevision.editor:type_name -> notes.v1.ActorRef
-	14, // 6: notes.v1.NoteRevision.edited_at:type_name -> google.protobuf.Timestamp
-	14, // 7: notes.v1.Attachment.uploaded_at:type_name -> google.protobuf.Timestamp
-	3,  // 8: notes.v1.CreateNoteRequest.attachments:type_name -> notes.v1.Attachment
-	0,  // 9: notes.v1.CreateNoteRequest.author:type_name -> notes.v1.ActorRef
-	3,  // 10: notes.v1.UpdateNoteRequest.attachments:type_name -> notes.v1.Attachment
-	0,  // 11: notes.v1.UpdateNoteRequest.user:type_name -> notes.v1.ActorRef
-	15, // 12: notes.v1.UpdateNoteRequest.update_mask:type_name -> google.protobuf.FieldMask
-	14, // 13: notes.v1.UpdateNoteRequest.if_match_updated_at:type_name -> google.protobuf.Timestamp
-	1,  // 14: notes.v1.NoteResponse.note:type_name -> notes.v1.Note
-	1,  // 15: notes.v1.ListNotesResponse.notes:type_name -> notes.v1.Note
-	2,  // 16: notes.v1.ListNoteRevisionsResponse.revisions:type_name -> notes.v1.NoteRevision
-	4,  // 17: notes.v1.NoteService.GetNote:input_type -> notes.v1.GetNoteRequest
-	5,  // 18: notes.v1.NoteService.ListNotes:input_type -> notes.v1.ListNotesRequest
-	6,  // 19: notes.v1.NoteService.CreateNote:input_type -> notes.v1.CreateNoteRequest
-	7,  // 20: notes.v1.NoteService.UpdateNote:input_type -> notes.v1.UpdateNoteRequest
-	8,  // 21: notes.v1.NoteService.DeleteNote:input_type -> notes.v1.DeleteNoteRequest
-	11, // 22: notes.v1.NoteService.ListNoteRevisions:input_type -> notes.v1.ListNoteRevisionsRequest
-	9,  // 23: notes.v1.NoteService.GetNote:output_type -> notes.v1.NoteResponse
-	10, // 24: notes.v1.NoteService.ListNotes:output_type -> notes.v1.ListNotesResponse
-	9,  // 25: notes.v1.NoteService.CreateNote:output_type -> notes.v1.NoteResponse
-	9,  // 26: notes.v1.NoteService.UpdateNote:output_type -> notes.v1.NoteResponse
-	13, // 27: notes.v1.NoteService.DeleteNote:output_type -> notes.v1.DeleteNoteResponse
-	12, // 28: notes.v1.NoteService.ListNoteRevisions:output_type -> notes.v1.ListNoteRevisionsResponse
-	23, // [23:29] is the sub-list for method output_type
-	17, // [17:23] is the sub-list for method input_type
-	17, // [17:17] is the sub-list for extension type_name
-	17, // [17:17] is the sub-list for extension extendee
-	0,  // [0:17] is the sub-list for field type_name
+	6,   // 0: notes.v1.Note.author:type_name -> notes.v1.ActorRef
+	8,   // 1: notes.v1.Note.revisions:type_name -> notes.v1.NoteRevision
+	9,   // 2: notes.v1.Note.attachments:type_name -> notes.v1.Attachment
+	88,  // 3: notes.v1.Note.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 4: notes.v1.Note.updated_at:type_name -> google.protobuf.Timestamp
+	88,  // 5: notes.v1.Note.deleted_at:type_name -> google.protobuf.Timestamp
+	6,   // 6: notes.v1.Note.deleted_by:type_name -> notes.v1.ActorRef
+	6,   // 7: notes.v1.NoteRevision.editor:type_name -> notes.v1.ActorRef
+	88,  // 8: notes.v1.NoteRevision.edited_at:type_name -> google.protobuf.Timestamp
+	88,  // 9: notes.v1.Attachment.uploaded_at:type_name -> google.protobuf.Timestamp
+	11,  // 10: notes.v1.UploadAttachmentRequest.metadata:type_name -> notes.v1.UploadAttachmentMetadata
+	6,   // 11: notes.v1.UploadAttachmentMetadata.user:type_name -> notes.v1.ActorRef
+	9,   // 12: notes.v1.DownloadAttachmentResponse.metadata:type_name -> notes.v1.Attachment
+	9,   // 13: notes.v1.CreateNoteRequest.attachments:type_name -> notes.v1.Attachment
+	6,   // 14: notes.v1.CreateNoteRequest.author:type_name -> notes.v1.ActorRef
+	9,   // 15: notes.v1.UpdateNoteRequest.attachments:type_name -> notes.v1.Attachment
+	6,   // 16: notes.v1.UpdateNoteRequest.user:type_name -> notes.v1.ActorRef
+	89,  // 17: notes.v1.UpdateNoteRequest.update_mask:type_name -> google.protobuf.FieldMask
+	88,  // 18: notes.v1.UpdateNoteRequest.if_match_updated_at:type_name -> google.protobuf.Timestamp
+	6,   // 19: notes.v1.MoveNotesRequest.user:type_name -> notes.v1.ActorRef
+	7,   // 20: notes.v1.MoveNotesResponse.notes:type_name -> notes.v1.Note
+	6,   // 21: notes.v1.DeleteNoteRequest.user:type_name -> notes.v1.ActorRef
+	7,   // 22: notes.v1.DuplicateNote.note:type_name -> notes.v1.Note
+	7,   // 23: notes.v1.NoteResponse.note:type_name -> notes.v1.Note
+	24,  // 24: notes.v1.NoteResponse.duplicates:type_name -> notes.v1.DuplicateNote
+	7,   // 25: notes.v1.ListNotesResponse.notes:type_name -> notes.v1.Note
+	28,  // 26: notes.v1.ListNotesResponse.results:type_name -> notes.v1.SearchResult
+	27,  // 27: notes.v1.SearchResult.highlights:type_name -> notes.v1.TextRange
+	8,   // 28: notes.v1.ListNoteRevisionsResponse.revisions:type_name -> notes.v1.NoteRevision
+	0,   // 29: notes.v1.DiffNoteRevisionsRequest.granularity:type_name -> notes.v1.DiffGranularity
+	5,   // 30: notes.v1.DiffSpan.op:type_name -> notes.v1.DiffSpan.Op
+	32,  // 31: notes.v1.DiffHunk.spans:type_name -> notes.v1.DiffSpan
+	33,  // 32: notes.v1.DiffNoteRevisionsResponse.title_hunks:type_name -> notes.v1.DiffHunk
+	33,  // 33: notes.v1.DiffNoteRevisionsResponse.content_hunks:type_name -> notes.v1.DiffHunk
+	6,   // 34: notes.v1.RestoreNoteRevisionRequest.user:type_name -> notes.v1.ActorRef
+	88,  // 35: notes.v1.RestoreNoteRevisionRequest.if_match_updated_at:type_name -> google.protobuf.Timestamp
+	1,   // 36: notes.v1.NoteEvent.type:type_name -> notes.v1.NoteEventType
+	88,  // 37: notes.v1.NoteEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	7,   // 38: notes.v1.NoteEvent.note:type_name -> notes.v1.Note
+	3,   // 39: notes.v1.Principal.type:type_name -> notes.v1.PrincipalType
+	38,  // 40: notes.v1.Grant.principal:type_name -> notes.v1.Principal
+	2,   // 41: notes.v1.Grant.role:type_name -> notes.v1.Role
+	88,  // 42: notes.v1.Grant.created_at:type_name -> google.protobuf.Timestamp
+	38,  // 43: notes.v1.ShareNoteRequest.principal:type_name -> notes.v1.Principal
+	2,   // 44: notes.v1.ShareNoteRequest.role:type_name -> notes.v1.Role
+	38,  // 45: notes.v1.UnshareNoteRequest.principal:type_name -> notes.v1.Principal
+	38,  // 46: notes.v1.ShareProjectRequest.principal:type_name -> notes.v1.Principal
+	2,   // 47: notes.v1.ShareProjectRequest.role:type_name -> notes.v1.Role
+	38,  // 48: notes.v1.UnshareProjectRequest.principal:type_name -> notes.v1.Principal
+	39,  // 49: notes.v1.ListGrantsResponse.grants:type_name -> notes.v1.Grant
+	6,   // 50: notes.v1.Project.owner:type_name -> notes.v1.ActorRef
+	88,  // 51: notes.v1.Project.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 52: notes.v1.Project.updated_at:type_name -> google.protobuf.Timestamp
+	88,  // 53: notes.v1.Project.archived_at:type_name -> google.protobuf.Timestamp
+	6,   // 54: notes.v1.CreateProjectRequest.owner:type_name -> notes.v1.ActorRef
+	48,  // 55: notes.v1.ListProjectsResponse.projects:type_name -> notes.v1.Project
+	89,  // 56: notes.v1.UpdateProjectRequest.update_mask:type_name -> google.protobuf.FieldMask
+	48,  // 57: notes.v1.ProjectResponse.project:type_name -> notes.v1.Project
+	16,  // 58: notes.v1.BatchCreateNotesRequest.requests:type_name -> notes.v1.CreateNoteRequest
+	17,  // 59: notes.v1.BatchUpdateNotesRequest.requests:type_name -> notes.v1.UpdateNoteRequest
+	20,  // 60: notes.v1.BatchDeleteNotesRequest.requests:type_name -> notes.v1.DeleteNoteRequest
+	90,  // 61: notes.v1.BatchNoteResult.status:type_name -> google.rpc.Status
+	7,   // 62: notes.v1.BatchNoteResult.note:type_name -> notes.v1.Note
+	24,  // 63: notes.v1.BatchNoteResult.duplicates:type_name -> notes.v1.DuplicateNote
+	60,  // 64: notes.v1.BatchNotesResponse.results:type_name -> notes.v1.BatchNoteResult
+	62,  // 65: notes.v1.TagCount.children:type_name -> notes.v1.TagCount
+	62,  // 66: notes.v1.ListTagsResponse.tags:type_name -> notes.v1.TagCount
+	7,   // 67: notes.v1.TitleMatch.note:type_name -> notes.v1.Note
+	4,   // 68: notes.v1.Suggestion.kind:type_name -> notes.v1.SuggestionKind
+	70,  // 69: notes.v1.SearchTitlesResponse.matches:type_name -> notes.v1.TitleMatch
+	71,  // 70: notes.v1.SearchTitlesResponse.suggestions:type_name -> notes.v1.Suggestion
+	7,   // 71: notes.v1.RelatedNote.note:type_name -> notes.v1.Note
+	74,  // 72: notes.v1.GetRelatedNotesResponse.notes:type_name -> notes.v1.RelatedNote
+	6,   // 73: notes.v1.SavedSearch.owner:type_name -> notes.v1.ActorRef
+	15,  // 74: notes.v1.SavedSearch.request:type_name -> notes.v1.ListNotesRequest
+	88,  // 75: notes.v1.SavedSearch.created_at:type_name -> google.protobuf.Timestamp
+	88,  // 76: notes.v1.SavedSearch.updated_at:type_name -> google.protobuf.Timestamp
+	15,  // 77: notes.v1.CreateSavedSearchRequest.request:type_name -> notes.v1.ListNotesRequest
+	6,   // 78: notes.v1.CreateSavedSearchRequest.owner:type_name -> notes.v1.ActorRef
+	76,  // 79: notes.v1.SavedSearchResponse.saved_search:type_name -> notes.v1.SavedSearch
+	76,  // 80: notes.v1.ListSavedSearchesResponse.saved_searches:type_name -> notes.v1.SavedSearch
+	7,   // 81: notes.v1.RunSavedSearchResponse.notes:type_name -> notes.v1.Note
+	28,  // 82: notes.v1.RunSavedSearchResponse.results:type_name -> notes.v1.SearchResult
+	38,  // 83: notes.v1.ShareSavedSearchRequest.principal:type_name -> notes.v1.Principal
+	2,   // 84: notes.v1.ShareSavedSearchRequest.role:type_name -> notes.v1.Role
+	38,  // 85: notes.v1.UnshareSavedSearchRequest.principal:type_name -> notes.v1.Principal
+	14,  // 86: notes.v1.NoteService.GetNote:input_type -> notes.v1.GetNoteRequest
+	15,  // 87: notes.v1.NoteService.ListNotes:input_type -> notes.v1.ListNotesRequest
+	15,  // 88: notes.v1.NoteService.StreamNotes:input_type -> notes.v1.ListNotesRequest
+	69,  // 89: notes.v1.NoteService.SearchTitles:input_type -> notes.v1.SearchTitlesRequest
+	73,  // 90: notes.v1.NoteService.GetRelatedNotes:input_type -> notes.v1.GetRelatedNotesRequest
+	16,  // 91: notes.v1.NoteService.CreateNote:input_type -> notes.v1.CreateNoteRequest
+	17,  // 92: notes.v1.NoteService.UpdateNote:input_type -> notes.v1.UpdateNoteRequest
+	20,  // 93: notes.v1.NoteService.DeleteNote:input_type -> notes.v1.DeleteNoteRequest
+	18,  // 94: notes.v1.NoteService.MoveNotes:input_type -> notes.v1.MoveNotesRequest
+	56,  // 95: notes.v1.NoteService.BatchGetNotes:input_type -> notes.v1.BatchGetNotesRequest
+	57,  // 96: notes.v1.NoteService.BatchCreateNotes:input_type -> notes.v1.BatchCreateNotesRequest
+	58,  // 97: notes.v1.NoteService.BatchUpdateNotes:input_type -> notes.v1.BatchUpdateNotesRequest
+	59,  // 98: notes.v1.NoteService.BatchDeleteNotes:input_type -> notes.v1.BatchDeleteNotesRequest
+	21,  // 99: notes.v1.NoteService.ListTrash:input_type -> notes.v1.ListTrashRequest
+	22,  // 100: notes.v1.NoteService.RestoreNote:input_type -> notes.v1.RestoreNoteRequest
+	23,  // 101: notes.v1.NoteService.PurgeNote:input_type -> notes.v1.PurgeNoteRequest
+	29,  // 102: notes.v1.NoteService.ListNoteRevisions:input_type -> notes.v1.ListNoteRevisionsRequest
+	35,  // 103: notes.v1.NoteService.RestoreNoteRevision:input_type -> notes.v1.RestoreNoteRevisionRequest
+	31,  // 104: notes.v1.NoteService.DiffNoteRevisions:input_type -> notes.v1.DiffNoteRevisionsRequest
+	10,  // 105: notes.v1.NoteService.UploadAttachment:input_type -> notes.v1.UploadAttachmentRequest
+	12,  // 106: notes.v1.NoteService.DownloadAttachment:input_type -> notes.v1.DownloadAttachmentRequest
+	36,  // 107: notes.v1.NoteService.WatchNotes:input_type -> notes.v1.WatchNotesRequest
+	40,  // 108: notes.v1.NoteService.ShareNote:input_type -> notes.v1.ShareNoteRequest
+	41,  // 109: notes.v1.NoteService.UnshareNote:input_type -> notes.v1.UnshareNoteRequest
+	42,  // 110: notes.v1.NoteService.ListNoteGrants:input_type -> notes.v1.ListNoteGrantsRequest
+	43,  // 111: notes.v1.NoteService.ShareProject:input_type -> notes.v1.ShareProjectRequest
+	44,  // 112: notes.v1.NoteService.UnshareProject:input_type -> notes.v1.UnshareProjectRequest
+	45,  // 113: notes.v1.NoteService.ListProjectGrants:input_type -> notes.v1.ListProjectGrantsRequest
+	49,  // 114: notes.v1.NoteService.CreateProject:input_type -> notes.v1.CreateProjectRequest
+	50,  // 115: notes.v1.NoteService.GetProject:input_type -> notes.v1.GetProjectRequest
+	51,  // 116: notes.v1.NoteService.ListProjects:input_type -> notes.v1.ListProjectsRequest
+	53,  // 117: notes.v1.NoteService.UpdateProject:input_type -> notes.v1.UpdateProjectRequest
+	54,  // 118: notes.v1.NoteService.ArchiveProject:input_type -> notes.v1.ArchiveProjectRequest
+	63,  // 119: notes.v1.NoteService.ListTags:input_type -> notes.v1.ListTagsRequest
+	65,  // 120: notes.v1.NoteService.RenameTag:input_type -> notes.v1.RenameTagRequest
+	66,  // 121: notes.v1.NoteService.MergeTags:input_type -> notes.v1.MergeTagsRequest
+	67,  // 122: notes.v1.NoteService.DeleteTag:input_type -> notes.v1.DeleteTagRequest
+	77,  // 123: notes.v1.NoteService.CreateSavedSearch:input_type -> notes.v1.CreateSavedSearchRequest
+	79,  // 124: notes.v1.NoteService.ListSavedSearches:input_type -> notes.v1.ListSavedSearchesRequest
+	81,  // 125: notes.v1.NoteService.RunSavedSearch:input_type -> notes.v1.RunSavedSearchRequest
+	83,  // 126: notes.v1.NoteService.DeleteSavedSearch:input_type -> notes.v1.DeleteSavedSearchRequest
+	85,  // 127: notes.v1.NoteService.ShareSavedSearch:input_type -> notes.v1.ShareSavedSearchRequest
+	86,  // 128: notes.v1.NoteService.UnshareSavedSearch:input_type -> notes.v1.UnshareSavedSearchRequest
+	25,  // 129: notes.v1.NoteService.GetNote:output_type -> notes.v1.NoteResponse
+	26,  // 130: notes.v1.NoteService.ListNotes:output_type -> notes.v1.ListNotesResponse
+	7,   // 131: notes.v1.NoteService.StreamNotes:output_type -> notes.v1.Note
+	72,  // 132: notes.v1.NoteService.SearchTitles:output_type -> notes.v1.SearchTitlesResponse
+	75,  // 133: notes.v1.NoteService.GetRelatedNotes:output_type -> notes.v1.GetRelatedNotesResponse
+	25,  // 134: notes.v1.NoteService.CreateNote:output_type -> notes.v1.NoteResponse
+	25,  // 135: notes.v1.NoteService.UpdateNote:output_type -> notes.v1.NoteResponse
+	87,  // 136: notes.v1.NoteService.DeleteNote:output_type -> notes.v1.DeleteNoteResponse
+	19,  // 137: notes.v1.NoteService.MoveNotes:output_type -> notes.v1.MoveNotesResponse
+	61,  // 138: notes.v1.NoteService.BatchGetNotes:output_type -> notes.v1.BatchNotesResponse
+	61,  // 139: notes.v1.NoteService.BatchCreateNotes:output_type -> notes.v1.BatchNotesResponse
+	61,  // 140: notes.v1.NoteService.BatchUpdateNotes:output_type -> notes.v1.BatchNotesResponse
+	61,  // 141: notes.v1.NoteService.BatchDeleteNotes:output_type -> notes.v1.BatchNotesResponse
+	26,  // 142: notes.v1.NoteService.ListTrash:output_type -> notes.v1.ListNotesResponse
+	25,  // 143: notes.v1.NoteService.RestoreNote:output_type -> notes.v1.NoteResponse
+	87,  // 144: notes.v1.NoteService.PurgeNote:output_type -> notes.v1.DeleteNoteResponse
+	30,  // 145: notes.v1.NoteService.ListNoteRevisions:output_type -> notes.v1.ListNoteRevisionsResponse
+	25,  // 146: notes.v1.NoteService.RestoreNoteRevision:output_type -> notes.v1.NoteResponse
+	34,  // 147: notes.v1.NoteService.DiffNoteRevisions:output_type -> notes.v1.DiffNoteRevisionsResponse
+	9,   // 148: notes.v1.NoteService.UploadAttachment:output_type -> notes.v1.Attachment
+	13,  // 149: notes.v1.NoteService.DownloadAttachment:output_type -> notes.v1.DownloadAttachmentResponse
+	37,  // 150: notes.v1.NoteService.WatchNotes:output_type -> notes.v1.NoteEvent
+	39,  // 151: notes.v1.NoteService.ShareNote:output_type -> notes.v1.Grant
+	47,  // 152: notes.v1.NoteService.UnshareNote:output_type -> notes.v1.UnshareResponse
+	46,  // 153: notes.v1.NoteService.ListNoteGrants:output_type -> notes.v1.ListGrantsResponse
+	39,  // 154: notes.v1.NoteService.ShareProject:output_type -> notes.v1.Grant
+	47,  // 155: notes.v1.NoteService.UnshareProject:output_type -> notes.v1.UnshareResponse
+	46,  // 156: notes.v1.NoteService.ListProjectGrants:output_type -> notes.v1.ListGrantsResponse
+	55,  // 157: notes.v1.NoteService.CreateProject:output_type -> notes.v1.ProjectResponse
+	55,  // 158: notes.v1.NoteService.GetProject:output_type -> notes.v1.ProjectResponse
+	52,  // 159: notes.v1.NoteService.ListProjects:output_type -> notes.v1.ListProjectsResponse
+	55,  // 160: notes.v1.NoteService.UpdateProject:output_type -> notes.v1.ProjectResponse
+	55,  // 161: notes.v1.NoteService.ArchiveProject:output_type -> notes.v1.ProjectResponse
+	64,  // 162: notes.v1.NoteService.ListTags:output_type -> notes.v1.ListTagsResponse
+	68,  // 163: notes.v1.NoteService.RenameTag:output_type -> notes.v1.TagChangeResponse
+	68,  // 164: notes.v1.NoteService.MergeTags:output_type -> notes.v1.TagChangeResponse
+	68,  // 165: notes.v1.NoteService.DeleteTag:output_type -> notes.v1.TagChangeResponse
+	78,  // 166: notes.v1.NoteService.CreateSavedSearch:output_type -> notes.v1.SavedSearchResponse
+	80,  // 167: notes.v1.NoteService.ListSavedSearches:output_type -> notes.v1.ListSavedSearchesResponse
+	82,  // 168: notes.v1.NoteService.RunSavedSearch:output_type -> notes.v1.RunSavedSearchResponse
+	84,  // 169: notes.v1.NoteService.DeleteSavedSearch:output_type -> notes.v1.DeleteSavedSearchResponse
+	39,  // 170: notes.v1.NoteService.ShareSavedSearch:output_type -> notes.v1.Grant
+	47,  // 171: notes.v1.NoteService.UnshareSavedSearch:output_type -> notes.v1.UnshareResponse
+	129, // [129:172] is the sub-list for method output_type
+	86,  // [86:129] is the sub-list for method input_type
+	86,  // [86:86] is the sub-list for extension type_name
+	86,  // [86:86] is the sub-list for extension extendee
+	0,   // [0:86] is the sub-list for field type_name
 }
 
 func init() { file_notes_proto_init() }
@@ -1246,22 +6417,48 @@ func file_notes_proto_init() {
 	file_notes_proto_msgTypes[0].OneofWrappers = []any{}
 	file_notes_proto_msgTypes[1].OneofWrappers = []any{}
 	file_notes_proto_msgTypes[3].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[4].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Metadata)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
 	file_notes_proto_msgTypes[5].OneofWrappers = []any{}
-	file_notes_proto_msgTypes[6].OneofWrappers = []any{}
-	file_notes_proto_msgTypes[7].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[7].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Metadata)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
 	file_notes_proto_msgTypes[8].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[9].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[10].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[11].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[12].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[14].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[15].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[25].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[29].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[30].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[31].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[33].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[42].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[43].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[57].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[59].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[60].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[61].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[63].OneofWrappers = []any{}
+	file_notes_proto_msgTypes[71].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notes_proto_rawDesc), len(file_notes_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      6,
+			NumMessages:   82,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_notes_proto_goTypes,
 		DependencyIndexes: file_notes_proto_depIdxs,
+		EnumInfos:         file_notes_proto_enumTypes,
 		MessageInfos:      file_notes_proto_msgTypes,
 	}.Build()
 	File_notes_proto = out.File
@@ -19,12 +19,49 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	NoteService_GetNote_FullMethodName           = "/notes.v1.NoteService/GetNote"
-	NoteService_ListNotes_FullMethodName         = "/notes.v1.NoteService/ListNotes"
-	NoteService_CreateNote_FullMethodName        = "/notes.v1.NoteService/CreateNote"
-	NoteService_UpdateNote_FullMethodName        = "/notes.v1.NoteService/UpdateNote"
-	NoteService_DeleteNote_FullMethodName        = "/notes.v1.NoteService/DeleteNote"
-	NoteService_ListNoteRevisions_FullMethodName = "/notes.v1.NoteService/ListNoteRevisions"
+	NoteService_GetNote_FullMethodName             = "/notes.v1.NoteService/GetNote"
+	NoteService_ListNotes_FullMethodName           = "/notes.v1.NoteService/ListNotes"
+	NoteService_StreamNotes_FullMethodName         = "/notes.v1.NoteService/StreamNotes"
+	NoteService_SearchTitles_FullMethodName        = "/notes.v1.NoteService/SearchTitles"
+	NoteService_GetRelatedNotes_FullMethodName     = "/notes.v1.NoteService/GetRelatedNotes"
+	NoteService_CreateNote_FullMethodName          = "/notes.v1.NoteService/CreateNote"
+	NoteService_UpdateNote_FullMethodName          = "/notes.v1.NoteService/UpdateNote"
+	NoteService_DeleteNote_FullMethodName          = "/notes.v1.NoteService/DeleteNote"
+	NoteService_MoveNotes_FullMethodName           = "/notes.v1.NoteService/MoveNotes"
+	NoteService_BatchGetNotes_FullMethodName       = "/notes.v1.NoteService/BatchGetNotes"
+	NoteService_BatchCreateNotes_FullMethodName    = "/notes.v1.NoteService/BatchCreateNotes"
+	NoteService_BatchUpdateNotes_FullMethodName    = "/notes.v1.NoteService/BatchUpdateNotes"
+	NoteService_BatchDeleteNotes_FullMethodName    = "/notes.v1.NoteService/BatchDeleteNotes"
+	NoteService_ListTrash_FullMethodName           = "/notes.v1.NoteService/ListTrash"
+	NoteService_RestoreNote_FullMethodName         = "/notes.v1.NoteService/RestoreNote"
+	NoteService_PurgeNote_FullMethodName           = "/notes.v1.NoteService/PurgeNote"
+	NoteService_ListNoteRevisions_FullMethodName   = "/notes.v1.NoteService/ListNoteRevisions"
+	NoteService_RestoreNoteRevision_FullMethodName = "/notes.v1.NoteService/RestoreNoteRevision"
+	NoteService_DiffNoteRevisions_FullMethodName   = "/notes.v1.NoteService/DiffNoteRevisions"
+	NoteService_UploadAttachment_FullMethodName    = "/notes.v1.NoteService/UploadAttachment"
+	NoteService_DownloadAttachment_FullMethodName  = "/notes.v1.NoteService/DownloadAttachment"
+	NoteService_WatchNotes_FullMethodName          = "/notes.v1.NoteService/WatchNotes"
+	NoteService_ShareNote_FullMethodName           = "/notes.v1.NoteService/ShareNote"
+	NoteService_UnshareNote_FullMethodName         = "/notes.v1.NoteService/UnshareNote"
+	NoteService_ListNoteGrants_FullMethodName      = "/notes.v1.NoteService/ListNoteGrants"
+	NoteService_ShareProject_FullMethodName        = "/notes.v1.NoteService/ShareProject"
+	NoteService_UnshareProject_FullMethodName      = "/notes.v1.NoteService/UnshareProject"
+	NoteService_ListProjectGrants_FullMethodName   = "/notes.v1.NoteService/ListProjectGrants"
+	NoteService_CreateProject_FullMethodName       = "/notes.v1.NoteService/CreateProject"
+	NoteService_GetProject_FullMethodName          = "/notes.v1.NoteService/GetProject"
+	NoteService_ListProjects_FullMethodName        = "/notes.v1.NoteService/ListProjects"
+	NoteService_UpdateProject_FullMethodName       = "/notes.v1.NoteService/UpdateProject"
+	NoteService_ArchiveProject_FullMethodName      = "/notes.v1.NoteService/ArchiveProject"
+	NoteService_ListTags_FullMethodName            = "/notes.v1.NoteService/ListTags"
+	NoteService_RenameTag_FullMethodName           = "/notes.v1.NoteService/RenameTag"
+	NoteService_MergeTags_FullMethodName           = "/notes.v1.NoteService/MergeTags"
+	NoteService_DeleteTag_FullMethodName           = "/notes.v1.NoteService/DeleteTag"
+	NoteService_CreateSavedSearch_FullMethodName   = "/notes.v1.NoteService/CreateSavedSearch"
+	NoteService_ListSavedSearches_FullMethodName   = "/notes.v1.NoteService/ListSavedSearches"
+	NoteService_RunSavedSearch_FullMethodName      = "/notes.v1.NoteService/RunSavedSearch"
+	NoteService_DeleteSavedSearch_FullMethodName   = "/notes.v1.NoteService/DeleteSavedSearch"
+	NoteService_ShareSavedSearch_FullMethodName    = "/notes.v1.NoteService/ShareSavedSearch"
+	NoteService_UnshareSavedSearch_FullMethodName  = "/notes.v1.NoteService/UnshareSavedSearch"
 )
 
 // NoteServiceClient is the client API for NoteService service.
@@ -33,11 +70,64 @@ const (
 type NoteServiceClient interface {
 	GetNote(ctx context.Context, in *GetNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error)
 	ListNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (*ListNotesResponse, error)
+	// Every note matching the ListNotes filters and sort order, for exports.
+	// page_size is ignored; page_token, if set, starts after that position
+	StreamNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error)
+	// Typo tolerant title search over live notes the caller can read
+	SearchTitles(ctx context.Context, in *SearchTitlesRequest, opts ...grpc.CallOption) (*SearchTitlesResponse, error)
+	// Live notes the caller can read with content similar to a note's
+	GetRelatedNotes(ctx context.Context, in *GetRelatedNotesRequest, opts ...grpc.CallOption) (*GetRelatedNotesResponse, error)
 	CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error)
 	UpdateNote(ctx context.Context, in *UpdateNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error)
 	DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
+	// Needs editor access to every note, to the projects they leave and to the target
+	MoveNotes(ctx context.Context, in *MoveNotesRequest, opts ...grpc.CallOption) (*MoveNotesResponse, error)
+	// At most 500 items each
+	BatchGetNotes(ctx context.Context, in *BatchGetNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error)
+	BatchCreateNotes(ctx context.Context, in *BatchCreateNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error)
+	BatchUpdateNotes(ctx context.Context, in *BatchUpdateNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error)
+	BatchDeleteNotes(ctx context.Context, in *BatchDeleteNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error)
+	// Trash: soft deleted notes, newest deletion first
+	ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListNotesResponse, error)
+	RestoreNote(ctx context.Context, in *RestoreNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error)
+	// Permanently removes a note that is already in the trash
+	PurgeNote(ctx context.Context, in *PurgeNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error)
 	// Optional explicit revisions endpoint
 	ListNoteRevisions(ctx context.Context, in *ListNoteRevisionsRequest, opts ...grpc.CallOption) (*ListNoteRevisionsResponse, error)
+	// Copies a revision back onto the note; the replaced state becomes a new revision
+	RestoreNoteRevision(ctx context.Context, in *RestoreNoteRevisionRequest, opts ...grpc.CallOption) (*NoteResponse, error)
+	DiffNoteRevisions(ctx context.Context, in *DiffNoteRevisionsRequest, opts ...grpc.CallOption) (*DiffNoteRevisionsResponse, error)
+	// Attachment bytes stored by the server
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment], error)
+	DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
+	// Live change feed; resume with the cursor of the last event received
+	WatchNotes(ctx context.Context, in *WatchNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NoteEvent], error)
+	// Sharing. Authors own their notes; project grants apply to every note in
+	// the project. Sharing needs the owner role, listing grants any role
+	ShareNote(ctx context.Context, in *ShareNoteRequest, opts ...grpc.CallOption) (*Grant, error)
+	UnshareNote(ctx context.Context, in *UnshareNoteRequest, opts ...grpc.CallOption) (*UnshareResponse, error)
+	ListNoteGrants(ctx context.Context, in *ListNoteGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error)
+	ShareProject(ctx context.Context, in *ShareProjectRequest, opts ...grpc.CallOption) (*Grant, error)
+	UnshareProject(ctx context.Context, in *UnshareProjectRequest, opts ...grpc.CallOption) (*UnshareResponse, error)
+	ListProjectGrants(ctx context.Context, in *ListProjectGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error)
+	// Projects; the owner can rename and archive, ListProjects hides archived ones by default
+	CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	// Tags; changes rewrite every matching note in one transaction
+	ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*TagChangeResponse, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error)
+	// Saved searches
+	CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearchResponse, error)
+	ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, opts ...grpc.CallOption) (*ListSavedSearchesResponse, error)
+	RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, opts ...grpc.CallOption) (*RunSavedSearchResponse, error)
+	DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...grpc.CallOption) (*DeleteSavedSearchResponse, error)
+	ShareSavedSearch(ctx context.Context, in *ShareSavedSearchRequest, opts ...grpc.CallOption) (*Grant, error)
+	UnshareSavedSearch(ctx context.Context, in *UnshareSavedSearchRequest, opts ...grpc.CallOption) (*UnshareResponse, error)
 }
 
 type noteServiceClient struct {
@@ -68,6 +158,45 @@ func (c *noteServiceClient) ListNotes(ctx context.Context, in *ListNotesRequest,
 	return out, nil
 }
 
+func (c *noteServiceClient) StreamNotes(ctx context.Context, in *ListNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Note], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[0], NoteService_StreamNotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListNotesRequest, Note]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_StreamNotesClient = grpc.ServerStreamingClient[Note]
+
+func (c *noteServiceClient) SearchTitles(ctx context.Context, in *SearchTitlesRequest, opts ...grpc.CallOption) (*SearchTitlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTitlesResponse)
+	err := c.cc.Invoke(ctx, NoteService_SearchTitles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetRelatedNotes(ctx context.Context, in *GetRelatedNotesRequest, opts ...grpc.CallOption) (*GetRelatedNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRelatedNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetRelatedNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *noteServiceClient) CreateNote(ctx context.Context, in *CreateNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(NoteResponse)
@@ -75,203 +204,1362 @@ func (c *noteServiceClient) CreateNote(ctx context.Context, in *CreateNoteReques
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *noteServiceClient) UpdateNote(ctx context.Context, in *UpdateNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_UpdateNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) MoveNotes(ctx context.Context, in *MoveNotesRequest, opts ...grpc.CallOption) (*MoveNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_MoveNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) BatchGetNotes(ctx context.Context, in *BatchGetNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_BatchGetNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) BatchCreateNotes(ctx context.Context, in *BatchCreateNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_BatchCreateNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) BatchUpdateNotes(ctx context.Context, in *BatchUpdateNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_BatchUpdateNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) BatchDeleteNotes(ctx context.Context, in *BatchDeleteNotesRequest, opts ...grpc.CallOption) (*BatchNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_BatchDeleteNotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListTrash(ctx context.Context, in *ListTrashRequest, opts ...grpc.CallOption) (*ListNotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotesResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RestoreNote(ctx context.Context, in *RestoreNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_RestoreNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) PurgeNote(ctx context.Context, in *PurgeNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteNoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_PurgeNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListNoteRevisions(ctx context.Context, in *ListNoteRevisionsRequest, opts ...grpc.CallOption) (*ListNoteRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNoteRevisionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListNoteRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RestoreNoteRevision(ctx context.Context, in *RestoreNoteRevisionRequest, opts ...grpc.CallOption) (*NoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NoteResponse)
+	err := c.cc.Invoke(ctx, NoteService_RestoreNoteRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DiffNoteRevisions(ctx context.Context, in *DiffNoteRevisionsRequest, opts ...grpc.CallOption) (*DiffNoteRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffNoteRevisionsResponse)
+	err := c.cc.Invoke(ctx, NoteService_DiffNoteRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[1], NoteService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, Attachment]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment]
+
+func (c *noteServiceClient) DownloadAttachment(ctx context.Context, in *DownloadAttachmentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[2], NoteService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
+func (c *noteServiceClient) WatchNotes(ctx context.Context, in *WatchNotesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[NoteEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &NoteService_ServiceDesc.Streams[3], NoteService_WatchNotes_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchNotesRequest, NoteEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_WatchNotesClient = grpc.ServerStreamingClient[NoteEvent]
+
+func (c *noteServiceClient) ShareNote(ctx context.Context, in *ShareNoteRequest, opts ...grpc.CallOption) (*Grant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Grant)
+	err := c.cc.Invoke(ctx, NoteService_ShareNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) UnshareNote(ctx context.Context, in *UnshareNoteRequest, opts ...grpc.CallOption) (*UnshareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareResponse)
+	err := c.cc.Invoke(ctx, NoteService_UnshareNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListNoteGrants(ctx context.Context, in *ListNoteGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGrantsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListNoteGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ShareProject(ctx context.Context, in *ShareProjectRequest, opts ...grpc.CallOption) (*Grant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Grant)
+	err := c.cc.Invoke(ctx, NoteService_ShareProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) UnshareProject(ctx context.Context, in *UnshareProjectRequest, opts ...grpc.CallOption) (*UnshareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareResponse)
+	err := c.cc.Invoke(ctx, NoteService_UnshareProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListProjectGrants(ctx context.Context, in *ListProjectGrantsRequest, opts ...grpc.CallOption) (*ListGrantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGrantsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListProjectGrants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) CreateProject(ctx context.Context, in *CreateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, NoteService_CreateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) GetProject(ctx context.Context, in *GetProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, NoteService_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, NoteService_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, NoteService_ArchiveProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListTags(ctx context.Context, in *ListTagsRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagChangeResponse)
+	err := c.cc.Invoke(ctx, NoteService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*TagChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagChangeResponse)
+	err := c.cc.Invoke(ctx, NoteService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*TagChangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TagChangeResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) CreateSavedSearch(ctx context.Context, in *CreateSavedSearchRequest, opts ...grpc.CallOption) (*SavedSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SavedSearchResponse)
+	err := c.cc.Invoke(ctx, NoteService_CreateSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ListSavedSearches(ctx context.Context, in *ListSavedSearchesRequest, opts ...grpc.CallOption) (*ListSavedSearchesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSavedSearchesResponse)
+	err := c.cc.Invoke(ctx, NoteService_ListSavedSearches_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) RunSavedSearch(ctx context.Context, in *RunSavedSearchRequest, opts ...grpc.CallOption) (*RunSavedSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RunSavedSearchResponse)
+	err := c.cc.Invoke(ctx, NoteService_RunSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) DeleteSavedSearch(ctx context.Context, in *DeleteSavedSearchRequest, opts ...grpc.CallOption) (*DeleteSavedSearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteSavedSearchResponse)
+	err := c.cc.Invoke(ctx, NoteService_DeleteSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) ShareSavedSearch(ctx context.Context, in *ShareSavedSearchRequest, opts ...grpc.CallOption) (*Grant, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Grant)
+	err := c.cc.Invoke(ctx, NoteService_ShareSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *noteServiceClient) UnshareSavedSearch(ctx context.Context, in *UnshareSavedSearchRequest, opts ...grpc.CallOption) (*UnshareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnshareResponse)
+	err := c.cc.Invoke(ctx, NoteService_UnshareSavedSearch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NoteServiceServer is the server API for NoteService service.
+// All implementations must embed UnimplementedNoteServiceServer
+// for forward compatibility.
+type NoteServiceServer interface {
+	GetNote(context.Context, *GetNoteRequest) (*NoteResponse, error)
+	ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error)
+	// Every note matching the ListNotes filters and sort order, for exports.
+	// page_size is ignored; page_token, if set, starts after that position
+	StreamNotes(*ListNotesRequest, grpc.ServerStreamingServer[Note]) error
+	// Typo tolerant title search over live notes the caller can read
+	SearchTitles(context.Context, *SearchTitlesRequest) (*SearchTitlesResponse, error)
+	// Live notes the caller can read with content similar to a note's
+	GetRelatedNotes(context.Context, *GetRelatedNotesRequest) (*GetRelatedNotesResponse, error)
+	CreateNote(context.Context, *CreateNoteRequest) (*NoteResponse, error)
+	UpdateNote(context.Context, *UpdateNoteRequest) (*NoteResponse, error)
+	DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
+	// Needs editor access to every note, to the projects they leave and to the target
+	MoveNotes(context.Context, *MoveNotesRequest) (*MoveNotesResponse, error)
+	// At most 500 items each
+	BatchGetNotes(context.Context, *BatchGetNotesRequest) (*BatchNotesResponse, error)
+	BatchCreateNotes(context.Context, *BatchCreateNotesRequest) (*BatchNotesResponse, error)
+	BatchUpdateNotes(context.Context, *BatchUpdateNotesRequest) (*BatchNotesResponse, error)
+	BatchDeleteNotes(context.Context, *BatchDeleteNotesRequest) (*BatchNotesResponse, error)
+	// Trash: soft deleted notes, newest deletion first
+	ListTrash(context.Context, *ListTrashRequest) (*ListNotesResponse, error)
+	RestoreNote(context.Context, *RestoreNoteRequest) (*NoteResponse, error)
+	// Permanently removes a note that is already in the trash
+	PurgeNote(context.Context, *PurgeNoteRequest) (*DeleteNoteResponse, error)
+	// Optional explicit revisions endpoint
+	ListNoteRevisions(context.Context, *ListNoteRevisionsRequest) (*ListNoteRevisionsResponse, error)
+	// Copies a revision back onto the note; the replaced state becomes a new revision
+	RestoreNoteRevision(context.Context, *RestoreNoteRevisionRequest) (*NoteResponse, error)
+	DiffNoteRevisions(context.Context, *DiffNoteRevisionsRequest) (*DiffNoteRevisionsResponse, error)
+	// Attachment bytes stored by the server
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]) error
+	DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
+	// Live change feed; resume with the cursor of the last event received
+	WatchNotes(*WatchNotesRequest, grpc.ServerStreamingServer[NoteEvent]) error
+	// Sharing. Authors own their notes; project grants apply to every note in
+	// the project. Sharing needs the owner role, listing grants any role
+	ShareNote(context.Context, *ShareNoteRequest) (*Grant, error)
+	UnshareNote(context.Context, *UnshareNoteRequest) (*UnshareResponse, error)
+	ListNoteGrants(context.Context, *ListNoteGrantsRequest) (*ListGrantsResponse, error)
+	ShareProject(context.Context, *ShareProjectRequest) (*Grant, error)
+	UnshareProject(context.Context, *UnshareProjectRequest) (*UnshareResponse, error)
+	ListProjectGrants(context.Context, *ListProjectGrantsRequest) (*ListGrantsResponse, error)
+	// Projects; the owner can rename and archive, ListProjects hides archived ones by default
+	CreateProject(context.Context, *CreateProjectRequest) (*ProjectResponse, error)
+	GetProject(context.Context, *GetProjectRequest) (*ProjectResponse, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	UpdateProject(context.Context, *UpdateProjectRequest) (*ProjectResponse, error)
+	ArchiveProject(context.Context, *ArchiveProjectRequest) (*ProjectResponse, error)
+	// Tags; changes rewrite every matching note in one transaction
+	ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error)
+	RenameTag(context.Context, *RenameTagRequest) (*TagChangeResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*TagChangeResponse, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*TagChangeResponse, error)
+	// Saved searches
+	CreateSavedSearch(context.Context, *CreateSavedSearchRequest) (*SavedSearchResponse, error)
+	ListSavedSearches(context.Context, *ListSavedSearchesRequest) (*ListSavedSearchesResponse, error)
+	RunSavedSearch(context.Context, *RunSavedSearchRequest) (*RunSavedSearchResponse, error)
+	DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest) (*DeleteSavedSearchResponse, error)
+	ShareSavedSearch(context.Context, *ShareSavedSearchRequest) (*Grant, error)
+	UnshareSavedSearch(context.Context, *UnshareSavedSearchRequest) (*UnshareResponse, error)
+	mustEmbedUnimplementedNoteServiceServer()
+}
+
+// UnimplementedNoteServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedNoteServiceServer struct{}
+
+func (UnimplementedNoteServiceServer) GetNote(context.Context, *GetNoteRequest) (*NoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNote not implemented")
+}
+func (UnimplementedNoteServiceServer) ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) StreamNotes(*ListNotesRequest, grpc.ServerStreamingServer[Note]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) SearchTitles(context.Context, *SearchTitlesRequest) (*SearchTitlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTitles not implemented")
+}
+func (UnimplementedNoteServiceServer) GetRelatedNotes(context.Context, *GetRelatedNotesRequest) (*GetRelatedNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) CreateNote(context.Context, *CreateNoteRequest) (*NoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNote not implemented")
+}
+func (UnimplementedNoteServiceServer) UpdateNote(context.Context, *UpdateNoteRequest) (*NoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNote not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNote not implemented")
+}
+func (UnimplementedNoteServiceServer) MoveNotes(context.Context, *MoveNotesRequest) (*MoveNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) BatchGetNotes(context.Context, *BatchGetNotesRequest) (*BatchNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) BatchCreateNotes(context.Context, *BatchCreateNotesRequest) (*BatchNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) BatchUpdateNotes(context.Context, *BatchUpdateNotesRequest) (*BatchNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchUpdateNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) BatchDeleteNotes(context.Context, *BatchDeleteNotesRequest) (*BatchNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) ListTrash(context.Context, *ListTrashRequest) (*ListNotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTrash not implemented")
+}
+func (UnimplementedNoteServiceServer) RestoreNote(context.Context, *RestoreNoteRequest) (*NoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreNote not implemented")
+}
+func (UnimplementedNoteServiceServer) PurgeNote(context.Context, *PurgeNoteRequest) (*DeleteNoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeNote not implemented")
+}
+func (UnimplementedNoteServiceServer) ListNoteRevisions(context.Context, *ListNoteRevisionsRequest) (*ListNoteRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNoteRevisions not implemented")
+}
+func (UnimplementedNoteServiceServer) RestoreNoteRevision(context.Context, *RestoreNoteRevisionRequest) (*NoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreNoteRevision not implemented")
+}
+func (UnimplementedNoteServiceServer) DiffNoteRevisions(context.Context, *DiffNoteRevisionsRequest) (*DiffNoteRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffNoteRevisions not implemented")
+}
+func (UnimplementedNoteServiceServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedNoteServiceServer) DownloadAttachment(*DownloadAttachmentRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedNoteServiceServer) WatchNotes(*WatchNotesRequest, grpc.ServerStreamingServer[NoteEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchNotes not implemented")
+}
+func (UnimplementedNoteServiceServer) ShareNote(context.Context, *ShareNoteRequest) (*Grant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareNote not implemented")
+}
+func (UnimplementedNoteServiceServer) UnshareNote(context.Context, *UnshareNoteRequest) (*UnshareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareNote not implemented")
+}
+func (UnimplementedNoteServiceServer) ListNoteGrants(context.Context, *ListNoteGrantsRequest) (*ListGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNoteGrants not implemented")
+}
+func (UnimplementedNoteServiceServer) ShareProject(context.Context, *ShareProjectRequest) (*Grant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareProject not implemented")
+}
+func (UnimplementedNoteServiceServer) UnshareProject(context.Context, *UnshareProjectRequest) (*UnshareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareProject not implemented")
+}
+func (UnimplementedNoteServiceServer) ListProjectGrants(context.Context, *ListProjectGrantsRequest) (*ListGrantsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjectGrants not implemented")
+}
+func (UnimplementedNoteServiceServer) CreateProject(context.Context, *CreateProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (UnimplementedNoteServiceServer) GetProject(context.Context, *GetProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedNoteServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedNoteServiceServer) UpdateProject(context.Context, *UpdateProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedNoteServiceServer) ArchiveProject(context.Context, *ArchiveProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveProject not implemented")
+}
+func (UnimplementedNoteServiceServer) ListTags(context.Context, *ListTagsRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedNoteServiceServer) RenameTag(context.Context, *RenameTagRequest) (*TagChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedNoteServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*TagChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*TagChangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedNoteServiceServer) CreateSavedSearch(context.Context, *CreateSavedSearchRequest) (*SavedSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) ListSavedSearches(context.Context, *ListSavedSearchesRequest) (*ListSavedSearchesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSavedSearches not implemented")
+}
+func (UnimplementedNoteServiceServer) RunSavedSearch(context.Context, *RunSavedSearchRequest) (*RunSavedSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) DeleteSavedSearch(context.Context, *DeleteSavedSearchRequest) (*DeleteSavedSearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) ShareSavedSearch(context.Context, *ShareSavedSearchRequest) (*Grant, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) UnshareSavedSearch(context.Context, *UnshareSavedSearchRequest) (*UnshareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareSavedSearch not implemented")
+}
+func (UnimplementedNoteServiceServer) mustEmbedUnimplementedNoteServiceServer() {}
+func (UnimplementedNoteServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeNoteServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NoteServiceServer will
+// result in compilation errors.
+type UnsafeNoteServiceServer interface {
+	mustEmbedUnimplementedNoteServiceServer()
+}
+
+func RegisterNoteServiceServer(s grpc.ServiceRegistrar, srv NoteServiceServer) {
+	// If the following call pancis, it indicates UnimplementedNoteServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&NoteService_ServiceDesc, srv)
+}
+
+func _NoteService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetNote(ctx, req.(*GetNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListNotes(ctx, req.(*ListNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_StreamNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).StreamNotes(m, &grpc.GenericServerStream[ListNotesRequest, Note]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_StreamNotesServer = grpc.ServerStreamingServer[Note]
+
+func _NoteService_SearchTitles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTitlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).SearchTitles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_SearchTitles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).SearchTitles(ctx, req.(*SearchTitlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetRelatedNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRelatedNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetRelatedNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetRelatedNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetRelatedNotes(ctx, req.(*GetRelatedNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_CreateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).CreateNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_CreateNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).CreateNote(ctx, req.(*CreateNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_UpdateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).UpdateNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_UpdateNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).UpdateNote(ctx, req.(*UpdateNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteNote(ctx, req.(*DeleteNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_MoveNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).MoveNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_MoveNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).MoveNotes(ctx, req.(*MoveNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_BatchGetNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).BatchGetNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_BatchGetNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).BatchGetNotes(ctx, req.(*BatchGetNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_BatchCreateNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).BatchCreateNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_BatchCreateNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).BatchCreateNotes(ctx, req.(*BatchCreateNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_BatchUpdateNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).BatchUpdateNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_BatchUpdateNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).BatchUpdateNotes(ctx, req.(*BatchUpdateNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_BatchDeleteNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteNotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).BatchDeleteNotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_BatchDeleteNotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).BatchDeleteNotes(ctx, req.(*BatchDeleteNotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListTrash(ctx, req.(*ListTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RestoreNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RestoreNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RestoreNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RestoreNote(ctx, req.(*RestoreNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_PurgeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).PurgeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_PurgeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).PurgeNote(ctx, req.(*PurgeNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListNoteRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNoteRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListNoteRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListNoteRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListNoteRevisions(ctx, req.(*ListNoteRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_RestoreNoteRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreNoteRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RestoreNoteRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RestoreNoteRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RestoreNoteRevision(ctx, req.(*RestoreNoteRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_DiffNoteRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffNoteRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DiffNoteRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DiffNoteRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DiffNoteRevisions(ctx, req.(*DiffNoteRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(NoteServiceServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, Attachment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]
+
+func _NoteService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadAttachmentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[DownloadAttachmentRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
+func _NoteService_WatchNotes_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchNotesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NoteServiceServer).WatchNotes(m, &grpc.GenericServerStream[WatchNotesRequest, NoteEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type NoteService_WatchNotesServer = grpc.ServerStreamingServer[NoteEvent]
+
+func _NoteService_ShareNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ShareNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ShareNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ShareNote(ctx, req.(*ShareNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_UnshareNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).UnshareNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_UnshareNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).UnshareNote(ctx, req.(*UnshareNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListNoteGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNoteGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListNoteGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListNoteGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListNoteGrants(ctx, req.(*ListNoteGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ShareProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ShareProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ShareProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ShareProject(ctx, req.(*ShareProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_UnshareProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).UnshareProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_UnshareProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).UnshareProject(ctx, req.(*UnshareProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_ListProjectGrants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectGrantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListProjectGrants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListProjectGrants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListProjectGrants(ctx, req.(*ListProjectGrantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_CreateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).CreateProject(ctx, req.(*CreateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NoteService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).GetProject(ctx, req.(*GetProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *noteServiceClient) UpdateNote(ctx context.Context, in *UpdateNoteRequest, opts ...grpc.CallOption) (*NoteResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(NoteResponse)
-	err := c.cc.Invoke(ctx, NoteService_UpdateNote_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _NoteService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *noteServiceClient) DeleteNote(ctx context.Context, in *DeleteNoteRequest, opts ...grpc.CallOption) (*DeleteNoteResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(DeleteNoteResponse)
-	err := c.cc.Invoke(ctx, NoteService_DeleteNote_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _NoteService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NoteServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).UpdateProject(ctx, req.(*UpdateProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *noteServiceClient) ListNoteRevisions(ctx context.Context, in *ListNoteRevisionsRequest, opts ...grpc.CallOption) (*ListNoteRevisionsResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(ListNoteRevisionsResponse)
-	err := c.cc.Invoke(ctx, NoteService_ListNoteRevisions_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _NoteService_ArchiveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveProjectRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ArchiveProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ArchiveProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ArchiveProject(ctx, req.(*ArchiveProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// NoteServiceServer is the server API for NoteService service.
-// All implementations must embed UnimplementedNoteServiceServer
-// for forward compatibility.
-type NoteServiceServer interface {
-	GetNote(context.Context, *GetNoteRequest) (*NoteResponse, error)
-	ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error)
-	CreateNote(context.Context, *CreateNoteRequest) (*NoteResponse, error)
-	UpdateNote(context.Context, *UpdateNoteRequest) (*NoteResponse, error)
-	DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error)
-	// Optional explicit revisions endpoint
-	ListNoteRevisions(context.Context, *ListNoteRevisionsRequest) (*ListNoteRevisionsResponse, error)
-	mustEmbedUnimplementedNoteServiceServer()
+func _NoteService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).ListTags(ctx, req.(*ListTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedNoteServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedNoteServiceServer struct{}
-
-func (UnimplementedNoteServiceServer) GetNote(context.Context, *GetNoteRequest) (*NoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetNote not implemented")
-}
-func (UnimplementedNoteServiceServer) ListNotes(context.Context, *ListNotesRequest) (*ListNotesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListNotes not implemented")
-}
-func (UnimplementedNoteServiceServer) CreateNote(context.Context, *CreateNoteRequest) (*NoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateNote not implemented")
-}
-func (UnimplementedNoteServiceServer) UpdateNote(context.Context, *UpdateNoteRequest) (*NoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateNote not implemented")
-}
-func (UnimplementedNoteServiceServer) DeleteNote(context.Context, *DeleteNoteRequest) (*DeleteNoteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteNote not implemented")
-}
-func (UnimplementedNoteServiceServer) ListNoteRevisions(context.Context, *ListNoteRevisionsRequest) (*ListNoteRevisionsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListNoteRevisions not implemented")
+func _NoteService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedNoteServiceServer) mustEmbedUnimplementedNoteServiceServer() {}
-func (UnimplementedNoteServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeNoteServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to NoteServiceServer will
-// result in compilation errors.
-type UnsafeNoteServiceServer interface {
-	mustEmbedUnimplementedNoteServiceServer()
+func _NoteService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NoteServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterNoteServiceServer(s grpc.ServiceRegistrar, srv NoteServiceServer) {
-	// If the following call pancis, it indicates UnimplementedNoteServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _NoteService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&NoteService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(NoteServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: NoteService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NoteServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_GetNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNoteRequest)
+func _NoteService_CreateSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).GetNote(ctx, in)
+		return srv.(NoteServiceServer).CreateSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_GetNote_FullMethodName,
+		FullMethod: NoteService_CreateSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).GetNote(ctx, req.(*GetNoteRequest))
+		return srv.(NoteServiceServer).CreateSavedSearch(ctx, req.(*CreateSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_ListNotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListNotesRequest)
+func _NoteService_ListSavedSearches_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSavedSearchesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).ListNotes(ctx, in)
+		return srv.(NoteServiceServer).ListSavedSearches(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_ListNotes_FullMethodName,
+		FullMethod: NoteService_ListSavedSearches_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).ListNotes(ctx, req.(*ListNotesRequest))
+		return srv.(NoteServiceServer).ListSavedSearches(ctx, req.(*ListSavedSearchesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_CreateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateNoteRequest)
+func _NoteService_RunSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).CreateNote(ctx, in)
+		return srv.(NoteServiceServer).RunSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_CreateNote_FullMethodName,
+		FullMethod: NoteService_RunSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).CreateNote(ctx, req.(*CreateNoteRequest))
+		return srv.(NoteServiceServer).RunSavedSearch(ctx, req.(*RunSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_UpdateNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateNoteRequest)
+func _NoteService_DeleteSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).UpdateNote(ctx, in)
+		return srv.(NoteServiceServer).DeleteSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_UpdateNote_FullMethodName,
+		FullMethod: NoteService_DeleteSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).UpdateNote(ctx, req.(*UpdateNoteRequest))
+		return srv.(NoteServiceServer).DeleteSavedSearch(ctx, req.(*DeleteSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_DeleteNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteNoteRequest)
+func _NoteService_ShareSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).DeleteNote(ctx, in)
+		return srv.(NoteServiceServer).ShareSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_DeleteNote_FullMethodName,
+		FullMethod: NoteService_ShareSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).DeleteNote(ctx, req.(*DeleteNoteRequest))
+		return srv.(NoteServiceServer).ShareSavedSearch(ctx, req.(*ShareSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _NoteService_ListNoteRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListNoteRevisionsRequest)
+func _NoteService_UnshareSavedSearch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareSavedSearchRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(NoteServiceServer).ListNoteRevisions(ctx, in)
+		return srv.(NoteServiceServer).UnshareSavedSearch(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: NoteService_ListNoteRevisions_FullMethodName,
+		FullMethod: NoteService_UnshareSavedSearch_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(NoteServiceServer).ListNoteRevisions(ctx, req.(*ListNoteRevisionsRequest))
+		return srv.(NoteServiceServer).UnshareSavedSearch(ctx, req.(*UnshareSavedSearchRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -291,6 +1579,14 @@ var NoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListNotes",
 			Handler:    _NoteService_ListNotes_Handler,
 		},
+		{
+			MethodName: "SearchTitles",
+			Handler:    _NoteService_SearchTitles_Handler,
+		},
+		{
+			MethodName: "GetRelatedNotes",
+			Handler:    _NoteService_GetRelatedNotes_Handler,
+		},
 		{
 			MethodName: "CreateNote",
 			Handler:    _NoteService_CreateNote_Handler,
@@ -303,11 +1599,156 @@ var NoteService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteNote",
 			Handler:    _NoteService_DeleteNote_Handler,
 		},
+		{
+			MethodName: "MoveNotes",
+			Handler:    _NoteService_MoveNotes_Handler,
+		},
+		{
+			MethodName: "BatchGetNotes",
+			Handler:    _NoteService_BatchGetNotes_Handler,
+		},
+		{
+			MethodName: "BatchCreateNotes",
+			Handler:    _NoteService_BatchCreateNotes_Handler,
+		},
+		{
+			MethodName: "BatchUpdateNotes",
+			Handler:    _NoteService_BatchUpdateNotes_Handler,
+		},
+		{
+			MethodName: "BatchDeleteNotes",
+			Handler:    _NoteService_BatchDeleteNotes_Handler,
+		},
+		{
+			MethodName: "ListTrash",
+			Handler:    _NoteService_ListTrash_Handler,
+		},
+		{
+			MethodName: "RestoreNote",
+			Handler:    _NoteService_RestoreNote_Handler,
+		},
+		{
+			MethodName: "PurgeNote",
+			Handler:    _NoteService_PurgeNote_Handler,
+		},
 		{
 			MethodName: "ListNoteRevisions",
 			Handler:    _NoteService_ListNoteRevisions_Handler,
 		},
+		{
+			MethodName: "RestoreNoteRevision",
+			Handler:    _NoteService_RestoreNoteRevision_Handler,
+		},
+		{
+			MethodName: "DiffNoteRevisions",
+			Handler:    _NoteService_DiffNoteRevisions_Handler,
+		},
+		{
+			MethodName: "ShareNote",
+			Handler:    _NoteService_ShareNote_Handler,
+		},
+		{
+			MethodName: "UnshareNote",
+			Handler:    _NoteService_UnshareNote_Handler,
+		},
+		{
+			MethodName: "ListNoteGrants",
+			Handler:    _NoteService_ListNoteGrants_Handler,
+		},
+		{
+			MethodName: "ShareProject",
+			Handler:    _NoteService_ShareProject_Handler,
+		},
+		{
+			MethodName: "UnshareProject",
+			Handler:    _NoteService_UnshareProject_Handler,
+		},
+		{
+			MethodName: "ListProjectGrants",
+			Handler:    _NoteService_ListProjectGrants_Handler,
+		},
+		{
+			MethodName: "CreateProject",
+			Handler:    _NoteService_CreateProject_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _NoteService_GetProject_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _NoteService_ListProjects_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _NoteService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "ArchiveProject",
+			Handler:    _NoteService_ArchiveProject_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _NoteService_ListTags_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _NoteService_RenameTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _NoteService_MergeTags_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _NoteService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "CreateSavedSearch",
+			Handler:    _NoteService_CreateSavedSearch_Handler,
+		},
+		{
+			MethodName: "ListSavedSearches",
+			Handler:    _NoteService_ListSavedSearches_Handler,
+		},
+		{
+			MethodName: "RunSavedSearch",
+			Handler:    _NoteService_RunSavedSearch_Handler,
+		},
+		{
+			MethodName: "DeleteSavedSearch",
+			Handler:    _NoteService_DeleteSavedSearch_Handler,
+		},
+		{
+			MethodName: "ShareSavedSearch",
+			Handler:    _NoteService_ShareSavedSearch_Handler,
+		},
+		{
+			MethodName: "UnshareSavedSearch",
+			Handler:    _NoteService_UnshareSavedSearch_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamNotes",
+			Handler:       _NoteService_StreamNotes_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _NoteService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _NoteService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchNotes",
+			Handler:       _NoteService_WatchNotes_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "notes.proto",
 }